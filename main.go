@@ -8,4 +8,7 @@ import (
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: vault.Provider})
+
+	// Serve returns once Terraform is done with the provider.
+	vault.RevokeTokens()
 }
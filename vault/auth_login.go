@@ -0,0 +1,161 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// authLoginAppRoleSchema is the provider block configuring it to log in
+// with AppRole instead of using a token.
+func authLoginAppRoleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Log in with AppRole to obtain the token of the provider, instead of using token.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"mount": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "approle",
+					Description: "Path of the AppRole auth backend.",
+				},
+				"role_id": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					DefaultFunc: schema.EnvDefaultFunc("VAULT_APPROLE_ROLE_ID", nil),
+					Description: "RoleID of the role to log in with.",
+				},
+				"secret_id": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("VAULT_APPROLE_SECRET_ID", ""),
+					Description: "SecretID to log in with, unless the role doesn't require one.",
+				},
+				"revoke_on_exit": revokeOnExitSchema(),
+			},
+		},
+	}
+}
+
+func revokeOnExitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Revoke the token obtained by logging in, and so its child token, when Terraform is done with the provider.",
+	}
+}
+
+// providerLogin logs in with the auth_login_* block of the provider, if
+// any is set. It returns the token obtained, or an empty one when there is
+// no block, and whether it is to be revoked on exit.
+func providerLogin(d *schema.ResourceData, client *api.Client) (string, bool, error) {
+	var logins []string
+	for _, k := range []string{"auth_login_approle", "auth_login_aws"} {
+		if len(d.Get(k).([]interface{})) > 0 {
+			logins = append(logins, k)
+		}
+	}
+	if len(logins) == 0 {
+		return "", false, nil
+	}
+	if len(logins) > 1 {
+		return "", false, fmt.Errorf("only one of %s can be set", strings.Join(logins, " and "))
+	}
+
+	login := d.Get(logins[0]).([]interface{})[0].(map[string]interface{})
+
+	// Logging in doesn't need a token, and the one from the environment
+	// may well be expired.
+	client.SetToken("")
+
+	var token string
+	var err error
+	switch logins[0] {
+	case "auth_login_approle":
+		token, err = authLoginAppRole(client, login)
+	case "auth_login_aws":
+		token, err = authLoginAWS(client, login)
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return token, login["revoke_on_exit"].(bool), nil
+}
+
+// authLogin logs in to the auth backend at mount with the given data,
+// returning the client token. method names the auth method in errors.
+func authLogin(client *api.Client, method, mount string, data map[string]interface{}) (string, error) {
+	mount = strings.Trim(mount, "/")
+
+	log.Printf("[DEBUG] Logging in to Vault with %s at %q", method, mount)
+	secret, err := client.Logical().Write("auth/"+mount+"/login", data)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in with %s at %q: %s", method, mount, err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return "", fmt.Errorf("failed to log in with %s at %q: no token returned", method, mount)
+	}
+
+	return secret.Auth.ClientToken, nil
+}
+
+// authLoginAppRole logs in to the AppRole auth backend described by the
+// auth_login_approle block, returning the client token.
+func authLoginAppRole(client *api.Client, login map[string]interface{}) (string, error) {
+	data := map[string]interface{}{
+		"role_id": login["role_id"].(string),
+	}
+	if v := login["secret_id"].(string); v != "" {
+		data["secret_id"] = v
+	}
+
+	return authLogin(client, "AppRole", login["mount"].(string), data)
+}
+
+// revokeOnExit holds the clients authenticated with the tokens to revoke
+// once the provider is done, see RevokeTokens.
+var revokeOnExit = struct {
+	sync.Mutex
+	clients []*api.Client
+}{}
+
+// registerTokenRevocation arranges for token to be revoked by RevokeTokens.
+func registerTokenRevocation(config *api.Config, token string) error {
+	client, err := api.NewClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to configure Vault API: %s", err)
+	}
+	client.SetToken(token)
+
+	revokeOnExit.Lock()
+	defer revokeOnExit.Unlock()
+	revokeOnExit.clients = append(revokeOnExit.clients, client)
+	return nil
+}
+
+// RevokeTokens revokes the tokens that the provider logged in with when
+// asked to, along with the child tokens derived from them. It is meant to
+// be called when Terraform stops the plugin, so tokens of a plugin that is
+// killed instead are left to expire.
+func RevokeTokens() {
+	revokeOnExit.Lock()
+	defer revokeOnExit.Unlock()
+
+	for _, client := range revokeOnExit.clients {
+		log.Printf("[DEBUG] Revoking Vault token obtained by logging in")
+		if err := client.Auth().Token().RevokeSelf(""); err != nil {
+			log.Printf("[WARN] Failed to revoke Vault token obtained by logging in: %s", err)
+		}
+	}
+	revokeOnExit.clients = nil
+}
@@ -0,0 +1,114 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// authLoginAWSSchema is the provider block configuring it to log in with
+// the IAM method of the AWS auth backend instead of using a token.
+func authLoginAWSSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Log in with the IAM method of the AWS auth backend to obtain the token of the provider, instead of using token.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"mount": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "aws",
+					Description: "Path of the AWS auth backend.",
+				},
+				"role": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Role of the backend to log in with, by default the name of the IAM principal.",
+				},
+				"header_value": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Value of the X-Vault-AWS-IAM-Server-ID header required by the backend, if any.",
+				},
+				"region": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{"AWS_REGION", "AWS_DEFAULT_REGION"}, "us-east-1"),
+					Description: "Region of the STS endpoint the signed request is sent to by Vault.",
+				},
+				"profile": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Profile of the shared AWS configuration to take credentials from.",
+				},
+				"revoke_on_exit": revokeOnExitSchema(),
+			},
+		},
+	}
+}
+
+// authLoginAWS logs in to the AWS auth backend described by the
+// auth_login_aws block, returning the client token. The credentials are
+// taken from the standard AWS chain: the environment, the shared
+// configuration and the instance or task role.
+func authLoginAWS(client *api.Client, login map[string]interface{}) (string, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			Region: aws.String(login["region"].(string)),
+		},
+		Profile:           login["profile"].(string),
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS credentials: %s", err)
+	}
+
+	data, err := awsIAMLoginData(sess, login["header_value"].(string))
+	if err != nil {
+		return "", err
+	}
+	if v := login["role"].(string); v != "" {
+		data["role"] = v
+	}
+
+	return authLogin(client, "AWS", login["mount"].(string), data)
+}
+
+// awsIAMLoginData signs an sts:GetCallerIdentity request with the
+// credentials of the session, returning it encoded as expected by the IAM
+// login of the AWS auth backend. Vault sends the request to STS itself to
+// learn the identity of the caller, so the credentials never reach it.
+func awsIAMLoginData(sess *session.Session, headerValue string) (map[string]interface{}, error) {
+	req, _ := sts.New(sess).GetCallerIdentityRequest(nil)
+	if headerValue != "" {
+		req.HTTPRequest.Header.Add("X-Vault-AWS-IAM-Server-ID", headerValue)
+	}
+	if err := req.Sign(); err != nil {
+		return nil, fmt.Errorf("failed to sign AWS login request: %s", err)
+	}
+
+	headers, err := json.Marshal(req.HTTPRequest.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode AWS login request headers: %s", err)
+	}
+	body, err := ioutil.ReadAll(req.HTTPRequest.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AWS login request body: %s", err)
+	}
+
+	return map[string]interface{}{
+		"iam_http_request_method": req.HTTPRequest.Method,
+		"iam_request_url":         base64.StdEncoding.EncodeToString([]byte(req.HTTPRequest.URL.String())),
+		"iam_request_headers":     base64.StdEncoding.EncodeToString(headers),
+		"iam_request_body":        base64.StdEncoding.EncodeToString(body),
+	}, nil
+}
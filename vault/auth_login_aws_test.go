@@ -0,0 +1,60 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+func TestAWSIAMLoginData(t *testing.T) {
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", ""),
+		Region:      aws.String("us-east-1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := awsIAMLoginData(sess, "vault.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := data["iam_http_request_method"]; got != "POST" {
+		t.Errorf("method is %q; want POST", got)
+	}
+
+	decode := func(k string) string {
+		raw, err := base64.StdEncoding.DecodeString(data[k].(string))
+		if err != nil {
+			t.Fatalf("%s is not base64-encoded: %s", k, err)
+		}
+		return string(raw)
+	}
+
+	if got := decode("iam_request_url"); got != "https://sts.amazonaws.com/" {
+		t.Errorf("URL is %q", got)
+	}
+	if got := decode("iam_request_body"); !strings.Contains(got, "Action=GetCallerIdentity") {
+		t.Errorf("body is %q", got)
+	}
+
+	var headers http.Header
+	if err := json.Unmarshal([]byte(decode("iam_request_headers")), &headers); err != nil {
+		t.Fatalf("headers are not JSON-encoded: %s", err)
+	}
+	if got := headers.Get("X-Vault-AWS-IAM-Server-ID"); got != "vault.example.com" {
+		t.Errorf("server ID header is %q", got)
+	}
+	// The server ID header must be covered by the signature.
+	auth := headers.Get("Authorization")
+	if !strings.Contains(auth, "Credential=AKIDEXAMPLE/") || !strings.Contains(auth, "x-vault-aws-iam-server-id") {
+		t.Errorf("unexpected Authorization header %q", auth)
+	}
+}
@@ -0,0 +1,98 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestAuthLoginAppRole(t *testing.T) {
+	revoked := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/ci-approle/login":
+			if got := r.Header.Get("X-Vault-Token"); got != "" {
+				t.Errorf("logging in with token %q", got)
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			if body["role_id"] != "role" || body["secret_id"] != "secret" {
+				t.Errorf("unexpected login %v", body)
+			}
+			w.Write([]byte(`{"auth": {"client_token": "login-token"}}`))
+		case "/v1/auth/token/revoke-self":
+			revoked = r.Header.Get("X-Vault-Token")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := api.DefaultConfig()
+	config.Address = server.URL
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetToken("")
+
+	token, err := authLoginAppRole(client, map[string]interface{}{
+		"mount":     "/ci-approle/",
+		"role_id":   "role",
+		"secret_id": "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "login-token" {
+		t.Errorf("token is %q; want %q", token, "login-token")
+	}
+
+	if err := registerTokenRevocation(config, token); err != nil {
+		t.Fatal(err)
+	}
+	RevokeTokens()
+	if revoked != "login-token" {
+		t.Errorf("revoked token %q; want %q", revoked, "login-token")
+	}
+
+	// Tokens are only revoked once.
+	revoked = ""
+	RevokeTokens()
+	if revoked != "" {
+		t.Errorf("token %q revoked again", revoked)
+	}
+
+	if _, err := authLoginAppRole(client, map[string]interface{}{
+		"mount":     "missing",
+		"role_id":   "role",
+		"secret_id": "",
+	}); err == nil {
+		t.Error("expected an error logging in to a missing backend")
+	}
+}
+
+func TestProviderLoginConflict(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, map[string]interface{}{
+		"address": "http://127.0.0.1:8200",
+		"auth_login_approle": []interface{}{
+			map[string]interface{}{"role_id": "role"},
+		},
+		"auth_login_aws": []interface{}{
+			map[string]interface{}{"role": "role"},
+		},
+	})
+
+	_, _, err := providerLogin(d, nil)
+	if err == nil || !strings.Contains(err.Error(), "only one of auth_login_approle and auth_login_aws") {
+		t.Errorf("expected an error for both login blocks, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/mitchellh/go-homedir"
+)
+
+// cliConfig holds the settings of the configuration file of the Vault CLI
+// that are relevant to the provider.
+type cliConfig struct {
+	TokenHelper string `hcl:"token_helper"`
+}
+
+// cliConfigPath returns the path of the configuration file of the Vault
+// CLI, given by VAULT_CONFIG_PATH or ~/.vault by default.
+func cliConfigPath(homePath string) string {
+	if path := os.Getenv("VAULT_CONFIG_PATH"); path != "" {
+		return path
+	}
+	return filepath.Join(homePath, ".vault")
+}
+
+// readCLIConfig reads the configuration file of the Vault CLI at path. A
+// missing file is not an error, as the CLI doesn't need one either.
+func readCLIConfig(path string) (*cliConfig, error) {
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &cliConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading Vault CLI config %q: %s", path, err)
+	}
+
+	var config cliConfig
+	if err := hcl.Decode(&config, string(contents)); err != nil {
+		return nil, fmt.Errorf("error parsing Vault CLI config %q: %s", path, err)
+	}
+	return &config, nil
+}
+
+// tokenFromHelper gets the token stored by an external token helper of the
+// Vault CLI, which prints it when run with the get argument.
+func tokenFromHelper(helper string) (string, error) {
+	if !filepath.IsAbs(helper) {
+		return "", fmt.Errorf("token helper %q must be an absolute path", helper)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(helper, "get")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error running token helper %q: %s: %s", helper, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// cliToken returns the token the Vault CLI would use when VAULT_TOKEN is
+// not set: the one returned by the token_helper of its configuration file,
+// or the one stored in ~/.vault-token otherwise.
+func cliToken() (string, error) {
+	homePath, err := homedir.Dir()
+	if err != nil {
+		return "", fmt.Errorf("Can't find home directory when looking for ~/.vault-token: %s", err)
+	}
+
+	config, err := readCLIConfig(cliConfigPath(homePath))
+	if err != nil {
+		return "", err
+	}
+	if config.TokenHelper != "" {
+		log.Printf("[DEBUG] Getting Vault token from token helper %q", config.TokenHelper)
+		token, err := tokenFromHelper(config.TokenHelper)
+		if err != nil {
+			return "", err
+		}
+		if token == "" {
+			return "", fmt.Errorf("No vault token found: token helper %q returned no token", config.TokenHelper)
+		}
+		return token, nil
+	}
+
+	tokenBytes, err := ioutil.ReadFile(filepath.Join(homePath, ".vault-token"))
+	if err != nil {
+		return "", fmt.Errorf("No vault token found: %s", err)
+	}
+	return strings.TrimSpace(string(tokenBytes)), nil
+}
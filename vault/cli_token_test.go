@@ -0,0 +1,81 @@
+package vault
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+func testCLITokenHome(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "vault-cli-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	home, configPath := os.Getenv("HOME"), os.Getenv("VAULT_CONFIG_PATH")
+	os.Setenv("HOME", dir)
+	os.Unsetenv("VAULT_CONFIG_PATH")
+	homedir.DisableCache = true
+	return dir, func() {
+		homedir.DisableCache = false
+		os.Setenv("HOME", home)
+		os.Setenv("VAULT_CONFIG_PATH", configPath)
+		os.RemoveAll(dir)
+	}
+}
+
+func testCLITokenWrite(t *testing.T, path, contents string, mode os.FileMode) {
+	if err := ioutil.WriteFile(path, []byte(contents), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCLIToken(t *testing.T) {
+	dir, cleanup := testCLITokenHome(t)
+	defer cleanup()
+
+	if _, err := cliToken(); err == nil {
+		t.Fatal("expected error without a token file")
+	}
+
+	testCLITokenWrite(t, filepath.Join(dir, ".vault-token"), "file-token\n", 0600)
+	if token, err := cliToken(); err != nil || token != "file-token" {
+		t.Fatalf("expected file-token, got %q, %v", token, err)
+	}
+
+	helper := filepath.Join(dir, "token-helper")
+	testCLITokenWrite(t, helper, "#!/bin/sh\n[ \"$1\" = get ] && echo helper-token\n", 0700)
+	testCLITokenWrite(t, filepath.Join(dir, ".vault"), `token_helper = "`+helper+`"`, 0600)
+	if token, err := cliToken(); err != nil || token != "helper-token" {
+		t.Fatalf("expected helper-token, got %q, %v", token, err)
+	}
+
+	// VAULT_CONFIG_PATH replaces ~/.vault.
+	os.Setenv("VAULT_CONFIG_PATH", filepath.Join(dir, "missing"))
+	if token, err := cliToken(); err != nil || token != "file-token" {
+		t.Fatalf("expected file-token, got %q, %v", token, err)
+	}
+}
+
+func TestCLIToken_helperErrors(t *testing.T) {
+	dir, cleanup := testCLITokenHome(t)
+	defer cleanup()
+
+	cases := map[string]string{
+		"relative": `token_helper = "token-helper"`,
+		"failing":  `token_helper = "` + filepath.Join(dir, "failing") + `"`,
+		"empty":    `token_helper = "` + filepath.Join(dir, "empty") + `"`,
+		"invalid":  `token_helper = `,
+	}
+	testCLITokenWrite(t, filepath.Join(dir, "failing"), "#!/bin/sh\necho locked >&2\nexit 1\n", 0700)
+	testCLITokenWrite(t, filepath.Join(dir, "empty"), "#!/bin/sh\n", 0700)
+
+	for name, config := range cases {
+		testCLITokenWrite(t, filepath.Join(dir, ".vault"), config, 0600)
+		if token, err := cliToken(); err == nil {
+			t.Errorf("%s: expected error, got token %q", name, token)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// retryBackoff is the delay before the first retry of a transport without
+// a backoff of its own. Each further retry waits twice as long.
+var retryBackoff = 500 * time.Millisecond
+
+// clientConfigs remembers the configuration each provider client was
+// created from, so that clients with other retry settings can be derived
+// from it. The API client doesn't expose its configuration.
+var clientConfigs = struct {
+	sync.Mutex
+	m map[*api.Client]*api.Config
+}{m: map[*api.Client]*api.Config{}}
+
+func registerClientConfig(client *api.Client, config *api.Config) {
+	clientConfigs.Lock()
+	defer clientConfigs.Unlock()
+	clientConfigs.m[client] = config
+}
+
+// maxRetriesSchema and retryOnSchema are the arguments overriding the
+// retry settings of the provider for a single resource.
+func maxRetriesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     -1,
+		Description: "Times to retry failed requests of this resource, 0 to never retry. -1 uses the provider default.",
+	}
+}
+
+func retryOnSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Description: "HTTP status codes of the responses to retry, instead of the ones of the provider.",
+	}
+}
+
+// resourceRetryClient returns the client to use for the requests of a
+// resource with the max_retries and retry_on arguments. Without a policy of
+// its own, this is the provider client.
+func resourceRetryClient(d *schema.ResourceData, client *api.Client) (*api.Client, error) {
+	maxRetries := d.Get("max_retries").(int)
+	retryOnI := d.Get("retry_on").(*schema.Set).List()
+	if maxRetries < 0 && len(retryOnI) == 0 {
+		return client, nil
+	}
+
+	clientConfigs.Lock()
+	config, ok := clientConfigs.m[client]
+	clientConfigs.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no configuration known for the Vault client, retry settings can't be applied")
+	}
+
+	// The policy of the resource replaces the parts of the provider policy
+	// it sets, the rest is kept.
+	provider, ok := config.HttpClient.Transport.(*retryTransport)
+	if !ok {
+		return nil, fmt.Errorf("no retry policy known for the Vault client, retry settings can't be applied")
+	}
+	transport := *provider
+	if maxRetries >= 0 {
+		transport.maxRetries = maxRetries
+	}
+	if len(retryOnI) > 0 {
+		transport.retryOn = make(map[int]bool, len(retryOnI))
+		for _, code := range retryOnI {
+			transport.retryOn[code.(int)] = true
+		}
+	}
+
+	// As for the provider client, the retries of the API client are
+	// disabled, so that requests are only retried by the transport.
+	derived, err := api.NewClient(&api.Config{
+		Address: config.Address,
+		HttpClient: &http.Client{
+			Transport: &transport,
+			Timeout:   config.HttpClient.Timeout,
+		},
+		MaxRetries: 0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating Vault client with retry settings: %s", err)
+	}
+	derived.SetToken(client.Token())
+
+	return derived, nil
+}
+
+// retryTransport retries failed requests up to maxRetries times. Requests
+// failing without a response are always retried, and responses are retried
+// when their status is in retryOn, or is any 5xx status if retryOn is empty.
+// The wait before each retry doubles from backoff, up to maxBackoff if set.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	retryOn    map[int]bool
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+func (t *retryTransport) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if len(t.retryOn) == 0 {
+		return resp.StatusCode >= 500
+	}
+	return t.retryOn[resp.StatusCode]
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempt := req
+	for i := 0; ; i++ {
+		resp, err := t.base.RoundTrip(attempt)
+		if i >= t.maxRetries || !t.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		retry, ok := rewindRequest(req)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		wait := t.wait(i, resp)
+		log.Printf("[DEBUG] Retrying request to %s in %s, attempt %d of %d", req.URL.Path, wait, i+2, t.maxRetries+1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+		attempt = retry
+	}
+}
+
+// wait returns the delay before the retry following the given number of
+// retries. Responses can ask for a delay of their own with a Retry-After
+// header in seconds, as Vault does when rate limiting requests.
+func (t *retryTransport) wait(retries int, resp *http.Response) time.Duration {
+	backoff := t.backoff
+	if backoff <= 0 {
+		backoff = retryBackoff
+	}
+	wait := backoff << uint(retries)
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+			wait = time.Duration(s) * time.Second
+		}
+	}
+	if t.maxBackoff > 0 && (wait > t.maxBackoff || wait < 0) {
+		wait = t.maxBackoff
+	}
+	return wait
+}
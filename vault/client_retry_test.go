@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	defer func(backoff time.Duration) { retryBackoff = backoff }(retryBackoff)
+	retryBackoff = time.Millisecond
+
+	cases := []struct {
+		name       string
+		status     int
+		maxRetries int
+		retryOn    map[int]bool
+		requests   int
+	}{
+		{"5xx retried by default", http.StatusServiceUnavailable, 2, nil, 3},
+		{"4xx not retried by default", http.StatusTooManyRequests, 2, nil, 1},
+		{"listed status retried", http.StatusTooManyRequests, 1, map[int]bool{429: true}, 2},
+		{"unlisted 5xx not retried", http.StatusInternalServerError, 2, map[int]bool{429: true}, 1},
+		{"no retries", http.StatusServiceUnavailable, 0, nil, 1},
+		{"success not retried", http.StatusOK, 2, nil, 1},
+	}
+
+	for _, c := range cases {
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(c.status)
+		}))
+
+		client := &http.Client{Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: c.maxRetries,
+			retryOn:    c.retryOn,
+		}}
+		req, err := http.NewRequest("PUT", server.URL+"/v1/secret/foo", strings.NewReader(`{"zip":"zap"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		server.Close()
+
+		if resp.StatusCode != c.status {
+			t.Errorf("%s: got status %d; want %d", c.name, resp.StatusCode, c.status)
+		}
+		if requests != c.requests {
+			t.Errorf("%s: got %d requests; want %d", c.name, requests, c.requests)
+		}
+	}
+}
+
+func TestRetryTransportWait(t *testing.T) {
+	cases := []struct {
+		name       string
+		backoff    time.Duration
+		maxBackoff time.Duration
+		retries    int
+		retryAfter string
+		wait       time.Duration
+	}{
+		{"first retry", time.Second, 30 * time.Second, 0, "", time.Second},
+		{"doubled", time.Second, 30 * time.Second, 2, "", 4 * time.Second},
+		{"capped", time.Second, 30 * time.Second, 10, "", 30 * time.Second},
+		{"uncapped", time.Second, 0, 6, "", 64 * time.Second},
+		{"retry after", time.Second, 30 * time.Second, 0, "5", 5 * time.Second},
+		{"retry after capped", time.Second, 30 * time.Second, 0, "120", 30 * time.Second},
+		{"invalid retry after", time.Second, 30 * time.Second, 1, "soon", 2 * time.Second},
+		{"default backoff", 0, 0, 1, "", 2 * retryBackoff},
+	}
+
+	for _, c := range cases {
+		transport := &retryTransport{backoff: c.backoff, maxBackoff: c.maxBackoff}
+		resp := &http.Response{Header: http.Header{}}
+		if c.retryAfter != "" {
+			resp.Header.Set("Retry-After", c.retryAfter)
+		}
+		if wait := transport.wait(c.retries, resp); wait != c.wait {
+			t.Errorf("%s: got wait %s; want %s", c.name, wait, c.wait)
+		}
+	}
+}
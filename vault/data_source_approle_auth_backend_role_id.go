@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func approleAuthBackendRoleIDDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: approleAuthBackendRoleIDDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "approle",
+				Description: "Path of the AppRole auth backend.",
+			},
+
+			"role_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the role.",
+			},
+
+			"role_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RoleID of the role.",
+			},
+		},
+	}
+}
+
+func approleAuthBackendRoleIDDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := approleAuthBackendRolePath(d.Get("backend").(string), d.Get("role_name").(string)) + "/role-id"
+
+	log.Printf("[DEBUG] Reading AppRole RoleID from %q", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading AppRole RoleID from %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no AppRole role found at %q", path)
+	}
+
+	d.SetId(path)
+	d.Set("role_id", secret.Data["role_id"])
+
+	return nil
+}
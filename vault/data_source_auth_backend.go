@@ -0,0 +1,88 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func authBackendDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: authBackendDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path the auth backend is enabled at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the auth backend.",
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the auth backend.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The accessor of the auth backend.",
+			},
+
+			"default_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Default lease duration in seconds of the tokens issued by the backend.",
+			},
+
+			"max_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum lease duration in seconds of the tokens issued by the backend.",
+			},
+		},
+	}
+}
+
+func authBackendDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Reading auth backend %q", path)
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("error reading auth backends from Vault: %s", err)
+	}
+	auth, ok := auths[path+"/"]
+	if !ok {
+		return fmt.Errorf("no auth backend enabled at %q", path)
+	}
+
+	accessor, err := authBackendAccessor(client, path)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(path)
+	d.Set("path", path)
+	d.Set("type", auth.Type)
+	d.Set("description", auth.Description)
+	d.Set("accessor", accessor)
+	d.Set("default_lease_ttl_seconds", auth.Config.DefaultLeaseTTL)
+	d.Set("max_lease_ttl_seconds", auth.Config.MaxLeaseTTL)
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceAuthBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("approle")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceAuthBackendConfig(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vault_auth_backend.test", "path", path),
+					resource.TestCheckResourceAttr("data.vault_auth_backend.test", "type", "approle"),
+					resource.TestCheckResourceAttr("data.vault_auth_backend.test", "description", "Test backend"),
+					resource.TestCheckResourceAttr("data.vault_auth_backend.test", "default_lease_ttl_seconds", "3600"),
+					resource.TestCheckResourceAttrPair(
+						"data.vault_auth_backend.test", "accessor",
+						"vault_auth_backend.test", "accessor",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceAuthBackend_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "vault_auth_backend" "test" {
+  path = "does-not-exist"
+}
+`,
+				ExpectError: regexp.MustCompile(`no auth backend enabled at "does-not-exist"`),
+			},
+		},
+	})
+}
+
+func testDataSourceAuthBackendConfig(path string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type                      = "approle"
+  path                      = %q
+  description               = "Test backend"
+  default_lease_ttl_seconds = 3600
+}
+
+data "vault_auth_backend" "test" {
+  path = "${vault_auth_backend.test.path}"
+}
+`, path)
+}
@@ -0,0 +1,132 @@
+package vault
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func authBackendsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read:   authBackendsDataSourceRead,
+		Schema: mountListingSchema("auth backends"),
+	}
+}
+
+func authBackendsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	return mountListingRead(d, meta.(*api.Client), "sys/auth")
+}
+
+// mountListingSchema is the schema of the data sources listing the auth
+// backends or the secret mounts, which are described the same way.
+func mountListingSchema(kind string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Only list the %s of this type.", kind),
+		},
+
+		"paths": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("Paths of the %s, sorted.", kind),
+		},
+
+		"types": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("Types of the %s, in the same order as the paths.", kind),
+		},
+
+		"accessors": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("Accessors of the %s, in the same order as the paths.", kind),
+		},
+
+		"accessors_by_path": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: fmt.Sprintf("Accessors of the %s by path.", kind),
+		},
+	}
+}
+
+type mountListingEntry struct {
+	Path     string
+	Type     string
+	Accessor string
+}
+
+// mountListing returns the entries of a listing of sys/auth or sys/mounts,
+// sorted by path. The output of the API client doesn't include accessors,
+// so the raw listing is read.
+func mountListing(client *api.Client, path string) ([]mountListingEntry, error) {
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, nil
+	}
+
+	var entries []mountListingEntry
+	for k, v := range secret.Data {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		entry := mountListingEntry{Path: strings.TrimSuffix(k, "/")}
+		entry.Type, _ = m["type"].(string)
+		entry.Accessor, _ = m["accessor"].(string)
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+
+	return entries, nil
+}
+
+func mountListingRead(d *schema.ResourceData, client *api.Client, path string) error {
+	entries, err := mountListing(client, path)
+	if err != nil {
+		return fmt.Errorf("error listing %q: %s", path, err)
+	}
+
+	filter := d.Get("type").(string)
+	paths := []string{}
+	types := []string{}
+	accessors := []string{}
+	byPath := map[string]interface{}{}
+	for _, entry := range entries {
+		if filter != "" && entry.Type != filter {
+			continue
+		}
+		paths = append(paths, entry.Path)
+		types = append(types, entry.Type)
+		accessors = append(accessors, entry.Accessor)
+		byPath[entry.Path] = entry.Accessor
+	}
+
+	d.SetId(path)
+	for k, v := range map[string]interface{}{
+		"paths":             paths,
+		"types":             types,
+		"accessors":         accessors,
+		"accessors_by_path": byPath,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s of %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceAuthBackends(t *testing.T) {
+	path := acctest.RandomWithPrefix("approle")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceAuthBackendsConfig(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vault_auth_backends.test", "paths.#", "1"),
+					resource.TestCheckResourceAttr("data.vault_auth_backends.test", "paths.0", path),
+					resource.TestCheckResourceAttr("data.vault_auth_backends.test", "types.0", "approle"),
+					resource.TestCheckResourceAttrPair(
+						"data.vault_auth_backends.test", "accessors.0",
+						"vault_auth_backend.test", "accessor",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.vault_auth_backends.test", "accessors_by_path."+path,
+						"vault_auth_backend.test", "accessor",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceAuthBackendsConfig(path string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "approle"
+  path = %q
+}
+
+data "vault_auth_backends" "test" {
+  type = "${vault_auth_backend.test.type}"
+}
+`, path)
+}
@@ -0,0 +1,198 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// awsAccessCredentialsTimeout bounds the wait for new IAM credentials to be
+// usable. IAM is eventually consistent, so access keys created by Vault can
+// be rejected for a while, and even intermittently after a first success,
+// hence the several successful calls in a row required.
+const (
+	awsAccessCredentialsTimeout   = 2 * time.Minute
+	awsAccessCredentialsSuccesses = 3
+)
+
+func awsAccessCredentialsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: awsAccessCredentialsDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "aws",
+				Description: "Path of the AWS secret backend.",
+			},
+
+			"role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the role to generate the credentials with.",
+			},
+
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "creds",
+				Description: "Endpoint generating the credentials: creds, or sts for an STS token of an iam_user role.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					switch v.(string) {
+					case "creds", "sts":
+					default:
+						errs = append(errs, fmt.Errorf("%s must be either creds or sts, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"role_arn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ARN of the IAM role to assume, when the role allows several.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "TTL in seconds of STS credentials, the default of the role if 0.",
+			},
+
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"AWS_REGION", "AWS_DEFAULT_REGION"}, "us-east-1"),
+				Description: "Region of the STS endpoint the credentials are checked against.",
+			},
+
+			"access_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AWS access key ID.",
+			},
+
+			"secret_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "AWS secret access key.",
+			},
+
+			"security_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "AWS session token of STS credentials.",
+			},
+
+			"lease_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Lease identifier of the credentials.",
+			},
+
+			"lease_duration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Lease duration in seconds of the credentials, relative to lease_start_time.",
+			},
+
+			"lease_start_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the credentials were generated, in RFC 3339 format.",
+			},
+
+			"lease_renewable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the lease of the credentials can be renewed.",
+			},
+		},
+	}
+}
+
+func awsAccessCredentialsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("backend").(string), "/") + "/" + d.Get("type").(string) + "/" + strings.Trim(d.Get("role").(string), "/")
+
+	query := map[string]interface{}{}
+	if v, ok := d.GetOk("role_arn"); ok {
+		query["role_arn"] = v.(string)
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		query["ttl"] = fmt.Sprintf("%ds", v.(int))
+	}
+
+	log.Printf("[DEBUG] Generating AWS credentials with %q", path)
+	secret, err := readWithQuery(client, path, query)
+	if err != nil {
+		return fmt.Errorf("error generating AWS credentials with %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no AWS credentials returned by %q", path)
+	}
+
+	accessKey, _ := secret.Data["access_key"].(string)
+	secretKey, _ := secret.Data["secret_key"].(string)
+	securityToken, _ := secret.Data["security_token"].(string)
+
+	if err := awsAccessCredentialsWait(d.Get("region").(string), accessKey, secretKey, securityToken); err != nil {
+		return err
+	}
+
+	d.SetId(secret.LeaseID)
+	d.Set("access_key", accessKey)
+	d.Set("secret_key", secretKey)
+	d.Set("security_token", securityToken)
+	d.Set("lease_id", secret.LeaseID)
+	d.Set("lease_duration", secret.LeaseDuration)
+	d.Set("lease_start_time", time.Now().UTC().Format(time.RFC3339))
+	d.Set("lease_renewable", secret.Renewable)
+
+	return nil
+}
+
+// awsAccessCredentialsWait waits until the credentials are accepted by STS,
+// so that they can be used right away by other providers.
+func awsAccessCredentialsWait(region, accessKey, secretKey, securityToken string) error {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, securityToken),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating AWS session: %s", err)
+	}
+	svc := sts.New(sess)
+
+	successes := 0
+	log.Printf("[DEBUG] Waiting for AWS access key %q to be usable", accessKey)
+	err = resource.Retry(awsAccessCredentialsTimeout, func() *resource.RetryError {
+		if _, err := svc.GetCallerIdentity(&sts.GetCallerIdentityInput{}); err != nil {
+			successes = 0
+			return resource.RetryableError(err)
+		}
+		successes++
+		if successes < awsAccessCredentialsSuccesses {
+			return resource.RetryableError(fmt.Errorf("AWS access key %q accepted %d times in a row", accessKey, successes))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("AWS access key %q not usable after %s: %s", accessKey, awsAccessCredentialsTimeout, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceAWSAccessCredentials(t *testing.T) {
+	// Vault creates an IAM user for the credentials, so this needs real AWS
+	// credentials allowed to manage users.
+	accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		t.Skip("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY not set")
+	}
+
+	backend := acctest.RandomWithPrefix("aws")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceAWSAccessCredentialsConfig(backend, accessKey, secretKey, "creds"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vault_aws_access_credentials.test", "access_key"),
+					resource.TestCheckResourceAttrSet("data.vault_aws_access_credentials.test", "secret_key"),
+					resource.TestCheckResourceAttr("data.vault_aws_access_credentials.test", "security_token", ""),
+					resource.TestCheckResourceAttrSet("data.vault_aws_access_credentials.test", "lease_id"),
+				),
+			},
+			{
+				Config: testDataSourceAWSAccessCredentialsConfig(backend, accessKey, secretKey, "sts"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vault_aws_access_credentials.test", "access_key"),
+					resource.TestCheckResourceAttrSet("data.vault_aws_access_credentials.test", "security_token"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceAWSAccessCredentialsConfig(backend, accessKey, secretKey, credsType string) string {
+	return fmt.Sprintf(`
+resource "vault_aws_secret_backend" "test" {
+	path = "%s"
+	access_key = "%s"
+	secret_key = "%s"
+}
+
+resource "vault_aws_secret_backend_role" "test" {
+	backend = "${vault_aws_secret_backend.test.path}"
+	name = "test"
+	credential_type = "iam_user"
+	policy_document = <<EOT
+{
+  "Version": "2012-10-17",
+  "Statement": [{"Effect": "Allow", "Action": "sts:GetCallerIdentity", "Resource": "*"}]
+}
+EOT
+}
+
+data "vault_aws_access_credentials" "test" {
+	backend = "${vault_aws_secret_backend.test.path}"
+	role = "${vault_aws_secret_backend_role.test.name}"
+	type = "%s"
+}
+`, backend, accessKey, secretKey, credsType)
+}
@@ -0,0 +1,65 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func awsStaticAccessCredentialsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: awsStaticAccessCredentialsDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "aws",
+				Description: "Path of the AWS secret backend.",
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the static role.",
+			},
+
+			"access_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current AWS access key ID of the user.",
+			},
+
+			"secret_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Current AWS secret access key of the user.",
+			},
+		},
+	}
+}
+
+func awsStaticAccessCredentialsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("backend").(string), "/") + "/static-creds/" + strings.Trim(d.Get("name").(string), "/")
+
+	log.Printf("[DEBUG] Reading AWS static credentials from %q", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading AWS static credentials from %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no AWS static credentials found at %q", path)
+	}
+
+	d.SetId(path)
+	d.Set("access_key", secret.Data["access_key"])
+	d.Set("secret_key", secret.Data["secret_key"])
+
+	return nil
+}
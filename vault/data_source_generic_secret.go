@@ -22,6 +22,25 @@ func genericSecretDataSource() *schema.Resource {
 				Description: "Full path from which a secret will be read.",
 			},
 
+			"read_query": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Query parameters added to the read request, e.g. version.",
+			},
+
+			"wrapping_ttl": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Wrap the secret in a token valid for this duration, e.g. 60s, instead of reading its data.",
+			},
+
+			"wrapping_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token wrapping the secret, when wrapping_ttl is set.",
+			},
+
 			"data_json": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -34,6 +53,12 @@ func genericSecretDataSource() *schema.Resource {
 				Description: "Map of strings read from Vault.",
 			},
 
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the secret read, for secrets in KV version 2 mounts.",
+			},
+
 			"lease_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -66,12 +91,21 @@ func genericSecretDataSourceRead(d *schema.ResourceData, meta interface{}) error
 
 	path := d.Get("path").(string)
 
+	// Secrets in KV version 2 mounts are read from their data path, and
+	// unwrapped, like in vault_generic_secret.
+	mount := genericSecretKVMount(client, "", path)
+
+	if wrapTTL, ok := d.GetOk("wrapping_ttl"); ok {
+		return genericSecretDataSourceReadWrapped(d, client, mount.dataPath(path), wrapTTL.(string))
+	}
+
 	log.Printf("[DEBUG] Reading %s from Vault", path)
-	secret, err := client.Logical().Read(path)
+	secret, err := readWithQuery(client, mount.dataPath(path), d.Get("read_query").(map[string]interface{}))
 	if err != nil {
 		return fmt.Errorf("error reading from Vault: %s", err)
 	}
-	if secret == nil {
+	data, version := kvSecretData(mount, secret)
+	if secret == nil || (mount.Version == 2 && data == nil) {
 		return fmt.Errorf("No secret found at %q", path)
 	}
 
@@ -79,31 +113,44 @@ func genericSecretDataSourceRead(d *schema.ResourceData, meta interface{}) error
 
 	// Ignoring error because this value came from JSON in the
 	// first place so no reason why it should fail to re-encode.
-	jsonDataBytes, _ := json.Marshal(secret.Data)
+	jsonDataBytes, _ := json.Marshal(data)
 	d.Set("data_json", string(jsonDataBytes))
 
-	// Since our "data" map can only contain string values, we
-	// will take strings from Data and write them in as-is,
-	// and write everything else in as a JSON serialization of
-	// whatever value we get so that complex types can be
-	// passed around and processed elsewhere if desired.
-	dataMap := map[string]string{}
-	for k, v := range secret.Data {
-		if vs, ok := v.(string); ok {
-			dataMap[k] = vs
-		} else {
-			// Again ignoring error because we know this value
-			// came from JSON in the first place and so must be valid.
-			vBytes, _ := json.Marshal(v)
-			dataMap[k] = string(vBytes)
-		}
-	}
-	d.Set("data", dataMap)
+	d.Set("data", secretDataStringMap(data))
+	d.Set("version", version)
 
 	d.Set("lease_id", secret.LeaseID)
 	d.Set("lease_duration", secret.LeaseDuration)
-	d.Set("lease_start_time", time.Now().Format("RFC3339"))
+	d.Set("lease_start_time", time.Now().Format(time.RFC3339))
 	d.Set("lease_renewable", secret.Renewable)
 
 	return nil
 }
+
+// genericSecretDataSourceReadWrapped reads the secret at path wrapped in a
+// token, so that it can be handed off without Terraform ever knowing its
+// data. Each read wraps a new response, e.g. with new credentials for
+// dynamic secrets.
+func genericSecretDataSourceReadWrapped(d *schema.ResourceData, client *api.Client, path, wrapTTL string) error {
+	log.Printf("[DEBUG] Reading %s from Vault wrapped in a token", path)
+	secret, err := readWrapped(client, path, d.Get("read_query").(map[string]interface{}), wrapTTL)
+	if err != nil {
+		return fmt.Errorf("error reading from Vault: %s", err)
+	}
+	if secret == nil || secret.WrapInfo == nil {
+		return fmt.Errorf("No secret found at %q", path)
+	}
+
+	d.SetId(secret.RequestID)
+	d.Set("wrapping_token", secret.WrapInfo.Token)
+
+	d.Set("data_json", "")
+	d.Set("data", map[string]string{})
+	d.Set("version", 0)
+	d.Set("lease_id", "")
+	d.Set("lease_duration", 0)
+	d.Set("lease_start_time", time.Now().Format(time.RFC3339))
+	d.Set("lease_renewable", false)
+
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/acctest"
 	r "github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
 )
 
 func TestDataSourceGenericSecret(t *testing.T) {
@@ -60,3 +62,138 @@ func testDataSourceGenericSecret_check(s *terraform.State) error {
 
 	return nil
 }
+
+func TestDataSourceGenericSecret_readQuery(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testDataSourceGenericSecret_readQueryConfig(mount, "v1", false),
+			},
+			r.TestStep{
+				Config: testDataSourceGenericSecret_readQueryConfig(mount, "v2", true),
+				Check:  r.TestCheckResourceAttr("data.vault_generic_secret.v1", "data.data", `{"zip":"v1"}`),
+			},
+		},
+	})
+}
+
+func testDataSourceGenericSecret_readQueryConfig(mount, value string, read bool) string {
+	config := fmt.Sprintf(`
+resource "vault_mount" "kv" {
+    path = "%s"
+    type = "kv-v2"
+}
+
+resource "vault_generic_secret" "test" {
+    path = "${vault_mount.kv.path}/data/foo"
+    data_json = "{\"data\": {\"zip\": \"%s\"}}"
+}
+`, mount, value)
+	if read {
+		config += `
+data "vault_generic_secret" "v1" {
+    path = "${vault_generic_secret.test.path}"
+    read_query {
+        version = "1"
+    }
+}
+`
+	}
+	return config
+}
+
+func TestDataSourceGenericSecret_kvV2(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testDataSourceGenericSecret_kvV2Config(mount, false),
+			},
+			r.TestStep{
+				// The data source is only added once the secret exists,
+				// as it is read before the resources are changed.
+				Config: testDataSourceGenericSecret_kvV2Config(mount, true),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("data.vault_generic_secret.test", "data.zip", "zap"),
+					r.TestCheckResourceAttr("data.vault_generic_secret.test", "data_json", `{"zip":"zap"}`),
+					r.TestCheckResourceAttr("data.vault_generic_secret.test", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceGenericSecret_kvV2Config(mount string, read bool) string {
+	config := fmt.Sprintf(`
+resource "vault_mount" "kv" {
+    path = "%s"
+    type = "kv-v2"
+}
+
+resource "vault_generic_secret" "test" {
+    path = "${vault_mount.kv.path}/foo"
+    data_json = "{\"zip\": \"zap\"}"
+}
+`, mount)
+	if read {
+		config += `
+data "vault_generic_secret" "test" {
+    path = "${vault_generic_secret.test.path}"
+}
+`
+	}
+	return config
+}
+
+func TestDataSourceGenericSecret_wrappingTTL(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "vault_generic_secret" "test" {
+    path = "secret/wrapped"
+    data_json = "{\"zip\": \"zap\"}"
+}
+
+data "vault_generic_secret" "test" {
+    path = "${vault_generic_secret.test.path}"
+    wrapping_ttl = "60s"
+}
+`,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("data.vault_generic_secret.test", "data_json", ""),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources["data.vault_generic_secret.test"]
+						token := rs.Primary.Attributes["wrapping_token"]
+						if token == "" {
+							return fmt.Errorf("no wrapping_token set")
+						}
+
+						client := testProvider.Meta().(*api.Client)
+						secret, err := client.Logical().Unwrap(token)
+						if err != nil {
+							return fmt.Errorf("error unwrapping %q: %s", token, err)
+						}
+						// The dev server mounts secret/ as KV version 2 in
+						// recent versions of Vault.
+						mount, err := kvMountForPath(client, "secret/wrapped")
+						if err != nil {
+							return err
+						}
+						if data, _ := kvSecretData(mount, secret); data["zip"] != "zap" {
+							return fmt.Errorf("unexpected secret wrapped in %q: %v", token, secret)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
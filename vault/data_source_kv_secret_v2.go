@@ -0,0 +1,138 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func kvSecretV2DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: kvSecretV2DataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"mount": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the KV version 2 mount.",
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the secret, relative to the mount.",
+			},
+
+			"current_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The latest version of the secret.",
+			},
+
+			"oldest_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The oldest version of the secret that is still kept.",
+			},
+
+			"max_versions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of versions kept for the secret. 0 means the mount default.",
+			},
+
+			"created_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the secret was created.",
+			},
+
+			"updated_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the secret was last updated.",
+			},
+
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The versions of the secret, ordered from oldest to latest.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deletion_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destroyed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func kvSecretV2DataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	mount := &kvMount{
+		Path:    strings.Trim(d.Get("mount").(string), "/") + "/",
+		Version: 2,
+	}
+	path := mount.metadataPath(mount.Path + strings.Trim(d.Get("name").(string), "/"))
+
+	log.Printf("[DEBUG] Reading KV metadata %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading KV metadata %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no KV metadata found at %q", path)
+	}
+
+	d.SetId(path)
+	d.Set("current_version", intFromResponse(secret.Data["current_version"]))
+	d.Set("oldest_version", intFromResponse(secret.Data["oldest_version"]))
+	d.Set("max_versions", intFromResponse(secret.Data["max_versions"]))
+	d.Set("created_time", secret.Data["created_time"])
+	d.Set("updated_time", secret.Data["updated_time"])
+
+	versionsI, _ := secret.Data["versions"].(map[string]interface{})
+	versions := make([]map[string]interface{}, 0, len(versionsI))
+	for k, v := range versionsI {
+		number, err := strconv.Atoi(k)
+		if err != nil {
+			return fmt.Errorf("unexpected version %q in KV metadata %q", k, path)
+		}
+		info, _ := v.(map[string]interface{})
+		versions = append(versions, map[string]interface{}{
+			"version":       number,
+			"created_time":  info["created_time"],
+			"deletion_time": info["deletion_time"],
+			"destroyed":     info["destroyed"],
+		})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i]["version"].(int) < versions[j]["version"].(int)
+	})
+	if err := d.Set("versions", versions); err != nil {
+		return fmt.Errorf("error setting versions of %q: %s", path, err)
+	}
+
+	return nil
+}
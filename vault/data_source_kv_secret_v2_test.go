@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceKVSecretV2(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKVSecretV2Config(mount),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vault_kv_secret_v2.test", "current_version", "1"),
+					resource.TestCheckResourceAttr("data.vault_kv_secret_v2.test", "oldest_version", "1"),
+					resource.TestCheckResourceAttr("data.vault_kv_secret_v2.test", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.vault_kv_secret_v2.test", "versions.0.version", "1"),
+					resource.TestCheckResourceAttr("data.vault_kv_secret_v2.test", "versions.0.deletion_time", ""),
+					resource.TestCheckResourceAttr("data.vault_kv_secret_v2.test", "versions.0.destroyed", "false"),
+					resource.TestCheckResourceAttrSet("data.vault_kv_secret_v2.test", "versions.0.created_time"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKVSecretV2Config(mount string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+	path = "%s"
+	type = "kv-v2"
+}
+
+resource "vault_generic_secret" "test" {
+	path = "${vault_mount.kv.path}/data/foo"
+	data_json = <<EOT
+{"data": {"zip": "zap"}}
+EOT
+}
+
+data "vault_kv_secret_v2" "test" {
+	mount = "${vault_mount.kv.path}"
+	name = "foo"
+	depends_on = ["vault_generic_secret.test"]
+}
+`, mount)
+}
@@ -0,0 +1,70 @@
+package vault
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func kvSecretsListDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: kvSecretsListDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full path of the KV folder to list, including the mount, of either KV version.",
+			},
+
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the secrets and folders directly under the path, folders ending with a slash.",
+			},
+
+			"paths": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Full paths of the secrets and folders directly under the path, folders ending with a slash.",
+			},
+		},
+	}
+}
+
+func kvSecretsListDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	mount, err := kvMountForPath(client, path)
+	if err != nil {
+		return err
+	}
+
+	names, err := listKVFolder(client, mount, path)
+	if err != nil {
+		return fmt.Errorf("error listing KV secrets under %q: %s", path, err)
+	}
+	sort.Strings(names)
+
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = path + "/" + name
+	}
+
+	d.SetId(path)
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting names of %q: %s", path, err)
+	}
+	if err := d.Set("paths", paths); err != nil {
+		return fmt.Errorf("error setting paths of %q: %s", path, err)
+	}
+
+	return nil
+}
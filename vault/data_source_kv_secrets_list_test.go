@@ -0,0 +1,71 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceKVSecretsList_v1(t *testing.T) {
+	testDataSourceKVSecretsList(t, "kv")
+}
+
+func TestDataSourceKVSecretsList_v2(t *testing.T) {
+	testDataSourceKVSecretsList(t, "kv-v2")
+}
+
+func testDataSourceKVSecretsList(t *testing.T, mountType string) {
+	mount := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKVSecretsListConfig(mount, mountType),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.root", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.root", "names.0", "app/"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.root", "names.1", "other"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.root", "paths.1", mount+"/other"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.app", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.app", "names.0", "a/"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.app", "names.1", "db"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.app", "paths.2", mount+"/app/web"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list.missing", "names.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKVSecretsListConfig(mount, mountType string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+	path = "%s"
+	type = "%s"
+}
+
+resource "vault_generic_secret" "test" {
+	count = 4
+	path = "${vault_mount.kv.path}/${element(list("app/db", "app/web", "app/a/deep", "other"), count.index)}"
+	data_json = "{\"zip\": \"zap\"}"
+}
+
+data "vault_kv_secrets_list" "root" {
+	path = "${vault_mount.kv.path}"
+	depends_on = ["vault_generic_secret.test"]
+}
+
+data "vault_kv_secrets_list" "app" {
+	path = "${vault_mount.kv.path}/app"
+	depends_on = ["vault_generic_secret.test"]
+}
+
+data "vault_kv_secrets_list" "missing" {
+	path = "${vault_mount.kv.path}/missing"
+	depends_on = ["vault_generic_secret.test"]
+}
+`, mount, mountType)
+}
@@ -0,0 +1,76 @@
+package vault
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func kvSecretsListV2DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: kvSecretsListV2DataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"mount": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the KV version 2 mount.",
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Folder to list, relative to the mount. Defaults to the whole mount.",
+			},
+
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the secrets found under the folder, relative to the mount.",
+			},
+
+			"paths": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Full paths of the secrets found under the folder, including the mount.",
+			},
+		},
+	}
+}
+
+func kvSecretsListV2DataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	mount := &kvMount{
+		Path:    strings.Trim(d.Get("mount").(string), "/") + "/",
+		Version: 2,
+	}
+	folder := mount.Path + strings.Trim(d.Get("name").(string), "/")
+
+	paths, err := listKVSubtree(client, mount, folder)
+	if err != nil {
+		return fmt.Errorf("error listing KV secrets under %q: %s", folder, err)
+	}
+	sort.Strings(paths)
+
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = mount.relativePath(path)
+	}
+
+	d.SetId(mount.metadataPath(folder))
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting names of %q: %s", folder, err)
+	}
+	if err := d.Set("paths", paths); err != nil {
+		return fmt.Errorf("error setting paths of %q: %s", folder, err)
+	}
+
+	return nil
+}
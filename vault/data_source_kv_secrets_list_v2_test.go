@@ -0,0 +1,65 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceKVSecretsListV2(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKVSecretsListV2Config(mount),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.all", "names.#", "4"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.all", "names.0", "app/a/b/c/deep"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.all", "names.1", "app/db"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.all", "names.2", "app/web"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.all", "names.3", "other"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.app", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.app", "paths.0", mount+"/app/a/b/c/deep"),
+					resource.TestCheckResourceAttr("data.vault_kv_secrets_list_v2.missing", "names.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKVSecretsListV2Config(mount string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+	path = "%s"
+	type = "kv-v2"
+}
+
+resource "vault_kv_secret_v2" "test" {
+	count = 4
+	mount = "${vault_mount.kv.path}"
+	name = "${element(list("app/db", "app/web", "app/a/b/c/deep", "other"), count.index)}"
+	data_json = "{\"zip\": \"zap\"}"
+}
+
+data "vault_kv_secrets_list_v2" "all" {
+	mount = "${vault_mount.kv.path}"
+	depends_on = ["vault_kv_secret_v2.test"]
+}
+
+data "vault_kv_secrets_list_v2" "app" {
+	mount = "${vault_mount.kv.path}"
+	name = "app"
+	depends_on = ["vault_kv_secret_v2.test"]
+}
+
+data "vault_kv_secrets_list_v2" "missing" {
+	mount = "${vault_mount.kv.path}"
+	name = "missing"
+	depends_on = ["vault_kv_secret_v2.test"]
+}
+`, mount)
+}
@@ -0,0 +1,17 @@
+package vault
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func mountsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read:   mountsDataSourceRead,
+		Schema: mountListingSchema("secret mounts"),
+	}
+}
+
+func mountsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	return mountListingRead(d, meta.(*api.Client), "sys/mounts")
+}
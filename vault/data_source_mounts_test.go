@@ -0,0 +1,42 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceMounts(t *testing.T) {
+	path := acctest.RandomWithPrefix("transit")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceMountsConfig(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vault_mounts.test", "paths.#", "1"),
+					resource.TestCheckResourceAttr("data.vault_mounts.test", "paths.0", path),
+					resource.TestCheckResourceAttr("data.vault_mounts.test", "types.0", "transit"),
+					resource.TestCheckResourceAttrSet("data.vault_mounts.test", "accessors.0"),
+					resource.TestCheckResourceAttrSet("data.vault_mounts.test", "accessors_by_path."+path),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceMountsConfig(path string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+  type = "transit"
+  path = %q
+}
+
+data "vault_mounts" "test" {
+  type = "${vault_mount.test.type}"
+}
+`, path)
+}
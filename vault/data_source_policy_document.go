@@ -0,0 +1,252 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var policyDocumentCapabilities = []string{
+	"create", "read", "update", "patch", "delete", "list", "sudo", "deny",
+}
+
+func policyDocumentParameterSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the parameter, or * for any parameter.",
+				},
+
+				"value": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Values of the parameter, any value if empty.",
+				},
+			},
+		},
+	}
+}
+
+func policyDocumentDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: policyDocumentDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Rules of the policy, rendered in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Path the rule applies to, which can contain * and + wildcards.",
+						},
+
+						"capabilities": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Capabilities granted on the path: " + strings.Join(policyDocumentCapabilities, ", ") + ".",
+						},
+
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Description of the rule, rendered as a comment.",
+						},
+
+						"required_parameters": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Parameters that must be given in requests.",
+						},
+
+						"allowed_parameter": policyDocumentParameterSchema("Parameters allowed in requests, along with their allowed values."),
+
+						"denied_parameter": policyDocumentParameterSchema("Parameters denied in requests, along with their denied values."),
+
+						"min_wrapping_ttl": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Minimum TTL of the response wrapping requests must ask for, e.g. 1s.",
+						},
+
+						"max_wrapping_ttl": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Maximum TTL of the response wrapping requests must ask for, e.g. 1h.",
+						},
+
+						"mfa_methods": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Names of the MFA methods requests must be validated with, Vault Enterprise only.",
+						},
+					},
+				},
+			},
+
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy document rendered as HCL.",
+			},
+		},
+	}
+}
+
+type policyDocumentRule struct {
+	Path               string
+	Description        string
+	Capabilities       []string
+	RequiredParameters []string
+	AllowedParameters  map[string][]string
+	DeniedParameters   map[string][]string
+	MinWrappingTTL     string
+	MaxWrappingTTL     string
+	MFAMethods         []string
+}
+
+func policyDocumentDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	var rules []policyDocumentRule
+	for i, raw := range d.Get("rule").([]interface{}) {
+		r := raw.(map[string]interface{})
+		rule := policyDocumentRule{
+			Path:               r["path"].(string),
+			Description:        r["description"].(string),
+			Capabilities:       toStringArray(r["capabilities"].([]interface{})),
+			RequiredParameters: toStringArray(r["required_parameters"].([]interface{})),
+			AllowedParameters:  policyDocumentParameters(r["allowed_parameter"].([]interface{})),
+			DeniedParameters:   policyDocumentParameters(r["denied_parameter"].([]interface{})),
+			MinWrappingTTL:     r["min_wrapping_ttl"].(string),
+			MaxWrappingTTL:     r["max_wrapping_ttl"].(string),
+			MFAMethods:         toStringArray(r["mfa_methods"].([]interface{})),
+		}
+		if err := validatePolicyDocumentCapabilities(rule.Capabilities); err != nil {
+			return fmt.Errorf("invalid rule %d for path %q: %s", i, rule.Path, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	hcl := renderPolicyDocument(rules)
+
+	sum := sha256.Sum256([]byte(hcl))
+	d.SetId(hex.EncodeToString(sum[:]))
+	d.Set("hcl", hcl)
+
+	return nil
+}
+
+// policyDocumentParameters merges the allowed_parameter or denied_parameter
+// blocks of a rule, so that the same key can be given in several blocks.
+func policyDocumentParameters(blocks []interface{}) map[string][]string {
+	if len(blocks) == 0 {
+		return nil
+	}
+	params := make(map[string][]string, len(blocks))
+	for _, raw := range blocks {
+		b := raw.(map[string]interface{})
+		key := b["key"].(string)
+		params[key] = append(params[key], toStringArray(b["value"].([]interface{}))...)
+	}
+	return params
+}
+
+func validatePolicyDocumentCapabilities(capabilities []string) error {
+	for _, c := range capabilities {
+		valid := false
+		for _, v := range policyDocumentCapabilities {
+			if c == v {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("capability must be one of %s, got %q", strings.Join(policyDocumentCapabilities, ", "), c)
+		}
+	}
+	return nil
+}
+
+// renderPolicyDocument renders the rules as an HCL policy document. Rules
+// are kept in order, while parameter keys are sorted so that the output is
+// stable. Repeated capabilities and parameter values are only rendered once.
+func renderPolicyDocument(rules []policyDocumentRule) string {
+	var buf bytes.Buffer
+	for i, rule := range rules {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if rule.Description != "" {
+			for _, line := range strings.Split(strings.TrimSpace(rule.Description), "\n") {
+				fmt.Fprintf(&buf, "# %s\n", strings.TrimSpace(line))
+			}
+		}
+		fmt.Fprintf(&buf, "path %s {\n", strconv.Quote(rule.Path))
+		fmt.Fprintf(&buf, "  capabilities = %s\n", policyDocumentList(rule.Capabilities))
+		if len(rule.RequiredParameters) > 0 {
+			fmt.Fprintf(&buf, "  required_parameters = %s\n", policyDocumentList(rule.RequiredParameters))
+		}
+		policyDocumentRenderParameters(&buf, "allowed_parameters", rule.AllowedParameters)
+		policyDocumentRenderParameters(&buf, "denied_parameters", rule.DeniedParameters)
+		if rule.MinWrappingTTL != "" {
+			fmt.Fprintf(&buf, "  min_wrapping_ttl = %s\n", strconv.Quote(rule.MinWrappingTTL))
+		}
+		if rule.MaxWrappingTTL != "" {
+			fmt.Fprintf(&buf, "  max_wrapping_ttl = %s\n", strconv.Quote(rule.MaxWrappingTTL))
+		}
+		if len(rule.MFAMethods) > 0 {
+			fmt.Fprintf(&buf, "  mfa_methods = %s\n", policyDocumentList(rule.MFAMethods))
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.String()
+}
+
+func policyDocumentRenderParameters(buf *bytes.Buffer, name string, params map[string][]string) {
+	if len(params) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(buf, "  %s = {\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(buf, "    %s = %s\n", strconv.Quote(k), policyDocumentList(params[k]))
+	}
+	buf.WriteString("  }\n")
+}
+
+// policyDocumentList renders a list of strings, skipping repeated values.
+func policyDocumentList(values []string) string {
+	seen := make(map[string]bool, len(values))
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
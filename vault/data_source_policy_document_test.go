@@ -0,0 +1,113 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestRenderPolicyDocument(t *testing.T) {
+	rules := []policyDocumentRule{
+		{
+			Path:         "secret/*",
+			Description:  "Read secrets",
+			Capabilities: []string{"read", "list", "read"},
+		},
+		{
+			Path:               "auth/token/create",
+			Capabilities:       []string{"update"},
+			RequiredParameters: []string{"ttl"},
+			AllowedParameters: map[string][]string{
+				"ttl":      {"1h", "2h"},
+				"policies": {},
+			},
+			DeniedParameters: map[string][]string{
+				"no_parent": {},
+			},
+			MinWrappingTTL: "1s",
+			MaxWrappingTTL: "90s",
+			MFAMethods:     []string{"duo"},
+		},
+	}
+	expected := `# Read secrets
+path "secret/*" {
+  capabilities = ["read", "list"]
+}
+
+path "auth/token/create" {
+  capabilities = ["update"]
+  required_parameters = ["ttl"]
+  allowed_parameters = {
+    "policies" = []
+    "ttl" = ["1h", "2h"]
+  }
+  denied_parameters = {
+    "no_parent" = []
+  }
+  min_wrapping_ttl = "1s"
+  max_wrapping_ttl = "90s"
+  mfa_methods = ["duo"]
+}
+`
+	got := renderPolicyDocument(rules)
+	if got != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+	if _, err := hcl.Parse(got); err != nil {
+		t.Fatalf("rendered policy is not valid HCL: %s", err)
+	}
+}
+
+func TestValidatePolicyDocumentCapabilities(t *testing.T) {
+	if err := validatePolicyDocumentCapabilities([]string{"create", "sudo", "deny"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := validatePolicyDocumentCapabilities([]string{"read", "write"}); err == nil {
+		t.Errorf("expected error for capability write")
+	}
+}
+
+func TestDataSourcePolicyDocument(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourcePolicyDocumentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vault_policy_document.test", "hcl", `path "secret/*" {
+  capabilities = ["read", "list"]
+  allowed_parameters = {
+    "version" = ["1", "2"]
+  }
+}
+`),
+					resource.TestCheckResourceAttrPair("vault_policy.test", "policy", "data.vault_policy_document.test", "hcl"),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourcePolicyDocumentConfig = `
+data "vault_policy_document" "test" {
+	rule {
+		path = "secret/*"
+		capabilities = ["read", "list"]
+		allowed_parameter {
+			key = "version"
+			value = ["1"]
+		}
+		allowed_parameter {
+			key = "version"
+			value = ["2"]
+		}
+	}
+}
+
+resource "vault_policy" "test" {
+	name = "policy-document-test"
+	policy = "${data.vault_policy_document.test.hcl}"
+}
+`
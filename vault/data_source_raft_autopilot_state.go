@@ -0,0 +1,157 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const raftAutopilotStatePath = "sys/storage/raft/autopilot/state"
+
+func raftAutopilotStateDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: raftAutopilotStateDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster is healthy.",
+			},
+
+			"failure_tolerance": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How many voters can fail without the cluster losing quorum.",
+			},
+
+			"leader": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the current leader.",
+			},
+
+			"voters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the voting servers.",
+			},
+
+			"non_voters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the non-voting servers.",
+			},
+
+			"servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Health of each server of the cluster, ordered by ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"healthy": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"last_contact": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_term": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"last_index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"stable_since": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func raftAutopilotStateDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Reading raft autopilot state from Vault")
+	secret, err := client.Logical().Read(raftAutopilotStatePath)
+	if err != nil {
+		// Vault rejects the request rather than answering 404 when raft
+		// is not the storage backend.
+		if strings.Contains(err.Error(), "raft storage is not in use") {
+			return fmt.Errorf("error reading raft autopilot state: Vault is not using raft integrated storage: %s", err)
+		}
+		return fmt.Errorf("error reading raft autopilot state: %s", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return fmt.Errorf("no raft autopilot state found; Vault 1.7 or later with raft integrated storage is required")
+	}
+
+	d.SetId(raftAutopilotStatePath)
+	d.Set("healthy", secret.Data["healthy"])
+	d.Set("failure_tolerance", intFromResponse(secret.Data["failure_tolerance"]))
+	d.Set("leader", secret.Data["leader"])
+	d.Set("voters", flattenStringList(secret.Data["voters"]))
+	d.Set("non_voters", flattenStringList(secret.Data["non_voters"]))
+
+	serversI, _ := secret.Data["servers"].(map[string]interface{})
+	ids := make([]string, 0, len(serversI))
+	for id := range serversI {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	servers := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		server, _ := serversI[id].(map[string]interface{})
+		servers = append(servers, map[string]interface{}{
+			"id":           id,
+			"name":         server["name"],
+			"address":      server["address"],
+			"node_status":  server["node_status"],
+			"status":       server["status"],
+			"healthy":      server["healthy"],
+			"last_contact": server["last_contact"],
+			"last_term":    intFromResponse(server["last_term"]),
+			"last_index":   intFromResponse(server["last_index"]),
+			"stable_since": server["stable_since"],
+		})
+	}
+	if err := d.Set("servers", servers); err != nil {
+		return fmt.Errorf("error setting raft autopilot servers: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package vault
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// The acceptance tests run against a dev server, which uses in-memory
+// storage, so only the error reported without raft can be checked.
+func TestDataSourceRaftAutopilotState_noRaft(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "vault_raft_autopilot_state" "test" {}`,
+				ExpectError: regexp.MustCompile("not using raft integrated storage"),
+			},
+		},
+	})
+}
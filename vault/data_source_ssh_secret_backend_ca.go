@@ -0,0 +1,64 @@
+package vault
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func sshSecretBackendCADataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: sshSecretBackendCADataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the SSH secret backend.",
+			},
+
+			"public_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public key of the SSH CA, in authorized_keys format.",
+			},
+		},
+	}
+}
+
+func sshSecretBackendCADataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/public_key"
+
+	// The public key endpoint is unauthenticated and answers with the
+	// bare key rather than a JSON response, so it can't be read through
+	// the logical API.
+	log.Printf("[DEBUG] Reading SSH CA public key from %q", path)
+	resp, err := client.RawRequest(client.NewRequest("GET", "/v1/"+path))
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("error reading SSH CA public key from %q: %s", path, err)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading SSH CA public key from %q: %s", path, err)
+	}
+	publicKey := strings.TrimSpace(string(body))
+	if publicKey == "" {
+		return fmt.Errorf("no SSH CA public key found at %q; the CA of the backend must be configured first", path)
+	}
+
+	d.SetId(path)
+	d.Set("public_key", publicKey)
+
+	return nil
+}
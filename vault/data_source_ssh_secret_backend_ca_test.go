@@ -0,0 +1,49 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestDataSourceSSHSecretBackendCA(t *testing.T) {
+	backend := acctest.RandomWithPrefix("ssh")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceSSHSecretBackendCAMountConfig(backend),
+				Check: func(*terraform.State) error {
+					client := testProvider.Meta().(*api.Client)
+					_, err := client.Logical().Write(backend+"/config/ca", map[string]interface{}{
+						"generate_signing_key": true,
+					})
+					return err
+				},
+			},
+			{
+				Config: testDataSourceSSHSecretBackendCAMountConfig(backend) + `
+data "vault_ssh_secret_backend_ca" "test" {
+	backend = "${vault_mount.ssh.path}"
+}
+`,
+				Check: resource.TestMatchResourceAttr("data.vault_ssh_secret_backend_ca.test", "public_key", regexp.MustCompile("^ssh-rsa ")),
+			},
+		},
+	})
+}
+
+func testDataSourceSSHSecretBackendCAMountConfig(backend string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "ssh" {
+	path = "%s"
+	type = "ssh"
+}
+`, backend)
+}
@@ -0,0 +1,92 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func transitRewrapDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: transitRewrapDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the transit secret backend.",
+			},
+
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the key the ciphertext was encrypted with.",
+			},
+
+			"ciphertext": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Ciphertext to rewrap.",
+			},
+
+			"context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Base64 encoded context used for key derivation.",
+			},
+
+			"key_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Version of the key to rewrap to. Defaults to the latest version.",
+			},
+
+			"rewrapped_ciphertext": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Ciphertext rewrapped to the requested key version.",
+			},
+		},
+	}
+}
+
+func transitRewrapDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	key := d.Get("key").(string)
+	path := backend + "/rewrap/" + key
+
+	data := map[string]interface{}{
+		"ciphertext": d.Get("ciphertext").(string),
+	}
+	if v, ok := d.GetOk("context"); ok {
+		data["context"] = v.(string)
+	}
+	if v, ok := d.GetOk("key_version"); ok {
+		data["key_version"] = v.(int)
+	}
+
+	log.Printf("[DEBUG] Rewrapping ciphertext with %q", path)
+	secret, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error rewrapping ciphertext with %q: %s", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return fmt.Errorf("no ciphertext returned by %q", path)
+	}
+
+	ciphertext, _ := secret.Data["ciphertext"].(string)
+	if ciphertext == "" {
+		return fmt.Errorf("no ciphertext returned by %q", path)
+	}
+
+	d.SetId(path)
+	d.Set("rewrapped_ciphertext", ciphertext)
+
+	return nil
+}
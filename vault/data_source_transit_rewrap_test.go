@@ -0,0 +1,77 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestDataSourceTransitRewrap(t *testing.T) {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skip(fmt.Sprintf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar))
+	}
+	testAccPreCheck(t)
+
+	// The ciphertext has to exist before the configuration is rendered, so
+	// it is encrypted with the first key version directly through the API,
+	// and the key is then rotated to give the rewrap a newer version.
+	client, err := api.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := acctest.RandomWithPrefix("transit")
+	if err := client.Sys().Mount(backend, &api.MountInput{Type: "transit"}); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Sys().Unmount(backend)
+	if _, err := client.Logical().Write(backend+"/keys/test", nil); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := client.Logical().Write(backend+"/encrypt/test", map[string]interface{}{
+		"plaintext": "aGVsbG8=",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext := secret.Data["ciphertext"].(string)
+	if _, err := client.Logical().Write(backend+"/keys/test/rotate", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "vault_transit_rewrap" "test" {
+	backend = "%s"
+	key = "test"
+	ciphertext = "%s"
+}
+`, backend, ciphertext),
+				Check: testDataSourceTransitRewrapCheck,
+			},
+		},
+	})
+}
+
+func testDataSourceTransitRewrapCheck(s *terraform.State) error {
+	resourceState := s.Modules[0].Resources["data.vault_transit_rewrap.test"]
+	if resourceState == nil {
+		return fmt.Errorf("resource not found in state")
+	}
+
+	rewrapped := resourceState.Primary.Attributes["rewrapped_ciphertext"]
+	if !strings.HasPrefix(rewrapped, "vault:v2:") {
+		return fmt.Errorf("expected ciphertext rewrapped to key version 2, got %q", rewrapped)
+	}
+
+	return nil
+}
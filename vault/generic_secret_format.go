@@ -0,0 +1,228 @@
+package vault
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Formats accepted for the data attribute of vault_generic_secret.
+const (
+	secretDataFormatJSON = "json"
+	secretDataFormatKV   = "kv"
+	secretDataFormatYAML = "yaml"
+)
+
+func validateSecretDataFormat(v interface{}, k string) (ws []string, errs []error) {
+	switch value := v.(string); value {
+	case secretDataFormatJSON, secretDataFormatKV, secretDataFormatYAML:
+	default:
+		errs = append(errs, fmt.Errorf("%s: unsupported format %q, must be %q, %q or %q", k, value, secretDataFormatJSON, secretDataFormatKV, secretDataFormatYAML))
+	}
+	return
+}
+
+// parseSecretData decodes secret data written in the given format.
+func parseSecretData(format, data string) (map[string]interface{}, error) {
+	switch format {
+	case secretDataFormatJSON:
+		return decodeDataJSON(data)
+	case secretDataFormatKV:
+		return parseSecretDataKV(data)
+	case secretDataFormatYAML:
+		return parseSecretDataYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported data format %q", format)
+	}
+}
+
+// parseSecretDataKV decodes one key=value pair per line. Blank lines and
+// lines starting with # are ignored. Keys are trimmed, but values are kept
+// verbatim so that they may contain any characters, including = and
+// surrounding whitespace.
+func parseSecretDataKV(data string) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if trimmed := strings.TrimSpace(text); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		idx := strings.Index(text, "=")
+		if idx < 0 {
+			return nil, fmt.Errorf("line %d: expected key=value", line)
+		}
+		key := strings.TrimSpace(text[:idx])
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", line)
+		}
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line, key)
+		}
+		result[key] = text[idx+1:]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// parseSecretDataYAML decodes a YAML mapping. It's converted to JSON and
+// decoded again, so that values have the same types as with the json
+// format.
+func parseSecretDataYAML(data string) (map[string]interface{}, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return map[string]interface{}{}, nil
+	}
+	if _, ok := raw.(map[interface{}]interface{}); !ok {
+		return nil, fmt.Errorf("expected a YAML mapping")
+	}
+
+	b, err := json.Marshal(yamlToJSONValue(raw))
+	if err != nil {
+		return nil, err
+	}
+	return decodeDataJSON(string(b))
+}
+
+// yamlToJSONValue converts the mappings decoded from YAML, which can have
+// keys of any type, into maps with string keys that can be encoded as JSON.
+func yamlToJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			m[fmt.Sprintf("%v", k)] = yamlToJSONValue(item)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, item := range v {
+			l[i] = yamlToJSONValue(item)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// formatSecretData renders secret data read from Vault in the given
+// format. In the kv format values that aren't strings are JSON-encoded,
+// while the yaml format keeps their structure, with keys sorted.
+func formatSecretData(format string, data map[string]interface{}) (string, error) {
+	switch format {
+	case secretDataFormatJSON:
+		b, err := json.Marshal(data)
+		return string(b), err
+	case secretDataFormatKV:
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		for _, k := range keys {
+			v, ok := data[k].(string)
+			if !ok {
+				encoded, err := json.Marshal(data[k])
+				if err != nil {
+					return "", err
+				}
+				v = string(encoded)
+			}
+			fmt.Fprintf(&b, "%s=%s\n", k, v)
+		}
+		return b.String(), nil
+	case secretDataFormatYAML:
+		b, err := yaml.Marshal(data)
+		return string(b), err
+	default:
+		return "", fmt.Errorf("unsupported data format %q", format)
+	}
+}
+
+// secretDataEquivalent reports whether two data strings in the given
+// format decode to the same secret data.
+func secretDataEquivalent(format, a, b string) bool {
+	dataA, err := parseSecretData(format, a)
+	if err != nil {
+		return false
+	}
+	dataB, err := parseSecretData(format, b)
+	if err != nil {
+		return false
+	}
+	jsonA, err := json.Marshal(dataA)
+	if err != nil {
+		return false
+	}
+	jsonB, err := json.Marshal(dataB)
+	if err != nil {
+		return false
+	}
+	return string(jsonA) == string(jsonB)
+}
+
+// renderSecretDataTemplate renders a text/template producing the JSON
+// secret data. The variables are available as {{.name}}, and the json
+// function encodes a value as JSON, e.g. {{json .password}} for a properly
+// quoted and escaped string. Referencing an undefined variable is an error.
+func renderSecretDataTemplate(text string, vars map[string]interface{}) (map[string]interface{}, error) {
+	tmpl, err := template.New("data_json_template").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"json": func(v interface{}) (string, error) {
+				b, err := json.Marshal(v)
+				return string(b), err
+			},
+		}).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %s", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return nil, fmt.Errorf("error rendering template: %s", err)
+	}
+
+	data, err := decodeDataJSON(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("template did not render to a JSON object: %s", err)
+	}
+
+	return data, nil
+}
+
+// secretDataStringMap converts secret data into a map of strings. Since
+// such a map can only contain string values, strings are taken as-is, and
+// everything else is written as a JSON serialization of whatever value we
+// got so that complex types can be passed around and processed elsewhere
+// if desired.
+func secretDataStringMap(data map[string]interface{}) map[string]string {
+	dataMap := make(map[string]string, len(data))
+	for k, v := range data {
+		if vs, ok := v.(string); ok {
+			dataMap[k] = vs
+		} else {
+			// Ignoring error because this value came from JSON in the
+			// first place and so must be valid.
+			vBytes, _ := json.Marshal(v)
+			dataMap[k] = string(vBytes)
+		}
+	}
+	return dataMap
+}
@@ -0,0 +1,148 @@
+package vault
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSecretDataKV(t *testing.T) {
+	got, err := parseSecretData(secretDataFormatKV, `
+# database credentials
+username=admin
+ password =s3cr=t 
+
+url=postgres://db:5432/app?sslmode=require
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"username": "admin",
+		"password": "s3cr=t ",
+		"url":      "postgres://db:5432/app?sslmode=require",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v; want %#v", got, want)
+	}
+
+	for _, invalid := range []string{"novalue", "=value", "a=1\na=2"} {
+		if _, err := parseSecretData(secretDataFormatKV, invalid); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
+
+func TestFormatSecretData(t *testing.T) {
+	data := map[string]interface{}{
+		"zip":   "zap",
+		"count": 3,
+		"alpha": "a=b",
+	}
+
+	got, err := formatSecretData(secretDataFormatKV, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "alpha=a=b\ncount=3\nzip=zap\n"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	got, err = formatSecretData(secretDataFormatJSON, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"alpha":"a=b","count":3,"zip":"zap"}`; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestSecretDataYAML(t *testing.T) {
+	data, err := parseSecretData(secretDataFormatYAML, `
+# database credentials
+username: admin
+port: 5432
+tags: [a, b]
+options:
+  sslmode: require
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatted, err := formatSecretData(secretDataFormatYAML, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "options:\n  sslmode: require\nport: 5432\ntags:\n- a\n- b\nusername: admin\n"
+	if formatted != want {
+		t.Errorf("got %q; want %q", formatted, want)
+	}
+
+	got, err := parseSecretData(secretDataFormatYAML, formatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("got %#v after a round trip; want %#v", got, data)
+	}
+
+	for _, invalid := range []string{"- a\n- b", "a: [", "just a string"} {
+		if _, err := parseSecretData(secretDataFormatYAML, invalid); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
+
+func TestSecretDataEquivalent(t *testing.T) {
+	if !secretDataEquivalent(secretDataFormatKV, "b=2\na=1\n", "# comment\na=1\nb=2") {
+		t.Errorf("expected reordered kv data to be equivalent")
+	}
+	if secretDataEquivalent(secretDataFormatKV, "a=1", "a=2") {
+		t.Errorf("expected different kv data not to be equivalent")
+	}
+	if !secretDataEquivalent(secretDataFormatJSON, `{"a": 1, "b": 2}`, `{"b":2,"a":1}`) {
+		t.Errorf("expected reformatted JSON data to be equivalent")
+	}
+}
+
+func TestRenderSecretDataTemplate(t *testing.T) {
+	vars := map[string]interface{}{
+		"user":     "admin",
+		"password": `pa"ss`,
+	}
+
+	data, err := renderSecretDataTemplate(`{"user": "{{.user}}", "password": {{json .password}}}`, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["user"] != "admin" || data["password"] != `pa"ss` {
+		t.Errorf("unexpected rendered data %#v", data)
+	}
+
+	if _, err := renderSecretDataTemplate(`{"user": "{{.missing}}"}`, vars); err == nil {
+		t.Errorf("expected an error referencing an undefined variable")
+	}
+	if _, err := renderSecretDataTemplate(`{"user": {{.user}}}`, vars); err == nil {
+		t.Errorf("expected an error rendering invalid JSON")
+	}
+	if _, err := renderSecretDataTemplate(`{"user": "{{.user"}`, vars); err == nil {
+		t.Errorf("expected an error parsing an invalid template")
+	}
+}
+
+func TestSecretDataStringMap(t *testing.T) {
+	data, err := decodeDataJSON(`{"user": "admin", "port": 5432, "tags": ["a", "b"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := secretDataStringMap(data)
+	want := map[string]string{
+		"user": "admin",
+		"port": "5432",
+		"tags": `["a","b"]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v; want %#v", got, want)
+	}
+}
@@ -0,0 +1,41 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccGenericSecret_importBasic(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/import")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    allow_read = true
+    data_json = "{\"zip\": \"zap\"}"
+}
+`, path),
+			},
+			{
+				ResourceName:            "vault_generic_secret.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"lease_start_time"},
+			},
+			{
+				ResourceName:  "vault_generic_secret.test",
+				ImportState:   true,
+				ImportStateId: path + "-missing",
+				ExpectError:   regexp.MustCompile("no secret found"),
+			},
+		},
+	})
+}
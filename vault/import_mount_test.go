@@ -21,6 +21,8 @@ func TestAccMount_importBasic(t *testing.T) {
 				ResourceName:      "vault_mount.test",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// prevent_unmount only exists in Terraform configuration.
+				ImportStateVerifyIgnore: []string{"prevent_unmount"},
 			},
 		},
 	})
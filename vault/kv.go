@@ -0,0 +1,180 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// kvMount describes the KV secret backend mount that a logical path
+// belongs to.
+type kvMount struct {
+	// Path is the mount path, with a trailing slash. It is empty when the
+	// mount could not be determined, in which case paths are used as-is.
+	Path string
+
+	// Version is the KV version of the mount, either 1 or 2.
+	Version int
+}
+
+// kvMountForPath looks up the KV mount serving the given logical path.
+//
+// Vault versions that do not provide the sys/internal/ui/mounts endpoint
+// predate KV version 2, so a missing endpoint is reported as a version 1
+// mount with no known path.
+func kvMountForPath(client *api.Client, path string) (*kvMount, error) {
+	return kvMountInNamespace(client, "", path)
+}
+
+// kvMountInNamespace looks up the KV mount serving the given logical path
+// within a namespace. The mount path is relative to the namespace.
+func kvMountInNamespace(client *api.Client, namespace, path string) (*kvMount, error) {
+	path = strings.Trim(path, "/")
+
+	log.Printf("[DEBUG] Looking up KV mount for %q", path)
+	secret, err := client.Logical().Read(namespacedPath(namespace, "sys/internal/ui/mounts/"+path))
+	if err != nil {
+		return nil, fmt.Errorf("error looking up mount of %q: %s", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return &kvMount{Version: 1}, nil
+	}
+
+	mount := &kvMount{Version: 1}
+	if v, ok := secret.Data["path"].(string); ok {
+		mount.Path = v
+	}
+	if options, ok := secret.Data["options"].(map[string]interface{}); ok {
+		if v, ok := options["version"].(string); ok && v == "2" {
+			mount.Version = 2
+		}
+	}
+
+	return mount, nil
+}
+
+// relativePath returns the path of the given logical path relative to the
+// mount.
+func (m *kvMount) relativePath(path string) string {
+	path = strings.Trim(path, "/")
+	if path+"/" == m.Path {
+		return ""
+	}
+	return strings.TrimPrefix(path, m.Path)
+}
+
+// dataPath returns the API path holding the secret data for the given
+// logical path.
+func (m *kvMount) dataPath(path string) string {
+	if m.Version != 2 {
+		return strings.Trim(path, "/")
+	}
+	return m.Path + "data/" + m.relativePath(path)
+}
+
+// metadataPath returns the API path holding the metadata for the given
+// logical path. For KV version 1 this is the same as the data path.
+func (m *kvMount) metadataPath(path string) string {
+	if m.Version != 2 {
+		return strings.Trim(path, "/")
+	}
+	return m.Path + "metadata/" + m.relativePath(path)
+}
+
+// destroyPath returns the API path permanently destroying versions of the
+// secret at the given logical path. Only KV version 2 mounts have one.
+func (m *kvMount) destroyPath(path string) string {
+	return m.Path + "destroy/" + m.relativePath(path)
+}
+
+// kvWriteData wraps secret data in the request body expected by the mount.
+func kvWriteData(m *kvMount, data map[string]interface{}) map[string]interface{} {
+	if m.Version != 2 {
+		return data
+	}
+	return map[string]interface{}{
+		"data": data,
+	}
+}
+
+// kvSecretData returns the secret data of a response read from the data
+// path of the mount, along with the version it belongs to for KV version 2
+// mounts. The latest version of a version 2 secret may have been deleted,
+// in which case there is no data.
+func kvSecretData(m *kvMount, secret *api.Secret) (map[string]interface{}, int) {
+	if secret == nil {
+		return nil, 0
+	}
+	if m.Version != 2 {
+		return secret.Data, 0
+	}
+
+	version := 0
+	if metadata, ok := secret.Data["metadata"].(map[string]interface{}); ok {
+		version = intFromResponse(metadata["version"])
+	}
+	data, _ := secret.Data["data"].(map[string]interface{})
+	return data, version
+}
+
+// listKVFolder returns the keys found directly under the given folder, as
+// returned by Vault: nested folders end with a slash. A missing folder has
+// no keys.
+func listKVFolder(client *api.Client, mount *kvMount, folder string) ([]string, error) {
+	folder = strings.Trim(folder, "/")
+
+	log.Printf("[DEBUG] Listing KV folder %q", folder)
+	secret, err := client.Logical().List(mount.metadataPath(folder))
+	if err != nil {
+		return nil, fmt.Errorf("error listing %q: %s", folder, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+
+	raw, _ := secret.Data["keys"].([]interface{})
+	var keys []string
+	for _, k := range raw {
+		if key, ok := k.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// listKVSubtree returns the logical paths of all secrets found under the
+// given prefix, descending into nested folders.
+//
+// Vault returns the whole content of a folder in one LIST response, so the
+// tree is walked one folder at a time to keep each response bounded by the
+// size of a single folder rather than of the whole subtree.
+func listKVSubtree(client *api.Client, mount *kvMount, prefix string) ([]string, error) {
+	prefix = strings.Trim(prefix, "/")
+
+	var paths []string
+	folders := []string{prefix}
+	for len(folders) > 0 {
+		folder := folders[len(folders)-1]
+		folders = folders[:len(folders)-1]
+
+		keys, err := listKVFolder(client, mount, folder)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			child := key
+			if folder != "" {
+				child = folder + "/" + key
+			}
+			if strings.HasSuffix(key, "/") {
+				folders = append(folders, strings.TrimSuffix(child, "/"))
+			} else {
+				paths = append(paths, child)
+			}
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,70 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+)
+
+func TestKVMountPaths(t *testing.T) {
+	v1 := &kvMount{Path: "secret/", Version: 1}
+	v2 := &kvMount{Path: "kv/", Version: 2}
+
+	cases := []struct {
+		mount        *kvMount
+		path         string
+		wantData     string
+		wantMetadata string
+	}{
+		{v1, "secret/foo", "secret/foo", "secret/foo"},
+		{v1, "/secret/foo/", "secret/foo", "secret/foo"},
+		{v2, "kv/foo/bar", "kv/data/foo/bar", "kv/metadata/foo/bar"},
+		{v2, "kv", "kv/data/", "kv/metadata/"},
+		{&kvMount{Version: 1}, "secret/foo", "secret/foo", "secret/foo"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.mount.dataPath(tc.path); got != tc.wantData {
+			t.Errorf("dataPath(%q) = %q; want %q", tc.path, got, tc.wantData)
+		}
+		if got := tc.mount.metadataPath(tc.path); got != tc.wantMetadata {
+			t.Errorf("metadataPath(%q) = %q; want %q", tc.path, got, tc.wantMetadata)
+		}
+	}
+
+	if got := v2.destroyPath("kv/foo/bar"); got != "kv/destroy/foo/bar" {
+		t.Errorf("destroyPath(%q) = %q; want %q", "kv/foo/bar", got, "kv/destroy/foo/bar")
+	}
+}
+
+func TestKVSecretData(t *testing.T) {
+	v1 := &kvMount{Path: "secret/", Version: 1}
+	v2 := &kvMount{Path: "kv/", Version: 2}
+
+	data, version := kvSecretData(v1, &api.Secret{Data: map[string]interface{}{"foo": "bar"}})
+	if data["foo"] != "bar" || version != 0 {
+		t.Errorf("unexpected version 1 data %v, version %d", data, version)
+	}
+
+	data, version = kvSecretData(v2, &api.Secret{Data: map[string]interface{}{
+		"data":     map[string]interface{}{"foo": "bar"},
+		"metadata": map[string]interface{}{"version": json.Number("3")},
+	}})
+	if len(data) != 1 || data["foo"] != "bar" || version != 3 {
+		t.Errorf("unexpected version 2 data %v, version %d", data, version)
+	}
+
+	// The latest version has been deleted.
+	data, version = kvSecretData(v2, &api.Secret{Data: map[string]interface{}{
+		"data":     nil,
+		"metadata": map[string]interface{}{"version": json.Number("4")},
+	}})
+	if data != nil || version != 4 {
+		t.Errorf("unexpected deleted version 2 data %v, version %d", data, version)
+	}
+
+	if data, _ := kvSecretData(v2, nil); data != nil {
+		t.Errorf("unexpected data %v without a secret", data)
+	}
+}
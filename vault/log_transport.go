@@ -0,0 +1,146 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+// logTransport logs the requests to Vault and their responses when debug
+// logging is enabled, like the logging transport of Terraform. Request and
+// response bodies carry secrets, so their values are redacted, keeping only
+// the structure of the data and the errors and warnings of Vault.
+type logTransport struct {
+	name string
+	base http.RoundTripper
+}
+
+func newLogTransport(name string, base http.RoundTripper) *logTransport {
+	return &logTransport{name: name, base: base}
+}
+
+// logRedactedHeaders are the headers whose values are never logged.
+var logRedactedHeaders = []string{"X-Vault-Token", "Authorization"}
+
+// logKeptKeys are the top-level keys of bodies whose values are logged,
+// as they hold no secrets and are needed to make sense of a response.
+var logKeptKeys = map[string]bool{
+	"request_id":     true,
+	"lease_duration": true,
+	"renewable":      true,
+	"errors":         true,
+	"warnings":       true,
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() {
+		return t.base.RoundTrip(req)
+	}
+
+	logged := req.Clone(req.Context())
+	for _, h := range logRedactedHeaders {
+		if logged.Header.Get(h) != "" {
+			logged.Header.Set(h, "<redacted>")
+		}
+	}
+	reqData, err := httputil.DumpRequestOut(logged, false)
+	if err != nil {
+		log.Printf("[ERROR] %s API Request error: %#v", t.name, err)
+	} else {
+		log.Printf("[DEBUG] %s API Request Details:\n---[ REQUEST ]---------------------------------------\n%s%s\n-----------------------------------------------------",
+			t.name, reqData, redactRequestBody(req))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respData, err := httputil.DumpResponse(resp, false)
+	if err != nil {
+		log.Printf("[ERROR] %s API Response error: %#v", t.name, err)
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	log.Printf("[DEBUG] %s API Response Details:\n---[ RESPONSE ]--------------------------------------\n%s%s\n-----------------------------------------------------",
+		t.name, respData, redactBody(body))
+
+	return resp, nil
+}
+
+// redactRequestBody returns the redacted body of req, read from a copy so
+// that the request can still be sent.
+func redactRequestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if req.GetBody == nil {
+		return "<body not logged>"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "<body not logged>"
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "<body not logged>"
+	}
+	return redactBody(data)
+}
+
+// redactBody returns body with the values of JSON objects replaced, except
+// for the top-level keys in logKeptKeys. Bodies that aren't JSON objects
+// are left out.
+func redactBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Sprintf("<%d bytes redacted>", len(body))
+	}
+	for k, v := range data {
+		if !logKeptKeys[k] {
+			data[k] = redactValue(v)
+		}
+	}
+	var redacted bytes.Buffer
+	enc := json.NewEncoder(&redacted)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return fmt.Sprintf("<%d bytes redacted>", len(body))
+	}
+	return strings.TrimSuffix(redacted.String(), "\n")
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = redactValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	case nil:
+		return nil
+	default:
+		return "<redacted>"
+	}
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", "", ""},
+		{"not JSON", "s3cr3t", "<6 bytes redacted>"},
+		{"values", `{"password":"s3cr3t","ttl":300}`, `{"password":"<redacted>","ttl":"<redacted>"}`},
+		{"nested", `{"data":{"keys":["a","b"],"none":null}}`, `{"data":{"keys":["<redacted>","<redacted>"],"none":null}}`},
+		{"kept keys", `{"errors":["permission denied"],"lease_duration":60,"data":{"token":"s3cr3t"}}`, `{"data":{"token":"<redacted>"},"errors":["permission denied"],"lease_duration":60}`},
+	}
+
+	for _, c := range cases {
+		if got := redactBody([]byte(c.body)); got != c.want {
+			t.Errorf("%s: got %s; want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestLogTransport(t *testing.T) {
+	defer func(v string) { os.Setenv("TF_LOG", v) }(os.Getenv("TF_LOG"))
+	os.Setenv("TF_LOG", "DEBUG")
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"password":"s3cr3t"}` {
+			t.Errorf("body was not sent, got %q", body)
+		}
+		w.Write([]byte(`{"data":{"password":"s3cr3t"}}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newLogTransport("Vault", http.DefaultTransport)}
+	req, err := http.NewRequest("PUT", server.URL+"/v1/secret/foo", strings.NewReader(`{"password":"s3cr3t"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Vault-Token", "t0k3n")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != `{"data":{"password":"s3cr3t"}}` {
+		t.Errorf("response body was not kept, got %q", body)
+	}
+	for _, secret := range []string{"s3cr3t", "t0k3n"} {
+		if strings.Contains(logged.String(), secret) {
+			t.Errorf("%q was logged:\n%s", secret, logged.String())
+		}
+	}
+	if !strings.Contains(logged.String(), `{"password":"<redacted>"}`) {
+		t.Errorf("redacted request body not logged:\n%s", logged.String())
+	}
+}
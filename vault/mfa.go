@@ -0,0 +1,107 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// MFA methods are only available in Vault Enterprise. They live under
+// sys/mfa/method/<type>/<name> and are required on paths through the
+// mfa_methods of policies. Every method can be replaced but not updated, so
+// all of their arguments force a new method. These are the step-up MFA
+// methods, unrelated to the login MFA methods of identity/mfa that
+// vault_identity_mfa_login_enforcement refers to.
+
+// mfaMethodSchema returns the arguments shared by the MFA methods, with
+// mount_accessor and username_format for the methods tied to the identity
+// of an auth backend.
+func mfaMethodSchema(kind string, withMountAccessor bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: fmt.Sprintf("Name of the %s MFA method.", kind),
+		},
+	}
+	if withMountAccessor {
+		s["mount_accessor"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Accessor of the auth backend whose aliases are the usernames of the method.",
+		}
+		s["username_format"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Format of the usernames of the method, e.g. {{alias.name}}@example.com, the alias name if empty.",
+		}
+	}
+	return s
+}
+
+func mfaMethodPath(methodType, name string) string {
+	return "sys/mfa/method/" + methodType + "/" + name
+}
+
+// mfaMethodWrite writes the method of the given type with its data, along
+// with the shared arguments that are set.
+func mfaMethodWrite(d *schema.ResourceData, client *api.Client, methodType, kind string, data map[string]interface{}) error {
+	name := d.Get("name").(string)
+
+	for _, k := range []string{"mount_accessor", "username_format"} {
+		if v, ok := d.GetOk(k); ok {
+			data[k] = v
+		}
+	}
+
+	log.Printf("[DEBUG] Writing %s MFA method %q to Vault", kind, name)
+	if _, err := client.Logical().Write(mfaMethodPath(methodType, name), data); err != nil {
+		return fmt.Errorf("error writing %s MFA method %q: %s", kind, name, err)
+	}
+
+	d.SetId(name)
+
+	return nil
+}
+
+// mfaMethodRead reads the method of the given type into d, returning its
+// data, or nil if it no longer exists.
+func mfaMethodRead(d *schema.ResourceData, client *api.Client, methodType, kind string) (map[string]interface{}, error) {
+	name := d.Id()
+
+	log.Printf("[DEBUG] Reading %s MFA method %q from Vault", kind, name)
+	secret, err := client.Logical().Read(mfaMethodPath(methodType, name))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s MFA method %q: %s", kind, name, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] %s MFA method %q not found, removing from state", kind, name)
+		d.SetId("")
+		return nil, nil
+	}
+
+	d.Set("name", name)
+	for _, k := range []string{"mount_accessor", "username_format"} {
+		if v, ok := secret.Data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+
+	return secret.Data, nil
+}
+
+func mfaMethodDelete(d *schema.ResourceData, client *api.Client, methodType, kind string) error {
+	name := d.Id()
+
+	log.Printf("[DEBUG] Deleting %s MFA method %q from Vault", kind, name)
+	if _, err := client.Logical().Delete(mfaMethodPath(methodType, name)); err != nil {
+		return fmt.Errorf("error deleting %s MFA method %q: %s", kind, name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package vault
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// pkiSecretBackendSubjectSchema returns the fields describing the subject
+// and key of the CA certificates and CSRs generated by a PKI secret
+// backend. Generated certificates can't be changed, so all of them force
+// a new resource.
+func pkiSecretBackendSubjectSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"common_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Common name of the certificate.",
+		},
+
+		"alt_names": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Subject alternative DNS names and email addresses.",
+		},
+
+		"ip_sans": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Subject alternative IP addresses.",
+		},
+
+		"exclude_cn_from_sans": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "Don't include the common name in the subject alternative names.",
+		},
+
+		"ou": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Organizational unit of the subject.",
+		},
+
+		"organization": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Organization of the subject.",
+		},
+
+		"country": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Country of the subject.",
+		},
+
+		"locality": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Locality of the subject.",
+		},
+
+		"province": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Province of the subject.",
+		},
+
+		"key_type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     "rsa",
+			Description: "Type of the generated key: rsa or ec.",
+		},
+
+		"key_bits": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     2048,
+			Description: "Number of bits of the generated key.",
+		},
+	}
+}
+
+// pkiSecretBackendSubjectData returns the request fields of
+// pkiSecretBackendSubjectSchema.
+func pkiSecretBackendSubjectData(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"common_name":          d.Get("common_name").(string),
+		"alt_names":            toStringArray(d.Get("alt_names").([]interface{})),
+		"ip_sans":              toStringArray(d.Get("ip_sans").([]interface{})),
+		"exclude_cn_from_sans": d.Get("exclude_cn_from_sans").(bool),
+		"ou":                   d.Get("ou").(string),
+		"organization":         d.Get("organization").(string),
+		"country":              d.Get("country").(string),
+		"locality":             d.Get("locality").(string),
+		"province":             d.Get("province").(string),
+		"key_type":             d.Get("key_type").(string),
+		"key_bits":             d.Get("key_bits").(int),
+		"format":               "pem",
+	}
+}
+
+// pkiSecretBackendTypeSchema is the field choosing whether the private key
+// generated along with a certificate or CSR is returned.
+func pkiSecretBackendTypeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Type of the generated key: exported returns the private key, internal keeps it in Vault.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			switch v.(string) {
+			case "internal", "exported":
+			default:
+				errs = append(errs, fmt.Errorf("%s must be either internal or exported, got %q", k, v))
+			}
+			return
+		},
+	}
+}
+
+// pkiSecretBackendCertNeedsRenewal reports whether a certificate expiring
+// at the given Unix time has less than minSecondsRemaining left at now.
+func pkiSecretBackendCertNeedsRenewal(expiration int64, minSecondsRemaining int, now time.Time) bool {
+	return time.Unix(expiration, 0).Sub(now) < time.Duration(minSecondsRemaining)*time.Second
+}
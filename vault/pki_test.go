@@ -0,0 +1,28 @@
+package vault
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPKISecretBackendCertNeedsRenewal(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	tests := []struct {
+		expiration          int64
+		minSecondsRemaining int
+		want                bool
+	}{
+		{now.Unix() + 3600, 600, false},
+		{now.Unix() + 3600, 3600, false},
+		{now.Unix() + 3599, 3600, true},
+		{now.Unix() - 1, 0, true},
+		{now.Unix() + 1, 0, false},
+	}
+	for _, test := range tests {
+		got := pkiSecretBackendCertNeedsRenewal(test.expiration, test.minSecondsRemaining, now)
+		if got != test.want {
+			t.Errorf("renewal of certificate expiring in %ds with %ds required is %t; want %t",
+				test.expiration-now.Unix(), test.minSecondsRemaining, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// The AWS, GCP and Azure secret backends of Vault Enterprise can get their
+// cloud credentials through workload identity federation instead of root
+// credentials, exchanging plugin identity tokens issued by Vault. The cloud
+// provider is set up to trust the tokens with the configured audience whose
+// subject is plugin-identity:<namespace ID>:secret:<accessor>, where
+// accessor is the one of the mount of the backend. The key signing the
+// tokens is a setting of the mount rather than of the config of the backend.
+
+// pluginIdentityWriteData adds the plugin identity token settings to the
+// config data of a backend.
+func pluginIdentityWriteData(d *schema.ResourceData, data map[string]interface{}) {
+	data["identity_token_audience"] = d.Get("identity_token_audience").(string)
+	data["identity_token_ttl"] = d.Get("identity_token_ttl").(int)
+}
+
+// pluginIdentityTuneKey tunes the mount of the backend at path with the key
+// signing its plugin identity tokens.
+func pluginIdentityTuneKey(d *schema.ResourceData, client *api.Client, path string) error {
+	return mountTune(client, path, map[string]interface{}{
+		"identity_token_key": d.Get("identity_token_key").(string),
+	})
+}
+
+// pluginIdentityRead reads the plugin identity token settings of the backend
+// mounted at path from its config data, which is nil if it couldn't be
+// read, along with the signing key and accessor of its mount.
+func pluginIdentityRead(d *schema.ResourceData, client *api.Client, path string, config map[string]interface{}) error {
+	if config != nil {
+		if v, ok := config["identity_token_audience"]; ok {
+			d.Set("identity_token_audience", v)
+		}
+		if v, ok := config["identity_token_ttl"]; ok {
+			d.Set("identity_token_ttl", durationSecondsFromResponse(v))
+		}
+	}
+
+	tune, err := client.Logical().Read("sys/mounts/" + path + "/tune")
+	if err != nil {
+		return fmt.Errorf("error reading tuning of mount %s from Vault: %s", path, err)
+	}
+	if tune != nil {
+		d.Set("identity_token_key", tune.Data["identity_token_key"])
+	}
+
+	accessor, err := mountAccessor(client, path)
+	if err != nil {
+		return err
+	}
+	d.Set("accessor", accessor)
+
+	return nil
+}
+
+// mountAccessor returns the accessor of the secret backend mounted at path.
+// Like for auth backends, the mount output of the API client doesn't
+// include it, so it is taken from the raw listing.
+func mountAccessor(client *api.Client, path string) (string, error) {
+	log.Printf("[DEBUG] Reading accessor of mount %q", path)
+	secret, err := client.Logical().Read("sys/mounts")
+	if err != nil {
+		return "", fmt.Errorf("error reading mounts from Vault: %s", err)
+	}
+	if secret == nil {
+		return "", nil
+	}
+
+	mount, ok := secret.Data[strings.Trim(path, "/")+"/"].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	accessor, _ := mount["accessor"].(string)
+	return accessor, nil
+}
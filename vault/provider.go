@@ -2,15 +2,14 @@ package vault
 
 import (
 	"fmt"
-	"io/ioutil"
 	"log"
+	"os"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/hashicorp/vault/api"
-	"github.com/mitchellh/go-homedir"
 )
 
 func Provider() terraform.ResourceProvider {
@@ -24,10 +23,12 @@ func Provider() terraform.ResourceProvider {
 			},
 			"token": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VAULT_TOKEN", ""),
 				Description: "Token to use to authenticate to Vault.",
 			},
+			"auth_login_approle": authLoginAppRoleSchema(),
+			"auth_login_aws":     authLoginAWSSchema(),
 			"ca_cert_file": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -61,6 +62,12 @@ func Provider() terraform.ResourceProvider {
 					},
 				},
 			},
+			"tls_server_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_TLS_SERVER_NAME", ""),
+				Description: "Name to use as the SNI host and to verify the server's certificate against, instead of the host of the address.",
+			},
 			"skip_tls_verify": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -79,19 +86,154 @@ func Provider() terraform.ResourceProvider {
 
 				Description: "Maximum TTL for secret leases requested by this provider",
 			},
+			"token_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "terraform",
+				Description: "Display name of the child token created by the provider.",
+			},
+			"skip_child_token": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TERRAFORM_VAULT_SKIP_CHILD_TOKEN", false),
+				Description: "Use the given token directly instead of creating a limited child token, e.g. when child tokens can't be created.",
+			},
+			"namespace": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_NAMESPACE", ""),
+				Description: "Namespace of Vault Enterprise to send all requests to, relative to the namespace of the token.",
+			},
+			"forward_to_active_node": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Forward all requests to the active node instead of letting performance standbys serve them.",
+			},
+			"max_retries": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_MAX_RETRIES", 2),
+				Description: "Times to retry failed requests, 0 to never retry.",
+			},
+			"retry_on": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "HTTP status codes of the responses to retry, instead of any 5xx status.",
+			},
+			"retry_backoff_ms": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     500,
+				Description: "Milliseconds to wait before the first retry of a request, doubled on every further retry.",
+			},
+			"max_retry_backoff_ms": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30000,
+				Description: "Maximum milliseconds to wait before a retry.",
+			},
 		},
 
 		ConfigureFunc: providerConfigure,
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"vault_generic_secret": genericSecretDataSource(),
+			"vault_approle_auth_backend_role_id":  approleAuthBackendRoleIDDataSource(),
+			"vault_auth_backend":                  authBackendDataSource(),
+			"vault_auth_backends":                 authBackendsDataSource(),
+			"vault_aws_access_credentials":        awsAccessCredentialsDataSource(),
+			"vault_aws_static_access_credentials": awsStaticAccessCredentialsDataSource(),
+			"vault_generic_secret":                genericSecretDataSource(),
+			"vault_kv_secret_v2":                  kvSecretV2DataSource(),
+			"vault_kv_secrets_list":               kvSecretsListDataSource(),
+			"vault_kv_secrets_list_v2":            kvSecretsListV2DataSource(),
+			"vault_mounts":                        mountsDataSource(),
+			"vault_policy_document":               policyDocumentDataSource(),
+			"vault_raft_autopilot_state":          raftAutopilotStateDataSource(),
+			"vault_ssh_secret_backend_ca":         sshSecretBackendCADataSource(),
+			"vault_transit_rewrap":                transitRewrapDataSource(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"vault_auth_backend":   authBackendResource(),
-			"vault_generic_secret": genericSecretResource(),
-			"vault_policy":         policyResource(),
-			"vault_mount":          mountResource(),
+			"vault_approle_auth_backend_role":                    approleAuthBackendRoleResource(),
+			"vault_approle_auth_backend_role_secret_id":          approleAuthBackendRoleSecretIDResource(),
+			"vault_aws_secret_backend":                           awsSecretBackendResource(),
+			"vault_aws_secret_backend_role":                      awsSecretBackendRoleResource(),
+			"vault_aws_secret_backend_static_role":               awsSecretBackendStaticRoleResource(),
+			"vault_audit":                                        auditResource(),
+			"vault_auth_backend":                                 authBackendResource(),
+			"vault_auth_backend_config_sts":                      authBackendConfigSTSResource(),
+			"vault_azure_secret_backend":                         azureSecretBackendResource(),
+			"vault_azure_secret_backend_role":                    azureSecretBackendRoleResource(),
+			"vault_cert_auth_backend_role":                       certAuthBackendRoleResource(),
+			"vault_consul_secret_backend":                        consulSecretBackendResource(),
+			"vault_consul_secret_backend_role":                   consulSecretBackendRoleResource(),
+			"vault_cors":                                         corsResource(),
+			"vault_database_secret_backend_connection":           databaseSecretBackendConnectionResource(),
+			"vault_database_secret_backend_role":                 databaseSecretBackendRoleResource(),
+			"vault_database_secret_backend_root_rotation":        databaseSecretBackendRootRotationResource(),
+			"vault_egp_policy":                                   egpPolicyResource(),
+			"vault_gcp_secret_backend":                           gcpSecretBackendResource(),
+			"vault_gcp_secret_roleset":                           gcpSecretRolesetResource(),
+			"vault_generic_endpoint":                             genericEndpointResource(),
+			"vault_generic_secret":                               genericSecretResource(),
+			"vault_github_auth_backend":                          githubAuthBackendResource(),
+			"vault_github_team":                                  githubTeamResource(),
+			"vault_github_user":                                  githubUserResource(),
+			"vault_identity_entity":                              identityEntityResource(),
+			"vault_identity_entity_alias":                        identityEntityAliasResource(),
+			"vault_identity_group":                               identityGroupResource(),
+			"vault_identity_group_alias":                         identityGroupAliasResource(),
+			"vault_identity_mfa_login_enforcement":               identityMFALoginEnforcementResource(),
+			"vault_identity_oidc_client":                         identityOIDCClientResource(),
+			"vault_identity_oidc_role":                           identityOIDCRoleResource(),
+			"vault_jwt_auth_backend":                             jwtAuthBackendResource(),
+			"vault_jwt_auth_backend_role":                        jwtAuthBackendRoleResource(),
+			"vault_kubernetes_auth_backend_config":               kubernetesAuthBackendConfigResource(),
+			"vault_kubernetes_auth_backend_role":                 kubernetesAuthBackendRoleResource(),
+			"vault_kv_secret_subtree":                            kvSecretSubtreeResource(),
+			"vault_kv_secret_v2":                                 kvSecretV2Resource(),
+			"vault_ldap_auth_backend":                            ldapAuthBackendResource(),
+			"vault_ldap_auth_backend_group":                      ldapAuthBackendGroupResource(),
+			"vault_ldap_auth_backend_user":                       ldapAuthBackendUserResource(),
+			"vault_lease":                                        leaseResource(),
+			"vault_mfa_duo":                                      mfaDuoResource(),
+			"vault_mfa_okta":                                     mfaOktaResource(),
+			"vault_mfa_pingid":                                   mfaPingIDResource(),
+			"vault_mfa_totp":                                     mfaTOTPResource(),
+			"vault_namespace":                                    namespaceResource(),
+			"vault_okta_auth_backend":                            oktaAuthBackendResource(),
+			"vault_okta_auth_backend_group":                      oktaAuthBackendGroupResource(),
+			"vault_okta_auth_backend_user":                       oktaAuthBackendUserResource(),
+			"vault_policy":                                       policyResource(),
+			"vault_mount":                                        mountResource(),
+			"vault_pki_secret_backend_cert":                      pkiSecretBackendCertResource(),
+			"vault_pki_secret_backend_config_cluster":            pkiSecretBackendConfigClusterResource(),
+			"vault_pki_secret_backend_intermediate_cert_request": pkiSecretBackendIntermediateCertRequestResource(),
+			"vault_pki_secret_backend_intermediate_set_signed":   pkiSecretBackendIntermediateSetSignedResource(),
+			"vault_pki_secret_backend_issuer":                    pkiSecretBackendIssuerResource(),
+			"vault_pki_secret_backend_role":                      pkiSecretBackendRoleResource(),
+			"vault_pki_secret_backend_root_cert":                 pkiSecretBackendRootCertResource(),
+			"vault_pki_secret_backend_sign_intermediate":         pkiSecretBackendSignIntermediateResource(),
+			"vault_quota_lease_count":                            quotaLeaseCountResource(),
+			"vault_quota_rate_limit":                             quotaRateLimitResource(),
+			"vault_rabbitmq_secret_backend":                      rabbitMQSecretBackendResource(),
+			"vault_rabbitmq_secret_backend_role":                 rabbitMQSecretBackendRoleResource(),
+			"vault_raft_autopilot":                               raftAutopilotResource(),
+			"vault_rgp_policy":                                   rgpPolicyResource(),
+			"vault_ssh_secret_backend_ca":                        sshSecretBackendCAResource(),
+			"vault_ssh_secret_backend_role":                      sshSecretBackendRoleResource(),
+			"vault_ssh_secret_backend_sign":                      sshSecretBackendSignResource(),
+			"vault_token":                                        tokenResource(),
+			"vault_transform_alphabet":                           transformAlphabetResource(),
+			"vault_transform_role":                               transformRoleResource(),
+			"vault_transform_template":                           transformTemplateResource(),
+			"vault_transform_transformation":                     transformTransformationResource(),
+			"vault_transit_secret_backend_key":                   transitSecretBackendKeyResource(),
+			"vault_transit_secret_backend_key_rotation":          transitSecretBackendKeyRotationResource(),
+			"vault_transit_secret_cache_config":                  transitSecretCacheConfigResource(),
+			"vault_userpass_auth_backend_user":                   userpassAuthBackendUserResource(),
 		},
 	}
 }
@@ -105,8 +247,10 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, fmt.Errorf("client_auth block may appear only once")
 	}
 
-	clientAuthCert := ""
-	clientAuthKey := ""
+	// Like the Vault CLI, the client certificate can also be given only
+	// through the environment.
+	clientAuthCert := os.Getenv("VAULT_CLIENT_CERT")
+	clientAuthKey := os.Getenv("VAULT_CLIENT_KEY")
 	if len(clientAuthI) == 1 {
 		clientAuth := clientAuthI[0].(map[string]interface{})
 		clientAuthCert = clientAuth["cert_file"].(string)
@@ -118,6 +262,8 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		CAPath:   d.Get("ca_cert_dir").(string),
 		Insecure: d.Get("skip_tls_verify").(bool),
 
+		TLSServerName: d.Get("tls_server_name").(string),
+
 		ClientCert: clientAuthCert,
 		ClientKey:  clientAuthKey,
 	})
@@ -125,26 +271,54 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, fmt.Errorf("failed to configure TLS for Vault API: %s", err)
 	}
 
-	config.HttpClient.Transport = logging.NewTransport("Vault", config.HttpClient.Transport)
+	transport := newVaultTransport(newLogTransport("Vault", config.HttpClient.Transport))
+	if d.Get("forward_to_active_node").(bool) {
+		transport.headers.Set("X-Vault-Forward", "active-node")
+	}
+	// Setting the namespace on the transport also applies it to the login
+	// and the child token, which then belong to the namespace.
+	if namespace := strings.Trim(d.Get("namespace").(string), "/"); namespace != "" {
+		transport.headers.Set("X-Vault-Namespace", namespace)
+	}
+	retryOn := map[int]bool{}
+	for _, code := range d.Get("retry_on").(*schema.Set).List() {
+		retryOn[code.(int)] = true
+	}
+	config.HttpClient.Transport = &retryTransport{
+		base:       transport,
+		maxRetries: d.Get("max_retries").(int),
+		retryOn:    retryOn,
+		backoff:    time.Duration(d.Get("retry_backoff_ms").(int)) * time.Millisecond,
+		maxBackoff: time.Duration(d.Get("max_retry_backoff_ms").(int)) * time.Millisecond,
+	}
+	// The API client retries 5xx responses on its own, which is disabled so
+	// that requests are only retried following the policy of the transport.
+	config.MaxRetries = 0
 
 	client, err := api.NewClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure Vault API: %s", err)
 	}
+	registerClientConfig(client, config)
 
-	token := d.Get("token").(string)
+	token, revoke, err := providerLogin(d, client)
+	if err != nil {
+		return nil, err
+	}
+	if revoke {
+		if err := registerTokenRevocation(config, token); err != nil {
+			return nil, err
+		}
+	}
+	if token == "" {
+		token = d.Get("token").(string)
+	}
 	if token == "" {
 		// Use the vault CLI's token, if present.
-		homePath, err := homedir.Dir()
-		if err != nil {
-			return nil, fmt.Errorf("Can't find home directory when looking for ~/.vault-token: %s", err)
-		}
-		tokenBytes, err := ioutil.ReadFile(homePath + "/.vault-token")
+		token, err = cliToken()
 		if err != nil {
-			return nil, fmt.Errorf("No vault token found: %s", err)
+			return nil, err
 		}
-
-		token = strings.TrimSpace(string(tokenBytes))
 	}
 
 	// In order to enforce our relatively-short lease TTL, we derive a
@@ -161,9 +335,21 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	// any secrets that are *written* by Terraform to Vault.
 
 	client.SetToken(token)
+
+	// The given token has to last for the whole run, either because it's
+	// used directly or because the child token can't outlive it.
+	if err := ensureProviderTokenTTL(client, d.Get("max_lease_ttl_seconds").(int)); err != nil {
+		return nil, err
+	}
+
+	if d.Get("skip_child_token").(bool) {
+		log.Printf("[INFO] Using the Vault token directly, without a limited child token")
+		return client, nil
+	}
+
 	renewable := false
 	childTokenLease, err := client.Auth().Token().Create(&api.TokenCreateRequest{
-		DisplayName:    "terraform",
+		DisplayName:    d.Get("token_name").(string),
 		TTL:            fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds").(int)),
 		ExplicitMaxTTL: fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds").(int)),
 		Renewable:      &renewable,
@@ -181,3 +367,46 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
 	return client, nil
 }
+
+// providerTokenExpiresEarly reports whether a token with ttl seconds left
+// expires before minTTL seconds. Tokens with a TTL of 0 never expire.
+func providerTokenExpiresEarly(ttl, minTTL int) bool {
+	return ttl > 0 && ttl < minTTL
+}
+
+// ensureProviderTokenTTL checks that the token of the client is valid for at
+// least minTTL seconds, renewing it if possible, so that runs fail when the
+// provider is configured instead of halfway through an apply.
+func ensureProviderTokenTTL(client *api.Client, minTTL int) error {
+	secret, err := client.Auth().Token().LookupSelf()
+	if err != nil {
+		// Tokens without the default policy may not be able to look
+		// themselves up, in which case their TTL is left unchecked.
+		log.Printf("[WARN] Can't look up the Vault token to check its TTL: %s", err)
+		return nil
+	}
+	if secret == nil {
+		return nil
+	}
+
+	ttl := intFromResponse(secret.Data["ttl"])
+	if !providerTokenExpiresEarly(ttl, minTTL) {
+		return nil
+	}
+
+	if renewable, _ := secret.Data["renewable"].(bool); renewable {
+		log.Printf("[INFO] Renewing the Vault token, which expires in %ds", ttl)
+		renewed, err := client.Auth().Token().RenewSelf(minTTL)
+		if err != nil {
+			return fmt.Errorf("error renewing the Vault token, which expires in %ds: %s", ttl, err)
+		}
+		if renewed != nil && renewed.Auth != nil {
+			ttl = renewed.Auth.LeaseDuration
+		}
+	}
+
+	if providerTokenExpiresEarly(ttl, minTTL) {
+		return fmt.Errorf("the Vault token expires in %ds, before max_lease_ttl_seconds (%ds); use a token with a longer TTL or lower max_lease_ttl_seconds", ttl, minTTL)
+	}
+	return nil
+}
@@ -40,6 +40,23 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProviderTokenExpiresEarly(t *testing.T) {
+	cases := []struct {
+		ttl, minTTL int
+		expected    bool
+	}{
+		{ttl: 0, minTTL: 1200, expected: false},
+		{ttl: 600, minTTL: 1200, expected: true},
+		{ttl: 1200, minTTL: 1200, expected: false},
+		{ttl: 3600, minTTL: 1200, expected: false},
+	}
+	for _, c := range cases {
+		if got := providerTokenExpiresEarly(c.ttl, c.minTTL); got != c.expected {
+			t.Errorf("ttl %d, min TTL %d: expected %t, got %t", c.ttl, c.minTTL, c.expected, got)
+		}
+	}
+}
+
 var testProvider *schema.Provider
 var testProviders map[string]terraform.ResourceProvider
 
@@ -0,0 +1,227 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var approleAuthBackendRoleFromPathRegex = regexp.MustCompile("^auth/(.+)/role/([^/]+)$")
+
+func approleAuthBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: approleAuthBackendRoleWrite,
+		Update: approleAuthBackendRoleWrite,
+		Delete: approleAuthBackendRoleDelete,
+		Read:   approleAuthBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "approle",
+				Description: "Path of the AppRole auth backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"role_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"role_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "RoleID of the role, generated by Vault unless set.",
+			},
+
+			"bind_secret_id": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether a SecretID must be presented to log in with the role.",
+			},
+
+			"secret_id_bound_cidrs": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "CIDR blocks of the addresses allowed to log in with the SecretIDs of the role.",
+			},
+
+			"secret_id_num_uses": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Number of times a SecretID can be used to log in, unlimited if 0.",
+			},
+
+			"secret_id_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Time in seconds after which SecretIDs expire, never if 0.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies of the tokens issued for the role.",
+			},
+
+			"token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_num_uses": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Number of times the tokens issued for the role can be used, unlimited if 0.",
+			},
+
+			"token_bound_cidrs": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "CIDR blocks of the addresses allowed to use the tokens issued for the role.",
+			},
+
+			"period": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Period in seconds of the tokens issued for the role, making them periodic if set.",
+			},
+		},
+	}
+}
+
+func approleAuthBackendRolePath(backend, name string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/role/" + strings.Trim(name, "/")
+}
+
+func approleAuthBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := approleAuthBackendRolePath(d.Get("backend").(string), d.Get("role_name").(string))
+
+	data := map[string]interface{}{
+		"bind_secret_id":        d.Get("bind_secret_id").(bool),
+		"secret_id_bound_cidrs": d.Get("secret_id_bound_cidrs").(*schema.Set).List(),
+		"secret_id_num_uses":    d.Get("secret_id_num_uses").(int),
+		"secret_id_ttl":         d.Get("secret_id_ttl").(int),
+		"policies":              d.Get("policies").(*schema.Set).List(),
+		"token_ttl":             d.Get("token_ttl").(int),
+		"token_max_ttl":         d.Get("token_max_ttl").(int),
+		"token_num_uses":        d.Get("token_num_uses").(int),
+		"token_bound_cidrs":     d.Get("token_bound_cidrs").(*schema.Set).List(),
+		"period":                d.Get("period").(int),
+	}
+
+	log.Printf("[DEBUG] Writing AppRole role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing AppRole role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	// A role is always created with a generated RoleID, which is replaced
+	// when one is configured.
+	if v, ok := d.GetOk("role_id"); ok && (d.IsNewResource() || d.HasChange("role_id")) {
+		log.Printf("[DEBUG] Writing RoleID of AppRole role %q to Vault", path)
+		if _, err := client.Logical().Write(path+"/role-id", map[string]interface{}{
+			"role_id": v.(string),
+		}); err != nil {
+			return fmt.Errorf("error writing RoleID of AppRole role %q: %s", path, err)
+		}
+	}
+
+	return approleAuthBackendRoleRead(d, meta)
+}
+
+func approleAuthBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := approleAuthBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid AppRole role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading AppRole role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading AppRole role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] AppRole role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("role_name", res[2])
+	d.Set("bind_secret_id", secret.Data["bind_secret_id"])
+	d.Set("secret_id_num_uses", intFromResponse(secret.Data["secret_id_num_uses"]))
+	d.Set("secret_id_ttl", intFromResponse(secret.Data["secret_id_ttl"]))
+	d.Set("token_ttl", intFromResponse(secret.Data["token_ttl"]))
+	d.Set("token_max_ttl", intFromResponse(secret.Data["token_max_ttl"]))
+	d.Set("token_num_uses", intFromResponse(secret.Data["token_num_uses"]))
+
+	if err := d.Set("policies", flattenStringList(tokenSetting(secret.Data, "token_policies", "policies"))); err != nil {
+		return fmt.Errorf("error setting policies of AppRole role %q: %s", path, err)
+	}
+	d.Set("period", intFromResponse(tokenSetting(secret.Data, "token_period", "period")))
+
+	for _, k := range []string{"secret_id_bound_cidrs", "token_bound_cidrs"} {
+		if err := d.Set(k, flattenStringList(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of AppRole role %q: %s", k, path, err)
+		}
+	}
+
+	roleID, err := client.Logical().Read(path + "/role-id")
+	if err != nil {
+		return fmt.Errorf("error reading RoleID of AppRole role %q: %s", path, err)
+	}
+	if roleID != nil {
+		d.Set("role_id", roleID.Data["role_id"])
+	}
+
+	return nil
+}
+
+func approleAuthBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting AppRole role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting AppRole role %q: %s", path, err)
+	}
+
+	return nil
+}
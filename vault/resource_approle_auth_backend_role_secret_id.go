@@ -0,0 +1,237 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var approleAuthBackendRoleSecretIDFromPathRegex = regexp.MustCompile("^(auth/.+/role/[^/]+)/secret-id-accessor/(.+)$")
+
+func approleAuthBackendRoleSecretIDResource() *schema.Resource {
+	return &schema.Resource{
+		Create: approleAuthBackendRoleSecretIDCreate,
+		Delete: approleAuthBackendRoleSecretIDDelete,
+		Read:   approleAuthBackendRoleSecretIDRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "approle",
+				Description: "Path of the AppRole auth backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"role_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"secret_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "The SecretID, generated by Vault unless set.",
+			},
+
+			"cidr_list": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "CIDR blocks of the addresses allowed to log in with the SecretID.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Time in seconds after which the SecretID expires, overriding the secret_id_ttl of the role.",
+			},
+
+			"num_uses": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Number of times the SecretID can be used to log in, overriding the secret_id_num_uses of the role.",
+			},
+
+			"metadata": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "{}",
+				Description:  "JSON-encoded object of strings attached to the tokens issued with the SecretID.",
+				StateFunc:    NormalizeDataJSON,
+				ValidateFunc: ValidateDataJSON,
+			},
+
+			"wrapping_ttl": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Return the SecretID wrapped in a token valid for this duration, e.g. 60s, instead of in the state.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Accessor of the SecretID.",
+			},
+
+			"wrapping_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token wrapping the SecretID, when wrapping_ttl is set.",
+			},
+		},
+	}
+}
+
+func approleAuthBackendRoleSecretIDCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	rolePath := approleAuthBackendRolePath(d.Get("backend").(string), d.Get("role_name").(string))
+
+	data := map[string]interface{}{
+		"cidr_list": d.Get("cidr_list").(*schema.Set).List(),
+		// The metadata is passed as a JSON-encoded string.
+		"metadata": d.Get("metadata").(string),
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		data["ttl"] = v.(int)
+	}
+	if v, ok := d.GetOk("num_uses"); ok {
+		data["num_uses"] = v.(int)
+	}
+
+	path := rolePath + "/secret-id"
+	if v, ok := d.GetOk("secret_id"); ok {
+		path = rolePath + "/custom-secret-id"
+		data["secret_id"] = v.(string)
+	}
+
+	var accessor string
+	if wrapTTL, ok := d.GetOk("wrapping_ttl"); ok {
+		log.Printf("[DEBUG] Writing wrapped AppRole SecretID to %q", path)
+		resp, err := writeWrapped(client, path, data, wrapTTL.(string))
+		if err != nil {
+			return fmt.Errorf("error writing AppRole SecretID to %q: %s", path, err)
+		}
+		if resp == nil || resp.WrapInfo == nil {
+			return fmt.Errorf("no wrapped response writing AppRole SecretID to %q", path)
+		}
+
+		// The wrapped SecretID itself is never known to Terraform.
+		d.Set("secret_id", "")
+		d.Set("wrapping_token", resp.WrapInfo.Token)
+		accessor = resp.WrapInfo.WrappedAccessor
+	} else {
+		log.Printf("[DEBUG] Writing AppRole SecretID to %q", path)
+		resp, err := client.Logical().Write(path, data)
+		if err != nil {
+			return fmt.Errorf("error writing AppRole SecretID to %q: %s", path, err)
+		}
+		if resp == nil {
+			return fmt.Errorf("no response writing AppRole SecretID to %q", path)
+		}
+
+		d.Set("secret_id", resp.Data["secret_id"])
+		accessor, _ = resp.Data["secret_id_accessor"].(string)
+	}
+	if accessor == "" {
+		return fmt.Errorf("no accessor returned for the AppRole SecretID written to %q", path)
+	}
+
+	d.SetId(rolePath + "/secret-id-accessor/" + accessor)
+
+	return approleAuthBackendRoleSecretIDRead(d, meta)
+}
+
+// approleSecretIDAccessorNotFound reports whether err is the error that
+// Vault versions before 1.1 return when looking up or destroying a
+// SecretID accessor that doesn't exist, e.g. because it expired.
+func approleSecretIDAccessorNotFound(err error) bool {
+	return strings.Contains(err.Error(), "failed to find accessor entry")
+}
+
+func approleAuthBackendRoleSecretIDRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	res := approleAuthBackendRoleSecretIDFromPathRegex.FindStringSubmatch(id)
+	if res == nil {
+		return fmt.Errorf("invalid AppRole SecretID ID %q", id)
+	}
+	rolePath, accessor := res[1], res[2]
+
+	log.Printf("[DEBUG] Looking up AppRole SecretID %q in Vault", id)
+	secret, err := client.Logical().Write(rolePath+"/secret-id-accessor/lookup", map[string]interface{}{
+		"secret_id_accessor": accessor,
+	})
+	if err != nil && !approleSecretIDAccessorNotFound(err) {
+		return fmt.Errorf("error looking up AppRole SecretID %q: %s", id, err)
+	}
+	if err != nil || secret == nil {
+		log.Printf("[WARN] AppRole SecretID %q not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	roleRes := approleAuthBackendRoleFromPathRegex.FindStringSubmatch(rolePath)
+	d.Set("backend", roleRes[1])
+	d.Set("role_name", roleRes[2])
+	d.Set("accessor", accessor)
+	if err := d.Set("cidr_list", flattenStringList(secret.Data["cidr_list"])); err != nil {
+		return fmt.Errorf("error setting cidr_list of AppRole SecretID %q: %s", id, err)
+	}
+
+	metadata := map[string]interface{}{}
+	if v, ok := secret.Data["metadata"].(map[string]interface{}); ok {
+		metadata = v
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("error encoding metadata of AppRole SecretID %q: %s", id, err)
+	}
+	d.Set("metadata", string(metadataJSON))
+
+	return nil
+}
+
+func approleAuthBackendRoleSecretIDDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	res := approleAuthBackendRoleSecretIDFromPathRegex.FindStringSubmatch(id)
+	if res == nil {
+		return fmt.Errorf("invalid AppRole SecretID ID %q", id)
+	}
+
+	log.Printf("[DEBUG] Destroying AppRole SecretID %q in Vault", id)
+	_, err := client.Logical().Write(res[1]+"/secret-id-accessor/destroy", map[string]interface{}{
+		"secret_id_accessor": res[2],
+	})
+	if err != nil && !approleSecretIDAccessorNotFound(err) {
+		return fmt.Errorf("error destroying AppRole SecretID %q: %s", id, err)
+	}
+
+	return nil
+}
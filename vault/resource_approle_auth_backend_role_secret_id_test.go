@@ -0,0 +1,132 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestApproleAuthBackendRoleSecretID(t *testing.T) {
+	backend := acctest.RandomWithPrefix("approle")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testApproleAuthBackendRoleSecretIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testApproleAuthBackendRoleSecretIDConfig(backend, `
+	cidr_list = ["0.0.0.0/0"]
+	metadata = "{\"team\": \"ci\"}"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vault_approle_auth_backend_role_secret_id.test", "secret_id"),
+					resource.TestCheckResourceAttrSet("vault_approle_auth_backend_role_secret_id.test", "accessor"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role_secret_id.test", "cidr_list.#", "1"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role_secret_id.test", "metadata", `{"team":"ci"}`),
+					func(s *terraform.State) error {
+						secretID := s.Modules[0].Resources["vault_approle_auth_backend_role_secret_id.test"].Primary.Attributes["secret_id"]
+						return testApproleLogin(s, backend, secretID)
+					},
+				),
+			},
+			{
+				Config: testApproleAuthBackendRoleSecretIDConfig(backend, `
+	secret_id = "custom-secret-id"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role_secret_id.test", "secret_id", "custom-secret-id"),
+					func(s *terraform.State) error {
+						return testApproleLogin(s, backend, "custom-secret-id")
+					},
+				),
+			},
+			{
+				Config: testApproleAuthBackendRoleSecretIDConfig(backend, `
+	wrapping_ttl = "60s"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role_secret_id.test", "secret_id", ""),
+					resource.TestCheckResourceAttrSet("vault_approle_auth_backend_role_secret_id.test", "accessor"),
+					func(s *terraform.State) error {
+						token := s.Modules[0].Resources["vault_approle_auth_backend_role_secret_id.test"].Primary.Attributes["wrapping_token"]
+						client := testProvider.Meta().(*api.Client)
+						secret, err := client.Logical().Unwrap(token)
+						if err != nil {
+							return fmt.Errorf("error unwrapping SecretID: %s", err)
+						}
+						if secret == nil {
+							return fmt.Errorf("nothing wrapped in %q", token)
+						}
+						secretID, _ := secret.Data["secret_id"].(string)
+						return testApproleLogin(s, backend, secretID)
+					},
+				),
+			},
+		},
+	})
+}
+
+// testApproleLogin checks that the role of the test can log in with the
+// given SecretID.
+func testApproleLogin(s *terraform.State, backend, secretID string) error {
+	roleID := s.Modules[0].Resources["vault_approle_auth_backend_role.test"].Primary.Attributes["role_id"]
+
+	client := testProvider.Meta().(*api.Client)
+	secret, err := client.Logical().Write("auth/"+backend+"/login", map[string]interface{}{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return fmt.Errorf("error logging in with the SecretID: %s", err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return fmt.Errorf("no token returned logging in with the SecretID")
+	}
+	return nil
+}
+
+func testApproleAuthBackendRoleSecretIDDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_approle_auth_backend_role_secret_id" {
+			continue
+		}
+		res := approleAuthBackendRoleSecretIDFromPathRegex.FindStringSubmatch(rs.Primary.ID)
+		if res == nil {
+			return fmt.Errorf("invalid AppRole SecretID ID %q", rs.Primary.ID)
+		}
+		secret, err := client.Logical().Write(res[1]+"/secret-id-accessor/lookup", map[string]interface{}{
+			"secret_id_accessor": res[2],
+		})
+		if err == nil && secret != nil {
+			return fmt.Errorf("AppRole SecretID %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testApproleAuthBackendRoleSecretIDConfig(backend, settings string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "approle" {
+	type = "approle"
+	path = "%s"
+}
+
+resource "vault_approle_auth_backend_role" "test" {
+	backend = "${vault_auth_backend.approle.path}"
+	role_name = "test"
+	policies = ["default"]
+}
+
+resource "vault_approle_auth_backend_role_secret_id" "test" {
+	backend = "${vault_auth_backend.approle.path}"
+	role_name = "${vault_approle_auth_backend_role.test.role_name}"
+%s
+}
+`, backend, settings)
+}
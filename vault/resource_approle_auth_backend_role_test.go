@@ -0,0 +1,111 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestApproleAuthBackendRole(t *testing.T) {
+	backend := acctest.RandomWithPrefix("approle")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testApproleAuthBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testApproleAuthBackendRoleConfig(backend, false, `
+	policies = ["default", "dev"]
+	token_ttl = 300
+	secret_id_bound_cidrs = ["10.0.0.0/8"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "id", "auth/"+backend+"/role/test"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "token_ttl", "300"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "secret_id_bound_cidrs.#", "1"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "bind_secret_id", "true"),
+					resource.TestCheckResourceAttrSet("vault_approle_auth_backend_role.test", "role_id"),
+				),
+			},
+			{
+				// The data source is only added once the role exists, as
+				// it is read before the resources are changed.
+				Config: testApproleAuthBackendRoleConfig(backend, true, `
+	policies = ["default", "dev"]
+	token_ttl = 300
+	secret_id_bound_cidrs = ["10.0.0.0/8"]
+`),
+				Check: resource.TestCheckResourceAttrPair(
+					"data.vault_approle_auth_backend_role_id.test", "role_id",
+					"vault_approle_auth_backend_role.test", "role_id",
+				),
+			},
+			{
+				Config: testApproleAuthBackendRoleConfig(backend, false, `
+	policies = ["dev"]
+	token_ttl = 600
+	role_id = "custom-role-id"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "token_ttl", "600"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "secret_id_bound_cidrs.#", "0"),
+					resource.TestCheckResourceAttr("vault_approle_auth_backend_role.test", "role_id", "custom-role-id"),
+				),
+			},
+			{
+				ResourceName:      "vault_approle_auth_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testApproleAuthBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_approle_auth_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for AppRole role %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("AppRole role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testApproleAuthBackendRoleConfig(backend string, withDataSource bool, settings string) string {
+	config := fmt.Sprintf(`
+resource "vault_auth_backend" "approle" {
+	type = "approle"
+	path = "%s"
+}
+
+resource "vault_approle_auth_backend_role" "test" {
+	backend = "${vault_auth_backend.approle.path}"
+	role_name = "test"
+%s
+}
+`, backend, settings)
+
+	if withDataSource {
+		config += `
+data "vault_approle_auth_backend_role_id" "test" {
+	backend = "${vault_auth_backend.approle.path}"
+	role_name = "test"
+}
+`
+	}
+	return config
+}
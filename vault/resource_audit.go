@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func auditResource() *schema.Resource {
+	return &schema.Resource{
+		Create: auditCreate,
+		Delete: auditDelete,
+		Read:   auditRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Type of the audit device: file, syslog or socket.",
+			},
+
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Path to enable the audit device at, the type if not set.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Human-friendly description of the audit device.",
+			},
+
+			"local": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Keep the audit device out of the replication to other clusters.",
+			},
+
+			"options": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Options of the audit device, e.g. file_path for file devices.",
+			},
+		},
+	}
+}
+
+func auditCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	auditType := d.Get("type").(string)
+	path := strings.Trim(d.Get("path").(string), "/")
+	if path == "" {
+		path = auditType
+	}
+
+	// The API client can't enable local audit devices, so the request is
+	// made directly.
+	log.Printf("[DEBUG] Enabling audit device %q", path)
+	_, err := client.Logical().Write("sys/audit/"+path, map[string]interface{}{
+		"type":        auditType,
+		"description": d.Get("description").(string),
+		"local":       d.Get("local").(bool),
+		"options":     d.Get("options").(map[string]interface{}),
+	})
+	if err != nil {
+		return fmt.Errorf("error enabling audit device %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return auditRead(d, meta)
+}
+
+func auditRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading audit device %q", path)
+	audits, err := client.Sys().ListAudit()
+	if err != nil {
+		return fmt.Errorf("error reading audit devices from Vault: %s", err)
+	}
+	audit, ok := audits[path+"/"]
+	if !ok {
+		log.Printf("[WARN] Audit device %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("type", audit.Type)
+	d.Set("path", path)
+	d.Set("description", audit.Description)
+	if err := d.Set("options", audit.Options); err != nil {
+		return fmt.Errorf("error setting options of audit device %q: %s", path, err)
+	}
+
+	local, err := auditLocal(client, path)
+	if err != nil {
+		return err
+	}
+	d.Set("local", local)
+
+	return nil
+}
+
+// auditLocal reports whether the audit device enabled at path is local.
+// The audit output of the API client doesn't include it, so it is taken
+// from the raw listing.
+func auditLocal(client *api.Client, path string) (bool, error) {
+	secret, err := client.Logical().Read("sys/audit")
+	if err != nil {
+		return false, fmt.Errorf("error reading audit devices from Vault: %s", err)
+	}
+	if secret == nil {
+		return false, nil
+	}
+
+	audit, ok := secret.Data[path+"/"].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	local, _ := audit["local"].(bool)
+	return local, nil
+}
+
+func auditDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Disabling audit device %q", path)
+	if err := client.Sys().DisableAudit(path); err != nil {
+		return fmt.Errorf("error disabling audit device %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestAudit(t *testing.T) {
+	path := acctest.RandomWithPrefix("file")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAuditDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAuditConfig(path, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_audit.test", "id", path),
+					resource.TestCheckResourceAttr("vault_audit.test", "type", "file"),
+					resource.TestCheckResourceAttr("vault_audit.test", "description", "test"),
+					resource.TestCheckResourceAttr("vault_audit.test", "local", "false"),
+					resource.TestCheckResourceAttr("vault_audit.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("vault_audit.test", "options.file_path", "stdout"),
+					resource.TestCheckResourceAttr("vault_audit.test", "options.log_raw", "true"),
+				),
+			},
+			{
+				Config: testAuditConfig(path, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_audit.test", "local", "true"),
+				),
+			},
+			{
+				ResourceName:      "vault_audit.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAudit_defaultPath(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAuditDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "vault_audit" "test" {
+	type = "file"
+	options = {
+		file_path = "stdout"
+	}
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_audit.test", "id", "file"),
+					resource.TestCheckResourceAttr("vault_audit.test", "path", "file"),
+				),
+			},
+		},
+	})
+}
+
+func testAuditDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	audits, err := client.Sys().ListAudit()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_audit" {
+			continue
+		}
+		if _, ok := audits[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("audit device %q still enabled", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAuditConfig(path string, local bool) string {
+	return fmt.Sprintf(`
+resource "vault_audit" "test" {
+	type = "file"
+	path = "%s"
+	description = "test"
+	local = %t
+	options = {
+		file_path = "stdout"
+		log_raw = "true"
+	}
+}
+`, path, local)
+}
@@ -15,6 +15,7 @@ func authBackendResource() *schema.Resource {
 		SchemaVersion: 1,
 
 		Create: authBackendWrite,
+		Update: authBackendUpdate,
 		Delete: authBackendDelete,
 		Read:   authBackendRead,
 		Importer: &schema.ResourceImporter{
@@ -50,10 +51,79 @@ func authBackendResource() *schema.Resource {
 
 			"description": &schema.Schema{
 				Type:        schema.TypeString,
-				ForceNew:    true,
 				Optional:    true,
 				Description: "The description of the auth backend",
 			},
+
+			"default_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default lease duration for tokens issued by the backend in seconds",
+			},
+
+			"max_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum possible lease duration for tokens issued by the backend in seconds",
+			},
+
+			"listing_visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Whether the backend is listed by the unauthenticated sys/internal/ui/mounts endpoint: unauth or hidden",
+				ValidateFunc: validateAuthBackendListingVisibility,
+			},
+
+			"audit_non_hmac_request_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Request keys that are not HMAC'd by audit devices",
+			},
+
+			"audit_non_hmac_response_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Response keys that are not HMAC'd by audit devices",
+			},
+
+			"token_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Type of the tokens issued by the backend: default-service, default-batch, service or batch",
+				ValidateFunc: validateAuthBackendTokenType,
+			},
+
+			"passthrough_request_headers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Request headers passed through to the backend",
+			},
+
+			"allowed_response_headers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Response headers the backend is allowed to set",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The accessor of the auth backend",
+			},
+
+			"identity_token_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key used to sign plugin identity tokens for this backend",
+			},
 		},
 	}
 }
@@ -79,9 +149,115 @@ func authBackendWrite(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(path)
 
+	// The API client only enables the backend with a description, the
+	// rest of the settings are applied by tuning it.
+	if tune := authBackendTuneData(d, false); len(tune) > 0 {
+		if err := authBackendTune(client, path, tune); err != nil {
+			return err
+		}
+	}
+
 	return authBackendRead(d, meta)
 }
 
+func authBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if tune := authBackendTuneData(d, true); len(tune) > 0 {
+		if err := authBackendTune(client, path, tune); err != nil {
+			return err
+		}
+	}
+
+	return authBackendRead(d, meta)
+}
+
+// authBackendTuneLists are the tune parameters of the backend that are lists.
+var authBackendTuneLists = []string{
+	"audit_non_hmac_request_keys",
+	"audit_non_hmac_response_keys",
+	"passthrough_request_headers",
+	"allowed_response_headers",
+}
+
+// authBackendTuneData returns the tune parameters of the backend. When
+// onlyChanged is set, only the parameters that changed in the current
+// plan are returned, otherwise those that are set.
+func authBackendTuneData(d *schema.ResourceData, onlyChanged bool) map[string]interface{} {
+	data := map[string]interface{}{}
+
+	// The description is set when enabling the backend.
+	if onlyChanged && d.HasChange("description") {
+		data["description"] = d.Get("description").(string)
+	}
+
+	for _, k := range []string{"default_lease_ttl_seconds", "max_lease_ttl_seconds"} {
+		if onlyChanged && !d.HasChange(k) {
+			continue
+		}
+		if v := d.Get(k).(int); v != 0 || onlyChanged {
+			data[strings.TrimSuffix(k, "_seconds")] = fmt.Sprintf("%ds", v)
+		}
+	}
+
+	for _, k := range []string{"listing_visibility", "identity_token_key", "token_type"} {
+		if onlyChanged && !d.HasChange(k) {
+			continue
+		}
+		if v := d.Get(k).(string); v != "" || onlyChanged {
+			data[k] = v
+		}
+	}
+
+	for _, k := range authBackendTuneLists {
+		if onlyChanged && !d.HasChange(k) {
+			continue
+		}
+		keys := toStringArray(d.Get(k).([]interface{}))
+		if len(keys) == 0 {
+			if !onlyChanged {
+				continue
+			}
+			// Vault ignores an empty list, a single empty key clears
+			// the setting instead.
+			keys = []string{""}
+		}
+		data[k] = keys
+	}
+
+	return data
+}
+
+func validateAuthBackendListingVisibility(v interface{}, k string) (ws []string, errs []error) {
+	switch v.(string) {
+	case "unauth", "hidden":
+	default:
+		errs = append(errs, fmt.Errorf("%s must be either unauth or hidden, got %q", k, v))
+	}
+	return
+}
+
+func validateAuthBackendTokenType(v interface{}, k string) (ws []string, errs []error) {
+	switch v.(string) {
+	case "default-service", "default-batch", "service", "batch":
+	default:
+		errs = append(errs, fmt.Errorf("%s must be one of default-service, default-batch, service or batch, got %q", k, v))
+	}
+	return
+}
+
+func authBackendTune(client *api.Client, path string, data map[string]interface{}) error {
+	log.Printf("[DEBUG] Tuning auth %q in Vault", path)
+
+	if _, err := client.Logical().Write("sys/auth/"+path+"/tune", data); err != nil {
+		return fmt.Errorf("error tuning auth %q in Vault: %s", path, err)
+	}
+
+	return nil
+}
+
 func authBackendDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*api.Client)
 
@@ -114,6 +290,37 @@ func authBackendRead(d *schema.ResourceData, meta interface{}) error {
 			d.Set("type", auth.Type)
 			d.Set("path", path)
 			d.Set("description", auth.Description)
+
+			tune, err := client.Logical().Read("sys/auth/" + d.Id() + "/tune")
+			if err != nil {
+				return fmt.Errorf("error reading tuning of auth %q from Vault: %s", d.Id(), err)
+			}
+			if tune != nil {
+				if v, ok := tune.Data["identity_token_key"]; ok {
+					d.Set("identity_token_key", v)
+				}
+				d.Set("default_lease_ttl_seconds", intFromResponse(tune.Data["default_lease_ttl"]))
+				d.Set("max_lease_ttl_seconds", intFromResponse(tune.Data["max_lease_ttl"]))
+				if v, ok := tune.Data["listing_visibility"].(string); ok && v != "" {
+					d.Set("listing_visibility", v)
+				} else {
+					d.Set("listing_visibility", "hidden")
+				}
+				// Vault versions before 1.0 don't have token types.
+				if v, ok := tune.Data["token_type"]; ok {
+					d.Set("token_type", v)
+				}
+				for _, k := range authBackendTuneLists {
+					d.Set(k, flattenStringList(tune.Data[k]))
+				}
+			}
+
+			accessor, err := authBackendAccessor(client, d.Id())
+			if err != nil {
+				return err
+			}
+			d.Set("accessor", accessor)
+
 			return nil
 		}
 	}
@@ -122,3 +329,25 @@ func authBackendRead(d *schema.ResourceData, meta interface{}) error {
 	d.SetId("")
 	return nil
 }
+
+// authBackendAccessor returns the accessor of the backend enabled at path.
+// The auth output of the API client doesn't include it, so it is taken
+// from the raw listing.
+func authBackendAccessor(client *api.Client, path string) (string, error) {
+	secret, err := client.Logical().Read("sys/auth")
+	if err != nil {
+		return "", fmt.Errorf("error reading auth backends from Vault: %s", err)
+	}
+	if secret == nil {
+		return "", nil
+	}
+
+	// Vault versions listing backends only at the top level of the
+	// response predate accessors, so there is nothing to report for them.
+	auth, ok := secret.Data[strings.Trim(path, "/")+"/"].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	accessor, _ := auth["accessor"].(string)
+	return accessor, nil
+}
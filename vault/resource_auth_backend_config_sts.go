@@ -0,0 +1,114 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var authBackendConfigSTSIDRegex = regexp.MustCompile("^auth/(.+)/config/sts/([^/]+)$")
+
+func authBackendConfigSTSResource() *schema.Resource {
+	return &schema.Resource{
+		Create: authBackendConfigSTSWrite,
+		Update: authBackendConfigSTSWrite,
+		Delete: authBackendConfigSTSDelete,
+		Read:   authBackendConfigSTSRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "aws",
+				ForceNew:    true,
+				Description: "Path of the AWS auth backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"account_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "AWS account ID whose entities are validated with the STS role.",
+			},
+
+			"sts_role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ARN of the role assumed to validate entities of the account.",
+			},
+		},
+	}
+}
+
+func authBackendConfigSTSPath(backend, accountID string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/config/sts/" + accountID
+}
+
+func authBackendConfigSTSWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := authBackendConfigSTSPath(d.Get("backend").(string), d.Get("account_id").(string))
+
+	log.Printf("[DEBUG] Writing AWS auth STS role %q to Vault", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"sts_role": d.Get("sts_role").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing AWS auth STS role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return authBackendConfigSTSRead(d, meta)
+}
+
+func authBackendConfigSTSRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := authBackendConfigSTSIDRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid AWS auth STS role ID %q, expected auth/<backend>/config/sts/<account_id>", path)
+	}
+
+	log.Printf("[DEBUG] Reading AWS auth STS role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading AWS auth STS role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] AWS auth STS role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("account_id", res[2])
+	d.Set("sts_role", secret.Data["sts_role"])
+
+	return nil
+}
+
+func authBackendConfigSTSDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting AWS auth STS role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting AWS auth STS role %q: %s", path, err)
+	}
+
+	return nil
+}
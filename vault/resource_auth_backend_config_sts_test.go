@@ -0,0 +1,72 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestAuthBackendConfigSTS(t *testing.T) {
+	backend := acctest.RandomWithPrefix("aws")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAuthBackendConfigSTSDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAuthBackendConfigSTSConfig(backend, "arn:aws:iam::123456789012:role/vault-a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_auth_backend_config_sts.test", "id", "auth/"+backend+"/config/sts/123456789012"),
+					resource.TestCheckResourceAttr("vault_auth_backend_config_sts.test", "sts_role", "arn:aws:iam::123456789012:role/vault-a"),
+				),
+			},
+			{
+				Config: testAuthBackendConfigSTSConfig(backend, "arn:aws:iam::123456789012:role/vault-b"),
+				Check:  resource.TestCheckResourceAttr("vault_auth_backend_config_sts.test", "sts_role", "arn:aws:iam::123456789012:role/vault-b"),
+			},
+			{
+				ResourceName:      "vault_auth_backend_config_sts.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAuthBackendConfigSTSDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_auth_backend_config_sts" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			// The auth backend itself is gone along with its config.
+			continue
+		}
+		if secret != nil {
+			return fmt.Errorf("AWS auth STS role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAuthBackendConfigSTSConfig(backend, role string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "aws" {
+	type = "aws"
+	path = "%s"
+}
+
+resource "vault_auth_backend_config_sts" "test" {
+	backend = "${vault_auth_backend.aws.path}"
+	account_id = "123456789012"
+	sts_role = "%s"
+}
+`, backend, role)
+}
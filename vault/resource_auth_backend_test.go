@@ -155,3 +155,95 @@ func testResourceAuth_updateCheck(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceAuth_identityTokenKey(t *testing.T) {
+	path := "approle-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceAuth_identityTokenKeyConfig(path, ""),
+				Check:  resource.TestCheckResourceAttr("vault_auth_backend.test", "identity_token_key", ""),
+			},
+			{
+				Config: testResourceAuth_identityTokenKeyConfig(path, "default"),
+				Check:  resource.TestCheckResourceAttr("vault_auth_backend.test", "identity_token_key", "default"),
+			},
+		},
+	})
+}
+
+func testResourceAuth_identityTokenKeyConfig(path, key string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+	type = "approle"
+	path = "%s"
+	identity_token_key = "%s"
+}`, path, key)
+}
+
+func TestResourceAuth_tune(t *testing.T) {
+	path := "approle-" + acctest.RandString(10)
+	var accessor string
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceAuth_tuneConfig(path, "First description", 3600, "hidden", "default-service"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "description", "First description"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "default_lease_ttl_seconds", "3600"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "max_lease_ttl_seconds", "36000"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "listing_visibility", "hidden"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "audit_non_hmac_request_keys.#", "1"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "audit_non_hmac_request_keys.0", "role_id"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "token_type", "default-service"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "passthrough_request_headers.#", "1"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "passthrough_request_headers.0", "X-Custom"),
+					func(s *terraform.State) error {
+						accessor = s.Modules[0].Resources["vault_auth_backend.test"].Primary.Attributes["accessor"]
+						if accessor == "" {
+							return fmt.Errorf("accessor not set")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testResourceAuth_tuneConfig(path, "Second description", 7200, "unauth", "batch"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "description", "Second description"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "default_lease_ttl_seconds", "7200"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "listing_visibility", "unauth"),
+					resource.TestCheckResourceAttr("vault_auth_backend.test", "token_type", "batch"),
+					// The backend was tuned, not enabled again.
+					func(s *terraform.State) error {
+						if got := s.Modules[0].Resources["vault_auth_backend.test"].Primary.Attributes["accessor"]; got != accessor {
+							return fmt.Errorf("backend was recreated, accessor changed from %q to %q", accessor, got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testResourceAuth_tuneConfig(path, description string, ttl int, visibility, tokenType string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+	type = "approle"
+	path = "%s"
+	description = "%s"
+	default_lease_ttl_seconds = %d
+	max_lease_ttl_seconds = 36000
+	listing_visibility = "%s"
+	audit_non_hmac_request_keys = ["role_id"]
+	token_type = "%s"
+	passthrough_request_headers = ["X-Custom"]
+}`, path, description, ttl, visibility, tokenType)
+}
@@ -0,0 +1,271 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func awsSecretBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: awsSecretBackendCreate,
+		Update: awsSecretBackendUpdate,
+		Delete: awsSecretBackendDelete,
+		Read:   awsSecretBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "aws",
+				Description: "Path to mount the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the mount.",
+			},
+
+			"default_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"max_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"access_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "AWS access key ID of the root credential, taken from the environment of Vault if not set.",
+			},
+
+			"secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "AWS secret access key of the root credential.",
+			},
+
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "us-east-1",
+				Description: "AWS region of the API calls made by the backend.",
+			},
+
+			"iam_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Custom endpoint of the IAM API.",
+			},
+
+			"sts_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Custom endpoint of the STS API.",
+			},
+
+			"role_arn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ARN of the role assumed with plugin identity tokens instead of a root credential.",
+			},
+
+			"identity_token_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key signing the plugin identity tokens of the backend, the default key if not set.",
+			},
+
+			"identity_token_audience": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Audience of the plugin identity tokens the backend gets cloud credentials with, Vault Enterprise only.",
+			},
+
+			"identity_token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "TTL in seconds of the plugin identity tokens.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Accessor of the mount, part of the subject of the plugin identity tokens.",
+			},
+		},
+	}
+}
+
+func awsSecretBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Mounting AWS secret backend at %q", path)
+	err := client.Sys().Mount(path, &api.MountInput{
+		Type:        "aws",
+		Description: d.Get("description").(string),
+		Config: api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error mounting AWS secret backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if _, ok := d.GetOk("identity_token_key"); ok {
+		if err := pluginIdentityTuneKey(d, client, path); err != nil {
+			return err
+		}
+	}
+
+	if err := awsSecretBackendWriteConfig(client, d); err != nil {
+		return err
+	}
+
+	return awsSecretBackendRead(d, meta)
+}
+
+func awsSecretBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("default_lease_ttl_seconds") || d.HasChange("max_lease_ttl_seconds") {
+		log.Printf("[DEBUG] Tuning AWS secret backend %q", path)
+		err := client.Sys().TuneMount(path, api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		})
+		if err != nil {
+			return fmt.Errorf("error tuning AWS secret backend %q: %s", path, err)
+		}
+	}
+
+	if d.HasChange("identity_token_key") {
+		if err := pluginIdentityTuneKey(d, client, path); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("description") {
+		if err := mountTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("access_key") || d.HasChange("secret_key") || d.HasChange("region") ||
+		d.HasChange("iam_endpoint") || d.HasChange("sts_endpoint") || d.HasChange("role_arn") ||
+		d.HasChange("identity_token_audience") || d.HasChange("identity_token_ttl") {
+		if err := awsSecretBackendWriteConfig(client, d); err != nil {
+			return err
+		}
+	}
+
+	return awsSecretBackendRead(d, meta)
+}
+
+// awsSecretBackendWriteConfig writes the root credential, or the role and
+// plugin identity token settings, and endpoints of the backend. They are
+// written together, as the backend replaces all of them on every write.
+func awsSecretBackendWriteConfig(client *api.Client, d *schema.ResourceData) error {
+	path := d.Id() + "/config/root"
+
+	log.Printf("[DEBUG] Writing AWS secret backend config %q", path)
+	data := map[string]interface{}{
+		"access_key":   d.Get("access_key").(string),
+		"secret_key":   d.Get("secret_key").(string),
+		"region":       d.Get("region").(string),
+		"iam_endpoint": d.Get("iam_endpoint").(string),
+		"sts_endpoint": d.Get("sts_endpoint").(string),
+		"role_arn":     d.Get("role_arn").(string),
+	}
+	pluginIdentityWriteData(d, data)
+
+	_, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error writing AWS secret backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func awsSecretBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading AWS secret backend %q", path)
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("error reading mounts from Vault: %s", err)
+	}
+	mount, ok := mounts[path+"/"]
+	if !ok {
+		log.Printf("[WARN] AWS secret backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("description", mount.Description)
+	d.Set("default_lease_ttl_seconds", mount.Config.DefaultLeaseTTL)
+	d.Set("max_lease_ttl_seconds", mount.Config.MaxLeaseTTL)
+
+	// Vault versions before 0.11 can't read the config back, in which case
+	// it's kept from the configuration. The secret key is never returned.
+	config, err := client.Logical().Read(path + "/config/root")
+	if err != nil && !strings.Contains(err.Error(), "unsupported operation") {
+		return fmt.Errorf("error reading AWS secret backend config %q: %s", path, err)
+	}
+	var configData map[string]interface{}
+	if config != nil {
+		configData = config.Data
+		for _, k := range []string{"access_key", "region", "iam_endpoint", "sts_endpoint", "role_arn"} {
+			if v, ok := config.Data[k]; ok {
+				d.Set(k, v)
+			}
+		}
+	}
+
+	return pluginIdentityRead(d, client, path, configData)
+}
+
+func awsSecretBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Unmounting AWS secret backend %q", path)
+	if err := client.Sys().Unmount(path); err != nil {
+		return fmt.Errorf("error unmounting AWS secret backend %q: %s", path, err)
+	}
+
+	return nil
+}
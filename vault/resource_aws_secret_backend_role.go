@@ -0,0 +1,188 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var awsSecretBackendRoleFromPathRegex = regexp.MustCompile("^(.+)/roles/([^/]+)$")
+
+func awsSecretBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: awsSecretBackendRoleWrite,
+		Update: awsSecretBackendRoleWrite,
+		Delete: awsSecretBackendRoleDelete,
+		Read:   awsSecretBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "aws",
+				Description: "Path of the AWS secret backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"credential_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Type of the credentials issued: iam_user, assumed_role or federation_token.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					switch v.(string) {
+					case "iam_user", "assumed_role", "federation_token":
+					default:
+						errs = append(errs, fmt.Errorf("%s must be one of iam_user, assumed_role or federation_token, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"policy_document": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "JSON-encoded IAM policy document of the credentials.",
+				ValidateFunc: ValidateDataJSON,
+				StateFunc:    NormalizeDataJSON,
+			},
+
+			"policy_arns": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "ARNs of the managed IAM policies of the credentials.",
+			},
+
+			"role_arns": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "ARNs of the IAM roles that can be assumed, for assumed_role credentials.",
+			},
+
+			"default_sts_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default TTL in seconds of assumed_role and federation_token credentials.",
+			},
+
+			"max_sts_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL in seconds of assumed_role and federation_token credentials.",
+			},
+		},
+	}
+}
+
+func awsSecretBackendRolePath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(name, "/")
+}
+
+func awsSecretBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := awsSecretBackendRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"credential_type": d.Get("credential_type").(string),
+		"policy_document": d.Get("policy_document").(string),
+		"policy_arns":     d.Get("policy_arns").(*schema.Set).List(),
+		"role_arns":       d.Get("role_arns").(*schema.Set).List(),
+	}
+	// The STS TTLs are rejected for iam_user credentials, even when 0.
+	if d.Get("credential_type").(string) != "iam_user" {
+		data["default_sts_ttl"] = d.Get("default_sts_ttl").(int)
+		data["max_sts_ttl"] = d.Get("max_sts_ttl").(int)
+	}
+
+	log.Printf("[DEBUG] Writing AWS secret backend role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing AWS secret backend role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return awsSecretBackendRoleRead(d, meta)
+}
+
+func awsSecretBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := awsSecretBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid AWS secret backend role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading AWS secret backend role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading AWS secret backend role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] AWS secret backend role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+
+	// Roles can allow several credential types, which Terraform doesn't
+	// support, so only the first one is kept.
+	if types := flattenStringList(secret.Data["credential_types"]); len(types) > 0 {
+		d.Set("credential_type", types[0])
+	} else {
+		d.Set("credential_type", secret.Data["credential_type"])
+	}
+
+	if v, _ := secret.Data["policy_document"].(string); v != "" {
+		d.Set("policy_document", NormalizeDataJSON(v))
+	} else {
+		d.Set("policy_document", "")
+	}
+	d.Set("default_sts_ttl", intFromResponse(secret.Data["default_sts_ttl"]))
+	d.Set("max_sts_ttl", intFromResponse(secret.Data["max_sts_ttl"]))
+
+	for _, k := range []string{"policy_arns", "role_arns"} {
+		if err := d.Set(k, flattenStringList(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of AWS secret backend role %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func awsSecretBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting AWS secret backend role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting AWS secret backend role %q: %s", path, err)
+	}
+
+	return nil
+}
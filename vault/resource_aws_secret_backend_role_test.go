@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestAWSSecretBackendRole(t *testing.T) {
+	path := acctest.RandomWithPrefix("aws")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAWSSecretBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAWSSecretBackendRoleConfig(path, `
+	credential_type = "iam_user"
+	policy_document = <<EOT
+{
+  "Version": "2012-10-17",
+  "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"}]
+}
+EOT
+	policy_arns = ["arn:aws:iam::aws:policy/ReadOnlyAccess"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "id", path+"/roles/test"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "credential_type", "iam_user"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "policy_arns.#", "1"),
+					resource.TestCheckResourceAttrSet("vault_aws_secret_backend_role.test", "policy_document"),
+				),
+			},
+			{
+				Config: testAWSSecretBackendRoleConfig(path, `
+	credential_type = "assumed_role"
+	role_arns = ["arn:aws:iam::123456789012:role/deploy"]
+	default_sts_ttl = 900
+	max_sts_ttl = 3600
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "credential_type", "assumed_role"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "policy_arns.#", "0"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "policy_document", ""),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "role_arns.#", "1"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "default_sts_ttl", "900"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_role.test", "max_sts_ttl", "3600"),
+				),
+			},
+			{
+				ResourceName:      "vault_aws_secret_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAWSSecretBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_aws_secret_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for AWS secret backend role %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("AWS secret backend role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAWSSecretBackendRoleConfig(path, settings string) string {
+	return fmt.Sprintf(`
+resource "vault_aws_secret_backend" "test" {
+	path = "%s"
+	access_key = "AKIAEXAMPLE"
+	secret_key = "secret"
+}
+
+resource "vault_aws_secret_backend_role" "test" {
+	backend = "${vault_aws_secret_backend.test.path}"
+	name = "test"
+%s
+}
+`, path, settings)
+}
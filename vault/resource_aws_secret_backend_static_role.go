@@ -0,0 +1,136 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var awsSecretBackendStaticRoleFromPathRegex = regexp.MustCompile("^(.+)/static-roles/(.+)$")
+
+func awsSecretBackendStaticRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: awsSecretBackendStaticRoleWrite,
+		Update: awsSecretBackendStaticRoleWrite,
+		Delete: awsSecretBackendStaticRoleDelete,
+		Read:   awsSecretBackendStaticRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "aws",
+				Description: "Path of the AWS secret backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the static role.",
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the IAM user whose access keys are rotated.",
+			},
+
+			"rotation_period": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Time in seconds between rotations of the access key.",
+			},
+
+			"assume_role_arn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ARN of the role to assume to manage the user, when it lives in another account.",
+			},
+		},
+	}
+}
+
+func awsSecretBackendStaticRolePath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/static-roles/" + strings.Trim(name, "/")
+}
+
+func awsSecretBackendStaticRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := awsSecretBackendStaticRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"username":        d.Get("username").(string),
+		"rotation_period": d.Get("rotation_period").(int),
+	}
+	if v, ok := d.GetOk("assume_role_arn"); ok || d.HasChange("assume_role_arn") {
+		data["assume_role_arn"] = v.(string)
+	}
+
+	log.Printf("[DEBUG] Writing AWS static role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing AWS static role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return awsSecretBackendStaticRoleRead(d, meta)
+}
+
+func awsSecretBackendStaticRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := awsSecretBackendStaticRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid AWS static role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading AWS static role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading AWS static role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] AWS static role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	d.Set("username", secret.Data["username"])
+	d.Set("rotation_period", intFromResponse(secret.Data["rotation_period"]))
+	if v, ok := secret.Data["assume_role_arn"]; ok {
+		d.Set("assume_role_arn", v)
+	}
+
+	return nil
+}
+
+func awsSecretBackendStaticRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting AWS static role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting AWS static role %q: %s", path, err)
+	}
+
+	return nil
+}
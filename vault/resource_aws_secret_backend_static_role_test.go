@@ -0,0 +1,96 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestAWSSecretBackendStaticRole(t *testing.T) {
+	// Vault creates an access key for the user when the role is written,
+	// so this needs a real IAM user and credentials allowed to manage it.
+	username := os.Getenv("TEST_AWS_STATIC_USERNAME")
+	if username == "" {
+		t.Skip("TEST_AWS_STATIC_USERNAME not set")
+	}
+
+	backend := acctest.RandomWithPrefix("aws")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAWSSecretBackendStaticRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAWSSecretBackendStaticRoleConfig(backend, username, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_static_role.test", "id", backend+"/static-roles/test"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_static_role.test", "username", username),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend_static_role.test", "rotation_period", "3600"),
+					resource.TestCheckResourceAttrSet("data.vault_aws_static_access_credentials.test", "access_key"),
+					resource.TestCheckResourceAttrSet("data.vault_aws_static_access_credentials.test", "secret_key"),
+				),
+			},
+			{
+				Config: testAWSSecretBackendStaticRoleConfig(backend, username, 7200),
+				Check:  resource.TestCheckResourceAttr("vault_aws_secret_backend_static_role.test", "rotation_period", "7200"),
+			},
+			{
+				ResourceName:      "vault_aws_secret_backend_static_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAWSSecretBackendStaticRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_aws_secret_backend_static_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("AWS static role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAWSSecretBackendStaticRoleConfig(backend, username string, rotationPeriod int) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "aws" {
+	path = "%s"
+	type = "aws"
+}
+
+resource "vault_generic_endpoint" "root" {
+	path = "${vault_mount.aws.path}/config/root"
+	data_json = "{\"access_key\": \"%s\", \"secret_key\": \"%s\"}"
+	disable_read = true
+	disable_delete = true
+}
+
+resource "vault_aws_secret_backend_static_role" "test" {
+	backend = "${vault_mount.aws.path}"
+	name = "test"
+	username = "%s"
+	rotation_period = %d
+	depends_on = ["vault_generic_endpoint.root"]
+}
+
+data "vault_aws_static_access_credentials" "test" {
+	backend = "${vault_mount.aws.path}"
+	name = "${vault_aws_secret_backend_static_role.test.name}"
+}
+`, backend, os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), username, rotationPeriod)
+}
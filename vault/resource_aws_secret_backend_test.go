@@ -0,0 +1,160 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+// Vault doesn't check the root credential until it issues credentials, so
+// the test doesn't need an AWS account.
+func TestAWSSecretBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("aws")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAWSSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAWSSecretBackendConfig(path, "AKIAEXAMPLE1", "us-east-1", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "access_key", "AKIAEXAMPLE1"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "region", "us-east-1"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "default_lease_ttl_seconds", "3600"),
+					testPluginIdentityCheckAccessor("vault_aws_secret_backend.test"),
+				),
+			},
+			{
+				Config: testAWSSecretBackendConfig(path, "AKIAEXAMPLE2", "eu-west-1", 1800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "access_key", "AKIAEXAMPLE2"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "region", "eu-west-1"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "default_lease_ttl_seconds", "1800"),
+				),
+			},
+			{
+				ResourceName:            "vault_aws_secret_backend.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_key"},
+			},
+		},
+	})
+}
+
+// Plugin identity tokens are only available in Vault Enterprise, which
+// doesn't use them until it issues credentials either.
+func TestAWSSecretBackend_identityToken(t *testing.T) {
+	testAccPluginIdentityPreCheck(t)
+
+	path := acctest.RandomWithPrefix("aws")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		CheckDestroy: testAWSSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAWSSecretBackendConfig_identityToken(path, "test-audience", 600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "role_arn", "arn:aws:iam::123456789012:role/vault"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "identity_token_key", "default"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "identity_token_audience", "test-audience"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "identity_token_ttl", "600"),
+					testPluginIdentityCheckAccessor("vault_aws_secret_backend.test"),
+				),
+			},
+			{
+				Config: testAWSSecretBackendConfig_identityToken(path, "updated-audience", 1200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "identity_token_audience", "updated-audience"),
+					resource.TestCheckResourceAttr("vault_aws_secret_backend.test", "identity_token_ttl", "1200"),
+				),
+			},
+			{
+				ResourceName:      "vault_aws_secret_backend.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPluginIdentityPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+}
+
+// testPluginIdentityCheckAccessor checks that the accessor of the backend
+// is the one of its mount.
+func testPluginIdentityCheckAccessor(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", name)
+		}
+
+		client := testProvider.Meta().(*api.Client)
+		accessor, err := mountAccessor(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if accessor == "" || rs.Primary.Attributes["accessor"] != accessor {
+			return fmt.Errorf("expected accessor %q, got %q", accessor, rs.Primary.Attributes["accessor"])
+		}
+		return nil
+	}
+}
+
+func testAWSSecretBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_aws_secret_backend" {
+			continue
+		}
+		if _, ok := mounts[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("AWS secret backend %q still mounted", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAWSSecretBackendConfig(path, accessKey, region string, defaultTTL int) string {
+	return fmt.Sprintf(`
+resource "vault_aws_secret_backend" "test" {
+	path = "%s"
+	description = "test"
+	access_key = "%s"
+	secret_key = "secret"
+	region = "%s"
+	default_lease_ttl_seconds = %d
+	max_lease_ttl_seconds = 86400
+}
+`, path, accessKey, region, defaultTTL)
+}
+
+func testAWSSecretBackendConfig_identityToken(path, audience string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_aws_secret_backend" "test" {
+	path = "%s"
+	role_arn = "arn:aws:iam::123456789012:role/vault"
+	identity_token_key = "default"
+	identity_token_audience = "%s"
+	identity_token_ttl = %d
+}
+`, path, audience, ttl)
+}
@@ -0,0 +1,262 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func azureSecretBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: azureSecretBackendCreate,
+		Update: azureSecretBackendUpdate,
+		Delete: azureSecretBackendDelete,
+		Read:   azureSecretBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "azure",
+				Description: "Path to mount the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the mount.",
+			},
+
+			"default_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"max_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"subscription_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Azure subscription the service principals are created in.",
+			},
+
+			"tenant_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Azure Active Directory tenant.",
+			},
+
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Client ID of the service principal Vault manages service principals with, the managed identity of Vault if not set.",
+			},
+
+			"client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Client secret of the service principal Vault manages service principals with.",
+			},
+
+			"environment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "AzurePublicCloud",
+				Description: "Azure environment, e.g. AzurePublicCloud or AzureUSGovernmentCloud.",
+			},
+
+			"identity_token_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key signing the plugin identity tokens of the backend, the default key if not set.",
+			},
+
+			"identity_token_audience": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Audience of the plugin identity tokens the backend gets cloud credentials with, Vault Enterprise only.",
+			},
+
+			"identity_token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "TTL in seconds of the plugin identity tokens.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Accessor of the mount, part of the subject of the plugin identity tokens.",
+			},
+		},
+	}
+}
+
+func azureSecretBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Mounting Azure secret backend at %q", path)
+	err := client.Sys().Mount(path, &api.MountInput{
+		Type:        "azure",
+		Description: d.Get("description").(string),
+		Config: api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error mounting Azure secret backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if _, ok := d.GetOk("identity_token_key"); ok {
+		if err := pluginIdentityTuneKey(d, client, path); err != nil {
+			return err
+		}
+	}
+
+	if err := azureSecretBackendWriteConfig(client, d); err != nil {
+		return err
+	}
+
+	return azureSecretBackendRead(d, meta)
+}
+
+func azureSecretBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("default_lease_ttl_seconds") || d.HasChange("max_lease_ttl_seconds") {
+		log.Printf("[DEBUG] Tuning Azure secret backend %q", path)
+		err := client.Sys().TuneMount(path, api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		})
+		if err != nil {
+			return fmt.Errorf("error tuning Azure secret backend %q: %s", path, err)
+		}
+	}
+
+	if d.HasChange("identity_token_key") {
+		if err := pluginIdentityTuneKey(d, client, path); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("description") {
+		if err := mountTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("subscription_id") || d.HasChange("tenant_id") || d.HasChange("client_id") ||
+		d.HasChange("client_secret") || d.HasChange("environment") ||
+		d.HasChange("identity_token_audience") || d.HasChange("identity_token_ttl") {
+		if err := azureSecretBackendWriteConfig(client, d); err != nil {
+			return err
+		}
+	}
+
+	return azureSecretBackendRead(d, meta)
+}
+
+// azureSecretBackendWriteConfig writes the subscription and credentials of
+// the backend, which are replaced all together on every write.
+func azureSecretBackendWriteConfig(client *api.Client, d *schema.ResourceData) error {
+	path := d.Id() + "/config"
+
+	log.Printf("[DEBUG] Writing Azure secret backend config %q", path)
+	data := map[string]interface{}{
+		"subscription_id": d.Get("subscription_id").(string),
+		"tenant_id":       d.Get("tenant_id").(string),
+		"client_id":       d.Get("client_id").(string),
+		"client_secret":   d.Get("client_secret").(string),
+		"environment":     d.Get("environment").(string),
+	}
+	pluginIdentityWriteData(d, data)
+
+	_, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error writing Azure secret backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func azureSecretBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading Azure secret backend %q", path)
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("error reading mounts from Vault: %s", err)
+	}
+	mount, ok := mounts[path+"/"]
+	if !ok {
+		log.Printf("[WARN] Azure secret backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("description", mount.Description)
+	d.Set("default_lease_ttl_seconds", mount.Config.DefaultLeaseTTL)
+	d.Set("max_lease_ttl_seconds", mount.Config.MaxLeaseTTL)
+
+	// The client secret is never returned, so it's kept from the state.
+	config, err := client.Logical().Read(path + "/config")
+	if err != nil {
+		return fmt.Errorf("error reading Azure secret backend config %q: %s", path, err)
+	}
+	var configData map[string]interface{}
+	if config != nil {
+		configData = config.Data
+		for _, k := range []string{"subscription_id", "tenant_id", "client_id", "environment"} {
+			if v, ok := config.Data[k]; ok {
+				d.Set(k, v)
+			}
+		}
+	}
+
+	return pluginIdentityRead(d, client, path, configData)
+}
+
+func azureSecretBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Unmounting Azure secret backend %q", path)
+	if err := client.Sys().Unmount(path); err != nil {
+		return fmt.Errorf("error unmounting Azure secret backend %q: %s", path, err)
+	}
+
+	return nil
+}
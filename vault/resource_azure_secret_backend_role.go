@@ -0,0 +1,224 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var azureSecretBackendRoleFromPathRegex = regexp.MustCompile("^(.+)/roles/([^/]+)$")
+
+func azureSecretBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: azureSecretBackendRoleWrite,
+		Update: azureSecretBackendRoleWrite,
+		Delete: azureSecretBackendRoleDelete,
+		Read:   azureSecretBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "azure",
+				Description: "Path of the Azure secret backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"azure_role": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Azure role assigned to the service principals created for the role.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the Azure role, e.g. Contributor. Either this or role_id must be set.",
+						},
+						"role_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of the Azure role, which takes precedence over role_name.",
+						},
+						"scope": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Scope of the role assignment, e.g. /subscriptions/<id>/resourceGroups/<name>.",
+						},
+					},
+				},
+			},
+
+			"application_object_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Object ID of an existing application, whose credentials are issued instead of creating service principals.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "TTL in seconds of the credentials, the default lease TTL of the backend if 0.",
+			},
+
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL in seconds of the credentials, the maximum lease TTL of the backend if 0.",
+			},
+		},
+	}
+}
+
+func azureSecretBackendRolePath(backend, role string) string {
+	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(role, "/")
+}
+
+// azureSecretBackendRoleAzureRoles encodes azure_role blocks into the role
+// assignments of a role, which Vault takes as a JSON list.
+func azureSecretBackendRoleAzureRoles(blocks []interface{}) (string, error) {
+	roles := make([]map[string]interface{}, 0, len(blocks))
+	for _, raw := range blocks {
+		block := raw.(map[string]interface{})
+		role := map[string]interface{}{"scope": block["scope"]}
+		for _, k := range []string{"role_name", "role_id"} {
+			if v, _ := block[k].(string); v != "" {
+				role[k] = v
+			}
+		}
+		roles = append(roles, role)
+	}
+	encoded, err := json.Marshal(roles)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// azureSecretBackendRoleAzureRolesFromResponse converts the role
+// assignments of a role into azure_role blocks. Vault returns both the name
+// and the ID of every Azure role, but only the ones set in the current
+// blocks are kept, as the ID of a role given by name would otherwise be
+// sent again, taking precedence, when the name changes. Roles with no
+// current block, e.g. on import, are kept by name.
+func azureSecretBackendRoleAzureRolesFromResponse(raw interface{}, current []interface{}) []map[string]interface{} {
+	roles, _ := raw.([]interface{})
+	blocks := make([]map[string]interface{}, 0, len(roles))
+	for i, r := range roles {
+		role, _ := r.(map[string]interface{})
+		var usesName, usesID bool
+		if i < len(current) {
+			block, _ := current[i].(map[string]interface{})
+			usesName = block["role_name"] != ""
+			usesID = block["role_id"] != ""
+		}
+		if !usesID {
+			usesName = true
+		}
+
+		block := map[string]interface{}{"scope": role["scope"], "role_name": "", "role_id": ""}
+		if usesName {
+			block["role_name"] = role["role_name"]
+		}
+		if usesID {
+			block["role_id"] = role["role_id"]
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func azureSecretBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := azureSecretBackendRolePath(d.Get("backend").(string), d.Get("role").(string))
+
+	azureRoles, err := azureSecretBackendRoleAzureRoles(d.Get("azure_role").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("error encoding Azure roles of Azure role %q: %s", path, err)
+	}
+
+	data := map[string]interface{}{
+		"azure_roles":           azureRoles,
+		"application_object_id": d.Get("application_object_id").(string),
+		"ttl":                   d.Get("ttl").(int),
+		"max_ttl":               d.Get("max_ttl").(int),
+	}
+
+	// Vault looks up the Azure roles by name when the role is written, so
+	// the credentials of the backend must be valid.
+	log.Printf("[DEBUG] Writing Azure role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing Azure role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return azureSecretBackendRoleRead(d, meta)
+}
+
+func azureSecretBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := azureSecretBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid Azure role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading Azure role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading Azure role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Azure role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("role", res[2])
+	d.Set("application_object_id", secret.Data["application_object_id"])
+	d.Set("ttl", durationSecondsFromResponse(secret.Data["ttl"]))
+	d.Set("max_ttl", durationSecondsFromResponse(secret.Data["max_ttl"]))
+
+	azureRoles := azureSecretBackendRoleAzureRolesFromResponse(secret.Data["azure_roles"], d.Get("azure_role").([]interface{}))
+	if err := d.Set("azure_role", azureRoles); err != nil {
+		return fmt.Errorf("error setting azure_role of Azure role %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func azureSecretBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting Azure role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting Azure role %q: %s", path, err)
+	}
+
+	return nil
+}
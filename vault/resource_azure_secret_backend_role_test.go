@@ -0,0 +1,129 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestAzureSecretBackendRoleAzureRoles(t *testing.T) {
+	blocks := []interface{}{
+		map[string]interface{}{"role_name": "Reader", "role_id": "", "scope": "/subscriptions/test"},
+		map[string]interface{}{"role_name": "", "role_id": "/providers/roles/1", "scope": "/subscriptions/test/resourceGroups/app"},
+	}
+	expected := `[{"role_name":"Reader","scope":"/subscriptions/test"},{"role_id":"/providers/roles/1","scope":"/subscriptions/test/resourceGroups/app"}]`
+	got, err := azureSecretBackendRoleAzureRoles(blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Errorf("azureSecretBackendRoleAzureRoles() = %s, expected %s", got, expected)
+	}
+}
+
+func TestAzureSecretBackendRoleAzureRolesFromResponse(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{"role_name": "Reader", "role_id": "/providers/roles/1", "scope": "/subscriptions/test"},
+		map[string]interface{}{"role_name": "Owner", "role_id": "/providers/roles/2", "scope": "/subscriptions/test"},
+		map[string]interface{}{"role_name": "Contributor", "role_id": "/providers/roles/3", "scope": "/subscriptions/test"},
+	}
+	current := []interface{}{
+		map[string]interface{}{"role_name": "Reader", "role_id": "", "scope": "/subscriptions/test"},
+		map[string]interface{}{"role_name": "", "role_id": "/providers/roles/2", "scope": "/subscriptions/test"},
+	}
+	expected := []map[string]interface{}{
+		{"role_name": "Reader", "role_id": "", "scope": "/subscriptions/test"},
+		{"role_name": "", "role_id": "/providers/roles/2", "scope": "/subscriptions/test"},
+		{"role_name": "Contributor", "role_id": "", "scope": "/subscriptions/test"},
+	}
+	if got := azureSecretBackendRoleAzureRolesFromResponse(raw, current); !reflect.DeepEqual(got, expected) {
+		t.Errorf("azureSecretBackendRoleAzureRolesFromResponse() = %v, expected %v", got, expected)
+	}
+}
+
+func TestAzureSecretBackendRole(t *testing.T) {
+	// Vault looks up the Azure roles when the role is written, so this needs
+	// a real subscription and a service principal allowed to read its roles.
+	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
+	if subscriptionID == "" {
+		t.Skip("ARM_SUBSCRIPTION_ID not set")
+	}
+
+	backend := acctest.RandomWithPrefix("azure")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAzureSecretBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureSecretBackendRoleConfig(backend, subscriptionID, "Reader", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_azure_secret_backend_role.test", "role", "app"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend_role.test", "azure_role.#", "1"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend_role.test", "azure_role.0.role_name", "Reader"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend_role.test", "azure_role.0.role_id", ""),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend_role.test", "ttl", "3600"),
+				),
+			},
+			{
+				Config: testAzureSecretBackendRoleConfig(backend, subscriptionID, "Contributor", 1800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_azure_secret_backend_role.test", "azure_role.0.role_name", "Contributor"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend_role.test", "ttl", "1800"),
+				),
+			},
+			{
+				ResourceName:      "vault_azure_secret_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAzureSecretBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_azure_secret_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("Azure role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAzureSecretBackendRoleConfig(backend, subscriptionID, roleName string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_azure_secret_backend" "test" {
+	path = "%s"
+	subscription_id = "%s"
+	tenant_id = "%s"
+	client_id = "%s"
+	client_secret = "%s"
+}
+
+resource "vault_azure_secret_backend_role" "test" {
+	backend = "${vault_azure_secret_backend.test.path}"
+	role = "app"
+	ttl = %d
+
+	azure_role {
+		role_name = "%s"
+		scope = "/subscriptions/%s"
+	}
+}
+`, backend, subscriptionID, os.Getenv("ARM_TENANT_ID"), os.Getenv("ARM_CLIENT_ID"), os.Getenv("ARM_CLIENT_SECRET"), ttl, roleName, subscriptionID)
+}
@@ -0,0 +1,128 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+// Vault doesn't check the credentials until it creates service principals,
+// so the test doesn't need an Azure subscription.
+func TestAzureSecretBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("azure")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAzureSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureSecretBackendConfig(path, "11111111-1111-1111-1111-111111111111", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "client_id", "11111111-1111-1111-1111-111111111111"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "environment", "AzurePublicCloud"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "default_lease_ttl_seconds", "3600"),
+					testPluginIdentityCheckAccessor("vault_azure_secret_backend.test"),
+				),
+			},
+			{
+				Config: testAzureSecretBackendConfig(path, "22222222-2222-2222-2222-222222222222", 1800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "client_id", "22222222-2222-2222-2222-222222222222"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "default_lease_ttl_seconds", "1800"),
+				),
+			},
+			{
+				ResourceName:            "vault_azure_secret_backend.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func TestAzureSecretBackend_identityToken(t *testing.T) {
+	testAccPluginIdentityPreCheck(t)
+
+	path := acctest.RandomWithPrefix("azure")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		CheckDestroy: testAzureSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureSecretBackendConfig_identityToken(path, "test-audience", 600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "identity_token_key", "default"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "identity_token_audience", "test-audience"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "identity_token_ttl", "600"),
+					testPluginIdentityCheckAccessor("vault_azure_secret_backend.test"),
+				),
+			},
+			{
+				Config: testAzureSecretBackendConfig_identityToken(path, "updated-audience", 1200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "identity_token_audience", "updated-audience"),
+					resource.TestCheckResourceAttr("vault_azure_secret_backend.test", "identity_token_ttl", "1200"),
+				),
+			},
+			{
+				ResourceName:      "vault_azure_secret_backend.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAzureSecretBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_azure_secret_backend" {
+			continue
+		}
+		if _, ok := mounts[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("Azure secret backend %q still mounted", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAzureSecretBackendConfig(path, clientID string, defaultTTL int) string {
+	return fmt.Sprintf(`
+resource "vault_azure_secret_backend" "test" {
+	path = "%s"
+	description = "test"
+	subscription_id = "00000000-0000-0000-0000-000000000001"
+	tenant_id = "00000000-0000-0000-0000-000000000002"
+	client_id = "%s"
+	client_secret = "secret"
+	default_lease_ttl_seconds = %d
+	max_lease_ttl_seconds = 86400
+}
+`, path, clientID, defaultTTL)
+}
+
+func testAzureSecretBackendConfig_identityToken(path, audience string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_azure_secret_backend" "test" {
+	path = "%s"
+	subscription_id = "00000000-0000-0000-0000-000000000001"
+	tenant_id = "00000000-0000-0000-0000-000000000002"
+	client_id = "11111111-1111-1111-1111-111111111111"
+	identity_token_key = "default"
+	identity_token_audience = "%s"
+	identity_token_ttl = %d
+}
+`, path, audience, ttl)
+}
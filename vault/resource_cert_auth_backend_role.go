@@ -0,0 +1,254 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var certAuthBackendRoleFromPathRegex = regexp.MustCompile("^auth/(.+)/certs/([^/]+)$")
+
+// certAuthBackendRoleConstraintFields are the role fields constraining the
+// client certificates allowed to log in, which map onto sets of strings.
+var certAuthBackendRoleConstraintFields = []string{
+	"allowed_names",
+	"allowed_common_names",
+	"allowed_dns_sans",
+	"allowed_email_sans",
+	"allowed_uri_sans",
+	"allowed_organizational_units",
+	"required_extensions",
+}
+
+func certAuthBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: certAuthBackendRoleWrite,
+		Update: certAuthBackendRoleWrite,
+		Delete: certAuthBackendRoleDelete,
+		Read:   certAuthBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "cert",
+				Description: "Path of the TLS certificate auth backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"certificate": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "PEM-encoded CA certificate, or client certificate, trusted to log in.",
+			},
+
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Display name of the tokens issued for the role, its name if not set.",
+			},
+
+			"allowed_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Common names or SANs, possibly globbed, of the certificates allowed to log in.",
+			},
+
+			"allowed_common_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Common names, possibly globbed, of the certificates allowed to log in.",
+			},
+
+			"allowed_dns_sans": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "DNS SANs, possibly globbed, of the certificates allowed to log in.",
+			},
+
+			"allowed_email_sans": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Email SANs, possibly globbed, of the certificates allowed to log in.",
+			},
+
+			"allowed_uri_sans": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "URI SANs, possibly globbed, of the certificates allowed to log in.",
+			},
+
+			"allowed_organizational_units": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Organizational units of the certificates allowed to log in.",
+			},
+
+			"required_extensions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Extensions the certificates must have, as <oid>:<value>.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies of the tokens issued for the role.",
+			},
+
+			"token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_bound_cidrs": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "CIDR blocks of the addresses allowed to use the tokens issued for the role.",
+			},
+
+			"period": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Period in seconds of the tokens issued for the role, making them periodic if set.",
+			},
+		},
+	}
+}
+
+func certAuthBackendRolePath(backend, name string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/certs/" + strings.Trim(name, "/")
+}
+
+func certAuthBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := certAuthBackendRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"certificate": d.Get("certificate").(string),
+		"policies":    d.Get("policies").(*schema.Set).List(),
+		"ttl":         d.Get("token_ttl").(int),
+		"max_ttl":     d.Get("token_max_ttl").(int),
+		"period":      d.Get("period").(int),
+		"bound_cidrs": d.Get("token_bound_cidrs").(*schema.Set).List(),
+	}
+	if v, ok := d.GetOk("display_name"); ok {
+		data["display_name"] = v.(string)
+	}
+	for _, k := range certAuthBackendRoleConstraintFields {
+		data[k] = d.Get(k).(*schema.Set).List()
+	}
+
+	log.Printf("[DEBUG] Writing TLS certificate auth backend role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing TLS certificate auth backend role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return certAuthBackendRoleRead(d, meta)
+}
+
+func certAuthBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := certAuthBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid TLS certificate auth backend role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading TLS certificate auth backend role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading TLS certificate auth backend role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] TLS certificate auth backend role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	d.Set("certificate", secret.Data["certificate"])
+	d.Set("display_name", secret.Data["display_name"])
+	d.Set("token_ttl", intFromResponse(tokenSetting(secret.Data, "token_ttl", "ttl")))
+	d.Set("token_max_ttl", intFromResponse(tokenSetting(secret.Data, "token_max_ttl", "max_ttl")))
+	d.Set("period", intFromResponse(tokenSetting(secret.Data, "token_period", "period")))
+
+	sets := map[string]interface{}{
+		"policies":          tokenSetting(secret.Data, "token_policies", "policies"),
+		"token_bound_cidrs": tokenSetting(secret.Data, "token_bound_cidrs", "bound_cidrs"),
+	}
+	for _, k := range certAuthBackendRoleConstraintFields {
+		if v, ok := secret.Data[k]; ok {
+			sets[k] = v
+		}
+	}
+	for k, v := range sets {
+		if err := d.Set(k, flattenStringList(v)); err != nil {
+			return fmt.Errorf("error setting %s of TLS certificate auth backend role %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func certAuthBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting TLS certificate auth backend role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting TLS certificate auth backend role %q: %s", path, err)
+	}
+
+	return nil
+}
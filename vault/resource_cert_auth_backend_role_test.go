@@ -0,0 +1,124 @@
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestCertAuthBackendRole(t *testing.T) {
+	backend := acctest.RandomWithPrefix("cert")
+	certificate := testCertAuthBackendCA(t)
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testCertAuthBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testCertAuthBackendRoleConfig(backend, certificate, `
+	allowed_common_names = ["app.example.com"]
+	policies = ["default", "dev"]
+	token_ttl = 300
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "id", "auth/"+backend+"/certs/test"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "certificate", certificate),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "display_name", "test"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "allowed_common_names.#", "1"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "token_ttl", "300"),
+				),
+			},
+			{
+				Config: testCertAuthBackendRoleConfig(backend, certificate, `
+	display_name = "app"
+	allowed_dns_sans = ["*.example.com"]
+	allowed_uri_sans = ["spiffe://example.com/*"]
+	policies = ["dev"]
+	token_ttl = 600
+	token_max_ttl = 1200
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "display_name", "app"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "allowed_common_names.#", "0"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "allowed_dns_sans.#", "1"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "allowed_uri_sans.#", "1"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("vault_cert_auth_backend_role.test", "token_max_ttl", "1200"),
+				),
+			},
+			{
+				ResourceName:      "vault_cert_auth_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testCertAuthBackendCA returns a PEM-encoded self-signed CA certificate.
+func testCertAuthBackendCA(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testCertAuthBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_cert_auth_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for TLS certificate auth backend role %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("TLS certificate auth backend role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testCertAuthBackendRoleConfig(backend, certificate, fields string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "cert" {
+	type = "cert"
+	path = "%s"
+}
+
+resource "vault_cert_auth_backend_role" "test" {
+	backend = "${vault_auth_backend.cert.path}"
+	name = "test"
+	certificate = %q
+%s
+}
+`, backend, certificate, fields)
+}
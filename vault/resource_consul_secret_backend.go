@@ -0,0 +1,211 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func consulSecretBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: consulSecretBackendCreate,
+		Update: consulSecretBackendUpdate,
+		Delete: consulSecretBackendDelete,
+		Read:   consulSecretBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "consul",
+				Description: "Path to mount the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the mount.",
+			},
+
+			"default_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default TTL in seconds of the tokens issued by the backend.",
+			},
+
+			"max_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TTL in seconds of the tokens issued by the backend.",
+			},
+
+			"address": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Address of the Consul agent or server, as host:port.",
+			},
+
+			"scheme": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "http",
+				Description: "Scheme of the connection to Consul, http or https.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if value := v.(string); value != "http" && value != "https" {
+						errs = append(errs, fmt.Errorf("%s must be http or https, got %q", k, value))
+					}
+					return
+				},
+			},
+
+			"token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Management token of Consul Vault creates ACL tokens with.",
+			},
+		},
+	}
+}
+
+func consulSecretBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Mounting Consul secret backend at %q", path)
+	err := client.Sys().Mount(path, &api.MountInput{
+		Type:        "consul",
+		Description: d.Get("description").(string),
+		Config: api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error mounting Consul secret backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if err := consulSecretBackendWriteAccess(client, d); err != nil {
+		return err
+	}
+
+	return consulSecretBackendRead(d, meta)
+}
+
+func consulSecretBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("default_lease_ttl_seconds") || d.HasChange("max_lease_ttl_seconds") {
+		log.Printf("[DEBUG] Tuning Consul secret backend %q", path)
+		err := client.Sys().TuneMount(path, api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		})
+		if err != nil {
+			return fmt.Errorf("error tuning Consul secret backend %q: %s", path, err)
+		}
+	}
+
+	if d.HasChange("description") {
+		if err := mountTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("address") || d.HasChange("scheme") || d.HasChange("token") {
+		if err := consulSecretBackendWriteAccess(client, d); err != nil {
+			return err
+		}
+	}
+
+	return consulSecretBackendRead(d, meta)
+}
+
+// consulSecretBackendWriteAccess writes the address and token the backend
+// connects to Consul with, which are replaced all together on every write.
+func consulSecretBackendWriteAccess(client *api.Client, d *schema.ResourceData) error {
+	path := d.Id() + "/config/access"
+
+	log.Printf("[DEBUG] Writing Consul secret backend access %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"address": d.Get("address").(string),
+		"scheme":  d.Get("scheme").(string),
+		"token":   d.Get("token").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing Consul secret backend access %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func consulSecretBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading Consul secret backend %q", path)
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("error reading mounts from Vault: %s", err)
+	}
+	mount, ok := mounts[path+"/"]
+	if !ok {
+		log.Printf("[WARN] Consul secret backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("description", mount.Description)
+	d.Set("default_lease_ttl_seconds", mount.Config.DefaultLeaseTTL)
+	d.Set("max_lease_ttl_seconds", mount.Config.MaxLeaseTTL)
+
+	// Old Vault versions can't read the access config back, in which case
+	// it's kept from the configuration. The token is never returned.
+	config, err := client.Logical().Read(path + "/config/access")
+	if err != nil && !strings.Contains(err.Error(), "unsupported operation") {
+		return fmt.Errorf("error reading Consul secret backend access %q: %s", path, err)
+	}
+	if config != nil {
+		for _, k := range []string{"address", "scheme"} {
+			if v, ok := config.Data[k]; ok {
+				d.Set(k, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+func consulSecretBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Unmounting Consul secret backend %q", path)
+	if err := client.Sys().Unmount(path); err != nil {
+		return fmt.Errorf("error unmounting Consul secret backend %q: %s", path, err)
+	}
+
+	return nil
+}
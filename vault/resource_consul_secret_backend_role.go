@@ -0,0 +1,160 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var consulSecretBackendRoleFromPathRegex = regexp.MustCompile("^(.+)/roles/([^/]+)$")
+
+func consulSecretBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: consulSecretBackendRoleWrite,
+		Update: consulSecretBackendRoleWrite,
+		Delete: consulSecretBackendRoleDelete,
+		Read:   consulSecretBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "consul",
+				Description: "Path of the Consul secret backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Consul ACL policies attached to the tokens. Required for client tokens.",
+			},
+
+			"token_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "client",
+				Description: "Type of the tokens, client or management.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if value := v.(string); value != "client" && value != "management" {
+						errs = append(errs, fmt.Errorf("%s must be client or management, got %q", k, value))
+					}
+					return
+				},
+			},
+
+			"local": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the tokens are local to the datacenter instead of replicated to all of them.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "TTL in seconds of the tokens, the default lease TTL of the backend if 0.",
+			},
+
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL in seconds of the tokens, the maximum lease TTL of the backend if 0.",
+			},
+		},
+	}
+}
+
+func consulSecretBackendRolePath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(name, "/")
+}
+
+func consulSecretBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := consulSecretBackendRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"policies":   d.Get("policies").(*schema.Set).List(),
+		"token_type": d.Get("token_type").(string),
+		"local":      d.Get("local").(bool),
+		"ttl":        fmt.Sprintf("%ds", d.Get("ttl").(int)),
+		"max_ttl":    fmt.Sprintf("%ds", d.Get("max_ttl").(int)),
+	}
+
+	log.Printf("[DEBUG] Writing Consul role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing Consul role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return consulSecretBackendRoleRead(d, meta)
+}
+
+func consulSecretBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := consulSecretBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid Consul role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading Consul role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading Consul role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Consul role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	d.Set("token_type", secret.Data["token_type"])
+	d.Set("local", secret.Data["local"])
+	// Vault versions before 1.0 return the TTLs as duration strings.
+	d.Set("ttl", durationSecondsFromResponse(secret.Data["ttl"]))
+	d.Set("max_ttl", durationSecondsFromResponse(secret.Data["max_ttl"]))
+	if err := d.Set("policies", flattenStringList(secret.Data["policies"])); err != nil {
+		return fmt.Errorf("error setting policies of Consul role %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func consulSecretBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting Consul role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting Consul role %q: %s", path, err)
+	}
+
+	return nil
+}
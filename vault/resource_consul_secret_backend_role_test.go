@@ -0,0 +1,80 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestConsulSecretBackendRole(t *testing.T) {
+	backend := acctest.RandomWithPrefix("consul")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testConsulSecretBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testConsulSecretBackendRoleConfig(backend, `["app"]`, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_consul_secret_backend_role.test", "backend", backend),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend_role.test", "name", "app"),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend_role.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend_role.test", "token_type", "client"),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend_role.test", "ttl", "3600"),
+				),
+			},
+			{
+				Config: testConsulSecretBackendRoleConfig(backend, `["app", "logs"]`, 1800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_consul_secret_backend_role.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend_role.test", "ttl", "1800"),
+				),
+			},
+			{
+				ResourceName:      "vault_consul_secret_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testConsulSecretBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_consul_secret_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("Consul role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testConsulSecretBackendRoleConfig(backend, policies string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_consul_secret_backend" "test" {
+	path = "%s"
+	address = "consul:8500"
+	token = "secret"
+}
+
+resource "vault_consul_secret_backend_role" "test" {
+	backend = "${vault_consul_secret_backend.test.path}"
+	name = "app"
+	policies = %s
+	ttl = %d
+	max_ttl = 86400
+}
+`, backend, policies, ttl)
+}
@@ -0,0 +1,77 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+// Vault doesn't check the access config until it issues tokens, so the
+// test doesn't need a Consul server.
+func TestConsulSecretBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("consul")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testConsulSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testConsulSecretBackendConfig(path, "consul-1:8500", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_consul_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend.test", "address", "consul-1:8500"),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend.test", "default_lease_ttl_seconds", "3600"),
+				),
+			},
+			{
+				Config: testConsulSecretBackendConfig(path, "consul-2:8500", 1800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_consul_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend.test", "address", "consul-2:8500"),
+					resource.TestCheckResourceAttr("vault_consul_secret_backend.test", "default_lease_ttl_seconds", "1800"),
+				),
+			},
+			{
+				ResourceName:            "vault_consul_secret_backend.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+	})
+}
+
+func testConsulSecretBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_consul_secret_backend" {
+			continue
+		}
+		if _, ok := mounts[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("Consul secret backend %q still mounted", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testConsulSecretBackendConfig(path, address string, defaultTTL int) string {
+	return fmt.Sprintf(`
+resource "vault_consul_secret_backend" "test" {
+	path = "%s"
+	description = "test"
+	address = "%s"
+	token = "secret"
+	default_lease_ttl_seconds = %d
+	max_lease_ttl_seconds = 86400
+}
+`, path, address, defaultTTL)
+}
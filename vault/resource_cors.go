@@ -0,0 +1,114 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const corsConfigPath = "sys/config/cors"
+
+func corsResource() *schema.Resource {
+	return &schema.Resource{
+		Create: corsWrite,
+		Update: corsWrite,
+		Delete: corsDelete,
+		Read:   corsRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"allowed_origins": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Origins allowed to make cross-origin requests, or * for any.",
+			},
+
+			"allowed_headers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers allowed in cross-origin requests, on top of those always allowed by Vault.",
+			},
+		},
+	}
+}
+
+func corsWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Enabling CORS in Vault")
+	_, err := client.Logical().Write(corsConfigPath, map[string]interface{}{
+		"enabled":         true,
+		"allowed_origins": d.Get("allowed_origins").(*schema.Set).List(),
+		"allowed_headers": d.Get("allowed_headers").([]interface{}),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing CORS config %q: %s", corsConfigPath, err)
+	}
+
+	d.SetId(corsConfigPath)
+
+	return corsRead(d, meta)
+}
+
+func corsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Reading CORS config from Vault")
+	secret, err := client.Logical().Read(corsConfigPath)
+	if err != nil {
+		return fmt.Errorf("error reading CORS config %q: %s", corsConfigPath, err)
+	}
+	if secret == nil || secret.Data["enabled"] != true {
+		log.Printf("[WARN] CORS is disabled, removing from state")
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("allowed_origins", flattenStringList(secret.Data["allowed_origins"])); err != nil {
+		return fmt.Errorf("error setting allowed_origins of CORS config: %s", err)
+	}
+	headers := corsConfiguredHeaders(toStringArray(d.Get("allowed_headers").([]interface{})), flattenStringList(secret.Data["allowed_headers"]))
+	if err := d.Set("allowed_headers", headers); err != nil {
+		return fmt.Errorf("error setting allowed_headers of CORS config: %s", err)
+	}
+
+	return nil
+}
+
+// corsConfiguredHeaders returns the configured headers found in the headers
+// allowed by Vault. Vault adds the headers it always allows to the
+// configured ones, and canonicalizes their names, so these are compared
+// case-insensitively and the rest ignored.
+func corsConfiguredHeaders(configured, allowed []string) []string {
+	found := make(map[string]bool, len(allowed))
+	for _, h := range allowed {
+		found[strings.ToLower(h)] = true
+	}
+
+	headers := []string{}
+	for _, h := range configured {
+		if found[strings.ToLower(h)] {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+func corsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Disabling CORS in Vault")
+	if _, err := client.Logical().Delete(corsConfigPath); err != nil {
+		return fmt.Errorf("error deleting CORS config %q: %s", corsConfigPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package vault
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestCORSConfiguredHeaders(t *testing.T) {
+	allowed := []string{"Content-Type", "X-Requested-With", "X-Vault-Token", "X-Custom-Header"}
+	for _, c := range []struct {
+		configured []string
+		expected   []string
+	}{
+		{nil, []string{}},
+		{[]string{"X-Custom-Header"}, []string{"X-Custom-Header"}},
+		{[]string{"x-custom-header"}, []string{"x-custom-header"}},
+		{[]string{"X-Custom-Header", "X-Removed"}, []string{"X-Custom-Header"}},
+	} {
+		if got := corsConfiguredHeaders(c.configured, allowed); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("corsConfiguredHeaders(%v) = %v, expected %v", c.configured, got, c.expected)
+		}
+	}
+}
+
+func TestCORS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testCORSCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testCORSConfig(`["https://example.com"]`, `["X-Custom-Header"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_cors.test", "id", "sys/config/cors"),
+					resource.TestCheckResourceAttr("vault_cors.test", "allowed_origins.#", "1"),
+					resource.TestCheckResourceAttr("vault_cors.test", "allowed_headers.#", "1"),
+					resource.TestCheckResourceAttr("vault_cors.test", "allowed_headers.0", "X-Custom-Header"),
+				),
+			},
+			{
+				Config: testCORSConfig(`["https://example.com", "https://example.org"]`, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_cors.test", "allowed_origins.#", "2"),
+					resource.TestCheckResourceAttr("vault_cors.test", "allowed_headers.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "vault_cors.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCORSCheckDestroy(*terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+	secret, err := client.Logical().Read("sys/config/cors")
+	if err != nil {
+		return fmt.Errorf("error reading CORS config: %s", err)
+	}
+	if secret != nil && secret.Data["enabled"] == true {
+		return fmt.Errorf("CORS is still enabled")
+	}
+	return nil
+}
+
+func testCORSConfig(origins, headers string) string {
+	return fmt.Sprintf(`
+resource "vault_cors" "test" {
+	allowed_origins = %s
+	allowed_headers = %s
+}
+`, origins, headers)
+}
@@ -0,0 +1,292 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var databaseSecretBackendConnectionFromPathRegex = regexp.MustCompile("^(.+)/config/([^/]+)$")
+
+// databaseSecretBackendConnectionPlugin describes the block configuring a
+// connection with one of the plugins of the database secret backend.
+type databaseSecretBackendConnectionPlugin struct {
+	block      string
+	pluginName string
+	// fields are the connection details of the plugin, besides the
+	// username and password all of them have.
+	fields map[string]*schema.Schema
+}
+
+var databaseSecretBackendConnectionPlugins = []databaseSecretBackendConnectionPlugin{
+	{"hana", "hana-database-plugin", databaseSQLConnectionFields()},
+	{"mongodb", "mongodb-database-plugin", map[string]*schema.Schema{
+		"connection_url": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Connection string of the MongoDB server, with {{username}} and {{password}} placeholders.",
+		},
+		"write_concern": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "JSON-encoded write concern of the operations run by Vault.",
+		},
+	}},
+	{"mssql", "mssql-database-plugin", databaseSQLConnectionFields()},
+	{"mysql", "mysql-database-plugin", databaseSQLConnectionFields()},
+	{"mysql_aurora", "mysql-aurora-database-plugin", databaseSQLConnectionFields()},
+	{"mysql_legacy", "mysql-legacy-database-plugin", databaseSQLConnectionFields()},
+	{"mysql_rds", "mysql-rds-database-plugin", databaseSQLConnectionFields()},
+	{"oracle", "oracle-database-plugin", databaseSQLConnectionFields()},
+	{"postgresql", "postgresql-database-plugin", databaseSQLConnectionFields()},
+}
+
+// databaseSQLConnectionFields returns the connection details shared by
+// the plugins of SQL databases.
+func databaseSQLConnectionFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"connection_url": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Connection string of the database, with {{username}} and {{password}} placeholders.",
+		},
+		"max_open_connections": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     2,
+			Description: "Maximum number of open connections to the database.",
+		},
+		"max_idle_connections": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Maximum number of idle connections to the database, 0 meaning max_open_connections.",
+		},
+		"max_connection_lifetime": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Maximum time in seconds a connection is reused, forever if 0.",
+		},
+	}
+}
+
+// databaseSecretBackendConnectionBlocks returns the names of the blocks of
+// all the supported plugins.
+func databaseSecretBackendConnectionBlocks() []string {
+	blocks := make([]string, 0, len(databaseSecretBackendConnectionPlugins))
+	for _, p := range databaseSecretBackendConnectionPlugins {
+		blocks = append(blocks, p.block)
+	}
+	return blocks
+}
+
+func databaseSecretBackendConnectionResource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"backend": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Path of the database secret backend.",
+			StateFunc: func(v interface{}) string {
+				return strings.Trim(v.(string), "/")
+			},
+		},
+
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the connection.",
+		},
+
+		"allowed_roles": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Roles allowed to use the connection, or * for any.",
+		},
+
+		"verify_connection": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether the connection is verified when written.",
+		},
+
+		"root_rotation_statements": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Statements run to rotate the root credential.",
+		},
+	}
+
+	blocks := databaseSecretBackendConnectionBlocks()
+	for _, p := range databaseSecretBackendConnectionPlugins {
+		fields := map[string]*schema.Schema{
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Username of the root credential of the connection.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of the root credential of the connection.",
+			},
+		}
+		for k, v := range p.fields {
+			fields[k] = v
+		}
+
+		conflicts := []string{}
+		for _, b := range blocks {
+			if b != p.block {
+				conflicts = append(conflicts, b)
+			}
+		}
+
+		s[p.block] = &schema.Schema{
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: conflicts,
+			Description:   fmt.Sprintf("Connection with the %s plugin.", p.pluginName),
+			Elem:          &schema.Resource{Schema: fields},
+		}
+	}
+
+	return &schema.Resource{
+		Create: databaseSecretBackendConnectionWrite,
+		Update: databaseSecretBackendConnectionWrite,
+		Delete: databaseSecretBackendConnectionDelete,
+		Read:   databaseSecretBackendConnectionRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func databaseSecretBackendConnectionPath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/config/" + strings.Trim(name, "/")
+}
+
+func databaseSecretBackendConnectionWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := databaseSecretBackendConnectionPath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"allowed_roles":            toStringArray(d.Get("allowed_roles").([]interface{})),
+		"verify_connection":        d.Get("verify_connection").(bool),
+		"root_rotation_statements": toStringArray(d.Get("root_rotation_statements").([]interface{})),
+	}
+
+	var plugin *databaseSecretBackendConnectionPlugin
+	for i, p := range databaseSecretBackendConnectionPlugins {
+		if v, ok := d.GetOk(p.block); ok {
+			plugin = &databaseSecretBackendConnectionPlugins[i]
+			for k, v := range v.([]interface{})[0].(map[string]interface{}) {
+				data[k] = v
+			}
+		}
+	}
+	if plugin == nil {
+		return fmt.Errorf("one of %s must be set", strings.Join(databaseSecretBackendConnectionBlocks(), ", "))
+	}
+	data["plugin_name"] = plugin.pluginName
+
+	log.Printf("[DEBUG] Writing database connection %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing database connection %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return databaseSecretBackendConnectionRead(d, meta)
+}
+
+func databaseSecretBackendConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := databaseSecretBackendConnectionFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid database connection ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading database connection %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading database connection %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Database connection %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	if v, ok := secret.Data["verify_connection"]; ok {
+		d.Set("verify_connection", v)
+	}
+	for _, k := range []string{"allowed_roles", "root_rotation_statements"} {
+		if err := d.Set(k, flattenStringList(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of database connection %q: %s", k, path, err)
+		}
+	}
+
+	details, _ := secret.Data["connection_details"].(map[string]interface{})
+	pluginName, _ := secret.Data["plugin_name"].(string)
+	for _, p := range databaseSecretBackendConnectionPlugins {
+		if p.pluginName != pluginName {
+			d.Set(p.block, nil)
+			continue
+		}
+
+		username, _ := details["username"].(string)
+		block := map[string]interface{}{
+			"username": username,
+		}
+		// Vault never returns the password, so it is kept from the
+		// configuration.
+		if v, ok := d.GetOk(p.block + ".0.password"); ok {
+			block["password"] = v
+		}
+		for k, s := range p.fields {
+			switch s.Type {
+			case schema.TypeInt:
+				block[k] = intFromResponse(details[k])
+			default:
+				v, _ := details[k].(string)
+				block[k] = v
+			}
+		}
+		if err := d.Set(p.block, []interface{}{block}); err != nil {
+			return fmt.Errorf("error setting %s of database connection %q: %s", p.block, path, err)
+		}
+	}
+
+	return nil
+}
+
+func databaseSecretBackendConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting database connection %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting database connection %q: %s", path, err)
+	}
+
+	return nil
+}
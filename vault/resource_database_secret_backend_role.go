@@ -0,0 +1,187 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var databaseSecretBackendRoleFromPathRegex = regexp.MustCompile("^(.+)/roles/([^/]+)$")
+
+func databaseSecretBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: databaseSecretBackendRoleWrite,
+		Update: databaseSecretBackendRoleWrite,
+		Delete: databaseSecretBackendRoleDelete,
+		Read:   databaseSecretBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the database secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"db_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the connection the credentials are created with.",
+			},
+
+			"creation_statements": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Statements run to create the credentials.",
+			},
+
+			"revocation_statements": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Statements run to revoke the credentials.",
+			},
+
+			"rollback_statements": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Statements run to roll back a failed creation of credentials.",
+			},
+
+			"renew_statements": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Statements run to renew the credentials.",
+			},
+
+			"default_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default TTL of the credentials in seconds.",
+			},
+
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL of the credentials in seconds.",
+			},
+		},
+	}
+}
+
+var databaseSecretBackendRoleStatements = []string{
+	"creation_statements",
+	"revocation_statements",
+	"rollback_statements",
+	"renew_statements",
+}
+
+func databaseSecretBackendRolePath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(name, "/")
+}
+
+func databaseSecretBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := databaseSecretBackendRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"db_name":     d.Get("db_name").(string),
+		"default_ttl": d.Get("default_ttl").(int),
+		"max_ttl":     d.Get("max_ttl").(int),
+	}
+	for _, k := range databaseSecretBackendRoleStatements {
+		data[k] = toStringArray(d.Get(k).([]interface{}))
+	}
+
+	log.Printf("[DEBUG] Writing database role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing database role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return databaseSecretBackendRoleRead(d, meta)
+}
+
+func databaseSecretBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := databaseSecretBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid database role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading database role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading database role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Database role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	d.Set("db_name", secret.Data["db_name"])
+	d.Set("default_ttl", intFromResponse(secret.Data["default_ttl"]))
+	d.Set("max_ttl", intFromResponse(secret.Data["max_ttl"]))
+
+	for _, k := range databaseSecretBackendRoleStatements {
+		if err := d.Set(k, databaseStatements(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of database role %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func databaseSecretBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting database role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting database role %q: %s", path, err)
+	}
+
+	return nil
+}
+
+// databaseStatements normalizes the statements of a database role from a
+// response. Vault versions before 0.10 return them as a single string,
+// which can't be split like other lists as statements contain commas.
+func databaseStatements(raw interface{}) []string {
+	if s, ok := raw.(string); ok {
+		if s == "" {
+			return []string{}
+		}
+		return []string{s}
+	}
+	return flattenStringList(raw)
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func databaseSecretBackendRootRotationResource() *schema.Resource {
+	return &schema.Resource{
+		Create: databaseSecretBackendRootRotationCreate,
+		Delete: databaseSecretBackendRootRotationDelete,
+		Read:   databaseSecretBackendRootRotationRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the database secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"connection_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the connection whose root credential is rotated.",
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that rotate the root credential again when changed.",
+			},
+		},
+	}
+}
+
+func databaseSecretBackendRootRotationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	name := d.Get("connection_name").(string)
+	path := backend + "/rotate-root/" + name
+
+	log.Printf("[DEBUG] Rotating root credential of database connection %q", path)
+	if _, err := client.Logical().Write(path, nil); err != nil {
+		return fmt.Errorf("error rotating root credential of database connection %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return databaseSecretBackendRootRotationRead(d, meta)
+}
+
+func databaseSecretBackendRootRotationRead(d *schema.ResourceData, meta interface{}) error {
+	// A rotation is an action rather than an object, so there is nothing
+	// in Vault to refresh it from.
+	return nil
+}
+
+func databaseSecretBackendRootRotationDelete(d *schema.ResourceData, meta interface{}) error {
+	// The previous root credential is gone once rotated, so destroying
+	// the resource only removes it from the state.
+	log.Printf("[DEBUG] Removing root rotation of database connection %q from state", d.Id())
+
+	return nil
+}
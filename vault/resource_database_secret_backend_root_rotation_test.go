@@ -0,0 +1,58 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/vault/api"
+)
+
+// The root credential of the connection is rotated for real, so the test
+// needs a disposable PostgreSQL server, given as a connection URL template
+// with {{username}} and {{password}} placeholders in POSTGRES_URL, along
+// with its current credentials in POSTGRES_USERNAME and POSTGRES_PASSWORD.
+func TestDatabaseSecretBackendRootRotation(t *testing.T) {
+	connURL := os.Getenv("POSTGRES_URL")
+	if connURL == "" {
+		t.Skip("POSTGRES_URL not set")
+	}
+
+	backend := acctest.RandomWithPrefix("database")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if err := client.Sys().Mount(backend, &api.MountInput{Type: "database"}); err != nil {
+						t.Fatal(err)
+					}
+					_, err := client.Logical().Write(backend+"/config/postgres", map[string]interface{}{
+						"plugin_name":    "postgresql-database-plugin",
+						"connection_url": connURL,
+						"username":       os.Getenv("POSTGRES_USERNAME"),
+						"password":       os.Getenv("POSTGRES_PASSWORD"),
+						"allowed_roles":  "*",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(`
+resource "vault_database_secret_backend_root_rotation" "test" {
+	backend = "%s"
+	connection_name = "postgres"
+	triggers {
+		version = "1"
+	}
+}
+`, backend),
+				Check: resource.TestCheckResourceAttr("vault_database_secret_backend_root_rotation.test", "id", backend+"/rotate-root/postgres"),
+			},
+		},
+	})
+}
@@ -0,0 +1,62 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func egpPolicyResource() *schema.Resource {
+	s := sentinelPolicySchema("endpoint governing policy")
+	s["paths"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Required:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+		Description: "Paths the policy applies to, ending with * to apply to every path with that prefix.",
+	}
+
+	return &schema.Resource{
+		Create: egpPolicyWrite,
+		Update: egpPolicyWrite,
+		Delete: egpPolicyDelete,
+		Read:   egpPolicyRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func egpPolicyWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := sentinelPolicyWrite(d, client, "egp", "EGP policy", map[string]interface{}{
+		"paths": d.Get("paths").(*schema.Set).List(),
+	}); err != nil {
+		return err
+	}
+
+	return egpPolicyRead(d, meta)
+}
+
+func egpPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := sentinelPolicyRead(d, client, "egp", "EGP policy")
+	if err != nil || data == nil {
+		return err
+	}
+
+	if err := d.Set("paths", flattenStringList(data["paths"])); err != nil {
+		return fmt.Errorf("error setting paths of EGP policy %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func egpPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	return sentinelPolicyDelete(d, meta.(*api.Client), "egp", "EGP policy")
+}
@@ -0,0 +1,98 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestSentinelPolicyEnforcementLevel(t *testing.T) {
+	validate := sentinelPolicySchema("policy")["enforcement_level"].ValidateFunc
+	for level, valid := range map[string]bool{
+		"advisory":       true,
+		"soft-mandatory": true,
+		"hard-mandatory": true,
+		"mandatory":      false,
+		"":               false,
+	} {
+		_, errs := validate(level, "enforcement_level")
+		if got := len(errs) == 0; got != valid {
+			t.Errorf("enforcement level %q valid = %t, expected %t", level, got, valid)
+		}
+	}
+}
+
+func TestEGPPolicy(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	// Sentinel policies are only available in Vault Enterprise.
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+
+	name := acctest.RandomWithPrefix("test-egp")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testSentinelPolicyCheckDestroy("egp", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testEGPPolicyConfig(name, "advisory", `"*"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_egp_policy.test", "name", name),
+					resource.TestCheckResourceAttr("vault_egp_policy.test", "enforcement_level", "advisory"),
+					resource.TestCheckResourceAttr("vault_egp_policy.test", "paths.#", "1"),
+				),
+			},
+			{
+				Config: testEGPPolicyConfig(name, "soft-mandatory", `"secret/*", "kv/*"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_egp_policy.test", "enforcement_level", "soft-mandatory"),
+					resource.TestCheckResourceAttr("vault_egp_policy.test", "paths.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "vault_egp_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testSentinelPolicyCheckDestroy(policyType, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		path := "sys/policies/" + policyType + "/" + name
+		secret, err := client.Logical().Read(path)
+		if err != nil {
+			return fmt.Errorf("error checking for policy %q: %s", path, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("policy %q still exists", path)
+		}
+		return nil
+	}
+}
+
+func testEGPPolicyConfig(name, level, paths string) string {
+	return fmt.Sprintf(`
+resource "vault_egp_policy" "test" {
+  name              = %q
+  enforcement_level = %q
+  paths             = [%s]
+
+  policy = <<EOT
+main = rule {
+  true
+}
+EOT
+}
+`, name, level, paths)
+}
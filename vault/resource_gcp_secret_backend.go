@@ -0,0 +1,240 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func gcpSecretBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: gcpSecretBackendCreate,
+		Update: gcpSecretBackendUpdate,
+		Delete: gcpSecretBackendDelete,
+		Read:   gcpSecretBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "gcp",
+				Description: "Path to mount the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the mount.",
+			},
+
+			"default_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"max_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"credentials": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "JSON-encoded key of the service account Vault manages GCP credentials with, the credentials of the environment of Vault if not set.",
+				ValidateFunc: ValidateDataJSON,
+				StateFunc:    NormalizeDataJSON,
+			},
+
+			"service_account_email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Email of the service account impersonated with plugin identity tokens instead of credentials.",
+			},
+
+			"identity_token_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key signing the plugin identity tokens of the backend, the default key if not set.",
+			},
+
+			"identity_token_audience": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Audience of the plugin identity tokens the backend gets cloud credentials with, Vault Enterprise only.",
+			},
+
+			"identity_token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "TTL in seconds of the plugin identity tokens.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Accessor of the mount, part of the subject of the plugin identity tokens.",
+			},
+		},
+	}
+}
+
+func gcpSecretBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Mounting GCP secret backend at %q", path)
+	err := client.Sys().Mount(path, &api.MountInput{
+		Type:        "gcp",
+		Description: d.Get("description").(string),
+		Config: api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error mounting GCP secret backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if _, ok := d.GetOk("identity_token_key"); ok {
+		if err := pluginIdentityTuneKey(d, client, path); err != nil {
+			return err
+		}
+	}
+
+	if err := gcpSecretBackendWriteConfig(client, d); err != nil {
+		return err
+	}
+
+	return gcpSecretBackendRead(d, meta)
+}
+
+func gcpSecretBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("default_lease_ttl_seconds") || d.HasChange("max_lease_ttl_seconds") {
+		log.Printf("[DEBUG] Tuning GCP secret backend %q", path)
+		err := client.Sys().TuneMount(path, api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		})
+		if err != nil {
+			return fmt.Errorf("error tuning GCP secret backend %q: %s", path, err)
+		}
+	}
+
+	if d.HasChange("identity_token_key") {
+		if err := pluginIdentityTuneKey(d, client, path); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("description") {
+		if err := mountTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("credentials") || d.HasChange("service_account_email") ||
+		d.HasChange("identity_token_audience") || d.HasChange("identity_token_ttl") {
+		if err := gcpSecretBackendWriteConfig(client, d); err != nil {
+			return err
+		}
+	}
+
+	return gcpSecretBackendRead(d, meta)
+}
+
+// gcpSecretBackendWriteConfig writes the credentials of the backend, or the
+// service account and plugin identity token settings.
+func gcpSecretBackendWriteConfig(client *api.Client, d *schema.ResourceData) error {
+	path := d.Id() + "/config"
+
+	log.Printf("[DEBUG] Writing GCP secret backend config %q", path)
+	data := map[string]interface{}{
+		"credentials":           d.Get("credentials").(string),
+		"service_account_email": d.Get("service_account_email").(string),
+	}
+	pluginIdentityWriteData(d, data)
+
+	_, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error writing GCP secret backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func gcpSecretBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading GCP secret backend %q", path)
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("error reading mounts from Vault: %s", err)
+	}
+	mount, ok := mounts[path+"/"]
+	if !ok {
+		log.Printf("[WARN] GCP secret backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("description", mount.Description)
+	d.Set("default_lease_ttl_seconds", mount.Config.DefaultLeaseTTL)
+	d.Set("max_lease_ttl_seconds", mount.Config.MaxLeaseTTL)
+
+	// The credentials are never returned, so they're kept from the
+	// configuration.
+	config, err := client.Logical().Read(path + "/config")
+	if err != nil {
+		return fmt.Errorf("error reading GCP secret backend config %q: %s", path, err)
+	}
+	var configData map[string]interface{}
+	if config != nil {
+		configData = config.Data
+		if v, ok := config.Data["service_account_email"]; ok {
+			d.Set("service_account_email", v)
+		}
+	}
+
+	return pluginIdentityRead(d, client, path, configData)
+}
+
+func gcpSecretBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Unmounting GCP secret backend %q", path)
+	if err := client.Sys().Unmount(path); err != nil {
+		return fmt.Errorf("error unmounting GCP secret backend %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+// Without credentials Vault uses the ones of its environment, which it
+// doesn't check until it creates rolesets, so the test doesn't need a GCP
+// project.
+func TestGCPSecretBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("gcp")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testGCPSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testGCPSecretBackendConfig(path, "test", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "description", "test"),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "default_lease_ttl_seconds", "3600"),
+					testPluginIdentityCheckAccessor("vault_gcp_secret_backend.test"),
+				),
+			},
+			{
+				Config: testGCPSecretBackendConfig(path, "updated", 1800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "description", "updated"),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "default_lease_ttl_seconds", "1800"),
+				),
+			},
+			{
+				ResourceName:      "vault_gcp_secret_backend.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestGCPSecretBackend_identityToken(t *testing.T) {
+	testAccPluginIdentityPreCheck(t)
+
+	path := acctest.RandomWithPrefix("gcp")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		CheckDestroy: testGCPSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testGCPSecretBackendConfig_identityToken(path, "test-audience", 600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "service_account_email", "vault@example.iam.gserviceaccount.com"),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "identity_token_key", "default"),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "identity_token_audience", "test-audience"),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "identity_token_ttl", "600"),
+					testPluginIdentityCheckAccessor("vault_gcp_secret_backend.test"),
+				),
+			},
+			{
+				Config: testGCPSecretBackendConfig_identityToken(path, "updated-audience", 1200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "identity_token_audience", "updated-audience"),
+					resource.TestCheckResourceAttr("vault_gcp_secret_backend.test", "identity_token_ttl", "1200"),
+				),
+			},
+			{
+				ResourceName:      "vault_gcp_secret_backend.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testGCPSecretBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_gcp_secret_backend" {
+			continue
+		}
+		if _, ok := mounts[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("GCP secret backend %q still mounted", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testGCPSecretBackendConfig(path, description string, defaultTTL int) string {
+	return fmt.Sprintf(`
+resource "vault_gcp_secret_backend" "test" {
+	path = "%s"
+	description = "%s"
+	default_lease_ttl_seconds = %d
+	max_lease_ttl_seconds = 86400
+}
+`, path, description, defaultTTL)
+}
+
+func testGCPSecretBackendConfig_identityToken(path, audience string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_gcp_secret_backend" "test" {
+	path = "%s"
+	service_account_email = "vault@example.iam.gserviceaccount.com"
+	identity_token_key = "default"
+	identity_token_audience = "%s"
+	identity_token_ttl = %d
+}
+`, path, audience, ttl)
+}
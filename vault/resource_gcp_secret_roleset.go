@@ -0,0 +1,228 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var gcpSecretRolesetFromPathRegex = regexp.MustCompile("^(.+)/roleset/([^/]+)$")
+
+func gcpSecretRolesetResource() *schema.Resource {
+	return &schema.Resource{
+		Create: gcpSecretRolesetWrite,
+		Update: gcpSecretRolesetWrite,
+		Delete: gcpSecretRolesetDelete,
+		Read:   gcpSecretRolesetRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "gcp",
+				Description: "Path of the GCP secret backend the roleset belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"roleset": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the roleset.",
+			},
+
+			"secret_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "access_token",
+				Description: "Type of the credentials issued, access_token or service_account_key.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if value := v.(string); value != "access_token" && value != "service_account_key" {
+						errs = append(errs, fmt.Errorf("%s must be access_token or service_account_key, got %q", k, value))
+					}
+					return
+				},
+			},
+
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "GCP project the service account of the roleset is created in.",
+			},
+
+			"token_scopes": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "OAuth scopes of the access tokens. Required for access_token rolesets.",
+			},
+
+			"binding": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "IAM roles granted to the service account of the roleset on a GCP resource.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the GCP resource, e.g. //cloudresourcemanager.googleapis.com/projects/my-project.",
+						},
+						"roles": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "IAM roles granted on the resource.",
+						},
+					},
+				},
+			},
+
+			"service_account_email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Email of the service account Vault created for the roleset.",
+			},
+		},
+	}
+}
+
+func gcpSecretRolesetPath(backend, roleset string) string {
+	return strings.Trim(backend, "/") + "/roleset/" + strings.Trim(roleset, "/")
+}
+
+// gcpSecretRolesetBindings encodes binding blocks into the bindings of a
+// roleset. Vault takes them in HCL, of which JSON is a subset, as resource
+// blocks with the list of roles granted on each resource.
+func gcpSecretRolesetBindings(bindings []interface{}) (string, error) {
+	resources := map[string]interface{}{}
+	for _, raw := range bindings {
+		binding := raw.(map[string]interface{})
+		roles := toStringArray(binding["roles"].(*schema.Set).List())
+		sort.Strings(roles)
+		resources[binding["resource"].(string)] = map[string]interface{}{
+			"roles": roles,
+		}
+	}
+	encoded, err := json.Marshal(map[string]interface{}{"resource": resources})
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// gcpSecretRolesetBindingsFromResponse converts the bindings of a roleset,
+// returned as the roles granted keyed by resource, into binding blocks.
+func gcpSecretRolesetBindingsFromResponse(raw interface{}) []map[string]interface{} {
+	bindings, _ := raw.(map[string]interface{})
+	resources := make([]string, 0, len(bindings))
+	for resource := range bindings {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	result := make([]map[string]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, map[string]interface{}{
+			"resource": resource,
+			"roles":    flattenStringList(bindings[resource]),
+		})
+	}
+	return result
+}
+
+func gcpSecretRolesetWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := gcpSecretRolesetPath(d.Get("backend").(string), d.Get("roleset").(string))
+
+	bindings, err := gcpSecretRolesetBindings(d.Get("binding").(*schema.Set).List())
+	if err != nil {
+		return fmt.Errorf("error encoding bindings of GCP roleset %q: %s", path, err)
+	}
+
+	data := map[string]interface{}{
+		"secret_type": d.Get("secret_type").(string),
+		"project":     d.Get("project").(string),
+		"bindings":    bindings,
+	}
+	if d.Get("secret_type").(string) == "access_token" {
+		data["token_scopes"] = d.Get("token_scopes").(*schema.Set).List()
+	}
+
+	// Vault creates or updates the service account and its IAM policies on
+	// every write, so this can take a while.
+	log.Printf("[DEBUG] Writing GCP roleset %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing GCP roleset %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return gcpSecretRolesetRead(d, meta)
+}
+
+func gcpSecretRolesetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := gcpSecretRolesetFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid GCP roleset ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading GCP roleset %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading GCP roleset %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] GCP roleset %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("roleset", res[2])
+	d.Set("secret_type", secret.Data["secret_type"])
+	d.Set("project", secret.Data["project"])
+	d.Set("service_account_email", secret.Data["service_account_email"])
+	if err := d.Set("token_scopes", flattenStringList(secret.Data["token_scopes"])); err != nil {
+		return fmt.Errorf("error setting token_scopes of GCP roleset %q: %s", path, err)
+	}
+	if err := d.Set("binding", gcpSecretRolesetBindingsFromResponse(secret.Data["bindings"])); err != nil {
+		return fmt.Errorf("error setting binding of GCP roleset %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func gcpSecretRolesetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting GCP roleset %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting GCP roleset %q: %s", path, err)
+	}
+
+	return nil
+}
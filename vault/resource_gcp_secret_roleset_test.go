@@ -0,0 +1,127 @@
+package vault
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestGCPSecretRolesetBindings(t *testing.T) {
+	bindings := []interface{}{
+		map[string]interface{}{
+			"resource": "//cloudresourcemanager.googleapis.com/projects/test",
+			"roles":    schema.NewSet(schema.HashString, []interface{}{"roles/viewer", "roles/browser"}),
+		},
+	}
+	expected := `{"resource":{"//cloudresourcemanager.googleapis.com/projects/test":{"roles":["roles/browser","roles/viewer"]}}}`
+	got, err := gcpSecretRolesetBindings(bindings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Errorf("gcpSecretRolesetBindings() = %s, expected %s", got, expected)
+	}
+}
+
+func TestGCPSecretRolesetBindingsFromResponse(t *testing.T) {
+	raw := map[string]interface{}{
+		"//storage.googleapis.com/buckets/logs":               []interface{}{"roles/storage.objectViewer"},
+		"//cloudresourcemanager.googleapis.com/projects/test": []interface{}{"roles/viewer", "roles/browser"},
+	}
+	expected := []map[string]interface{}{
+		{"resource": "//cloudresourcemanager.googleapis.com/projects/test", "roles": []string{"roles/viewer", "roles/browser"}},
+		{"resource": "//storage.googleapis.com/buckets/logs", "roles": []string{"roles/storage.objectViewer"}},
+	}
+	if got := gcpSecretRolesetBindingsFromResponse(raw); !reflect.DeepEqual(got, expected) {
+		t.Errorf("gcpSecretRolesetBindingsFromResponse() = %v, expected %v", got, expected)
+	}
+}
+
+func TestGCPSecretRoleset(t *testing.T) {
+	// Vault creates a service account in the project when the roleset is
+	// written, so this needs a real project and credentials allowed to
+	// manage its service accounts and IAM policy.
+	project := os.Getenv("GOOGLE_PROJECT")
+	if project == "" {
+		t.Skip("GOOGLE_PROJECT not set")
+	}
+	credentials, err := ioutil.ReadFile(os.Getenv("GOOGLE_CREDENTIALS_FILE"))
+	if err != nil {
+		t.Skipf("GOOGLE_CREDENTIALS_FILE can't be read: %s", err)
+	}
+
+	backend := acctest.RandomWithPrefix("gcp")
+	roleset := acctest.RandomWithPrefix("roleset")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testGCPSecretRolesetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testGCPSecretRolesetConfig(backend, string(credentials), roleset, project, `["roles/viewer"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_gcp_secret_roleset.test", "roleset", roleset),
+					resource.TestCheckResourceAttr("vault_gcp_secret_roleset.test", "secret_type", "access_token"),
+					resource.TestCheckResourceAttr("vault_gcp_secret_roleset.test", "binding.#", "1"),
+					resource.TestCheckResourceAttrSet("vault_gcp_secret_roleset.test", "service_account_email"),
+				),
+			},
+			{
+				Config: testGCPSecretRolesetConfig(backend, string(credentials), roleset, project, `["roles/viewer", "roles/browser"]`),
+				Check:  resource.TestCheckResourceAttr("vault_gcp_secret_roleset.test", "binding.#", "1"),
+			},
+			{
+				ResourceName:      "vault_gcp_secret_roleset.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testGCPSecretRolesetDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_gcp_secret_roleset" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("GCP roleset %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testGCPSecretRolesetConfig(backend, credentials, roleset, project, roles string) string {
+	return fmt.Sprintf(`
+resource "vault_gcp_secret_backend" "test" {
+	path = "%s"
+	credentials = %q
+}
+
+resource "vault_gcp_secret_roleset" "test" {
+	backend = "${vault_gcp_secret_backend.test.path}"
+	roleset = "%s"
+	project = "%s"
+	token_scopes = ["https://www.googleapis.com/auth/cloud-platform"]
+
+	binding {
+		resource = "//cloudresourcemanager.googleapis.com/projects/%s"
+		roles = %s
+	}
+}
+`, backend, credentials, roleset, project, project, roles)
+}
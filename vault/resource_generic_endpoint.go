@@ -0,0 +1,298 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func genericEndpointResource() *schema.Resource {
+	return &schema.Resource{
+		Create: genericEndpointWrite,
+		Update: genericEndpointWrite,
+		Delete: genericEndpointDelete,
+		Read:   genericEndpointRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Full path of the Vault endpoint to write to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Namespace of the endpoint, relative to the namespace of the provider.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"data_json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				StateFunc:    NormalizeDataJSON,
+				ValidateFunc: ValidateDataJSON,
+				Description:  "JSON-encoded data to write to the endpoint.",
+				// As in vault_generic_secret, with store_hash_only the
+				// state holds the hash of the data instead.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("store_hash_only").(bool) && new != "" && old == hashDataJSON(new)
+				},
+			},
+
+			"store_hash_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Store only a SHA256 hash of data_json in the state instead of the data, which is then not compared with Vault.",
+			},
+
+			"disable_read": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't read the endpoint back, for endpoints that can't be read.",
+			},
+
+			"disable_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't delete the endpoint on destroy, for endpoints that can't be deleted.",
+			},
+
+			"max_retries": maxRetriesSchema(),
+
+			"retry_on": retryOnSchema(),
+
+			"wrapping_ttl": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Wrap the response of the write in a token valid for this duration, e.g. 60s.",
+			},
+
+			"wrapping_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token wrapping the response of the last write, when wrapping_ttl is set.",
+			},
+
+			"ignore_absent_fields": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Ignore fields returned by Vault that are not set in data_json when looking for drift.",
+			},
+
+			"write_fields": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"wrapping_ttl"},
+				Description:   "Top-level fields of the response of the write to store in write_data and write_data_json.",
+			},
+
+			"write_data": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Fields of the response of the last write listed in write_fields, non-string values encoded as JSON.",
+			},
+
+			"write_data_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "JSON-encoded fields of the response of the last write listed in write_fields.",
+			},
+		},
+	}
+}
+
+func genericEndpointWrite(d *schema.ResourceData, meta interface{}) error {
+	client, err := resourceRetryClient(d, meta.(*api.Client))
+	if err != nil {
+		return err
+	}
+
+	path := strings.Trim(d.Get("path").(string), "/")
+	namespace := strings.Trim(d.Get("namespace").(string), "/")
+	if namespace != "" && strings.HasPrefix(path, namespace+"/") {
+		return fmt.Errorf("path %q already starts with namespace %q; the path is relative to the namespace and must not repeat it", path, namespace)
+	}
+	apiPath := namespacedPath(namespace, path)
+
+	data, err := decodeDataJSON(d.Get("data_json").(string))
+	if err != nil {
+		return fmt.Errorf("data_json syntax error: %s", err)
+	}
+
+	if wrapTTL, ok := d.GetOk("wrapping_ttl"); ok {
+		log.Printf("[DEBUG] Writing generic endpoint %q to Vault with a wrapped response", path)
+		resp, err := writeWrapped(client, apiPath, data, wrapTTL.(string))
+		if err != nil {
+			return fmt.Errorf("error writing to %q: %s", path, err)
+		}
+		// Endpoints returning no data have nothing to wrap.
+		if resp == nil || resp.WrapInfo == nil {
+			return fmt.Errorf("no wrapped response writing to %q", path)
+		}
+		d.Set("wrapping_token", resp.WrapInfo.Token)
+	} else {
+		log.Printf("[DEBUG] Writing generic endpoint %q to Vault", path)
+		resp, err := client.Logical().Write(apiPath, data)
+		if err != nil {
+			return fmt.Errorf("error writing to %q: %s", path, err)
+		}
+		d.Set("wrapping_token", "")
+
+		var respData map[string]interface{}
+		if resp != nil {
+			respData = resp.Data
+		}
+		writeData, writeDataStrings, err := genericEndpointWriteFields(toStringArray(d.Get("write_fields").([]interface{})), respData)
+		if err != nil {
+			return fmt.Errorf("error reading response of the write to %q: %s", path, err)
+		}
+		writeDataJSON, err := json.Marshal(writeData)
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON for the response of the write to %q: %s", path, err)
+		}
+		d.Set("write_data", writeDataStrings)
+		d.Set("write_data_json", string(writeDataJSON))
+	}
+
+	d.SetId(path)
+
+	if d.Get("store_hash_only").(bool) {
+		d.Set("data_json", hashDataJSON(NormalizeDataJSON(d.Get("data_json").(string))))
+	}
+
+	return genericEndpointRead(d, meta)
+}
+
+func genericEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := resourceRetryClient(d, meta.(*api.Client))
+	if err != nil {
+		return err
+	}
+
+	path := d.Id()
+	d.Set("path", path)
+
+	if d.Get("disable_read").(bool) {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Reading generic endpoint %q from Vault", path)
+	secret, err := client.Logical().Read(namespacedPath(d.Get("namespace").(string), path))
+	if err != nil {
+		return fmt.Errorf("error reading %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Generic endpoint %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	// Only the hash of the written data is known, so there is nothing to
+	// compare the data read with.
+	if d.Get("store_hash_only").(bool) {
+		return nil
+	}
+
+	data := secret.Data
+	// On import there is no data_json to compare with yet, so everything
+	// Vault returns is kept.
+	if written := d.Get("data_json").(string); d.Get("ignore_absent_fields").(bool) && written != "" {
+		writtenData, err := decodeDataJSON(written)
+		if err != nil {
+			return fmt.Errorf("error decoding data_json of %q: %s", path, err)
+		}
+		data = genericEndpointPresentFields(writtenData, data)
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON for %q: %s", path, err)
+	}
+	d.Set("data_json", string(jsonData))
+
+	return nil
+}
+
+// genericEndpointPresentFields returns the top-level fields of read that
+// are also set in written. Config endpoints usually return many defaults
+// that were never written, and comparing them would report drift forever.
+func genericEndpointPresentFields(written, read map[string]interface{}) map[string]interface{} {
+	present := make(map[string]interface{}, len(written))
+	for k, v := range read {
+		if _, ok := written[k]; ok {
+			present[k] = v
+		}
+	}
+	return present
+}
+
+// genericEndpointWriteFields returns the fields of the response of a write
+// that are listed in fields, both as they are and as strings for the
+// write_data map, with non-string values encoded as JSON. Fields missing
+// from the response are skipped.
+func genericEndpointWriteFields(fields []string, data map[string]interface{}) (map[string]interface{}, map[string]string, error) {
+	values := make(map[string]interface{}, len(fields))
+	strs := make(map[string]string, len(fields))
+	for _, k := range fields {
+		v, ok := data[k]
+		if !ok {
+			continue
+		}
+		values[k] = v
+		if s, ok := v.(string); ok {
+			strs[k] = s
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error marshaling JSON for field %q: %s", k, err)
+		}
+		strs[k] = string(b)
+	}
+	return values, strs, nil
+}
+
+func genericEndpointDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := resourceRetryClient(d, meta.(*api.Client))
+	if err != nil {
+		return err
+	}
+
+	path := d.Id()
+
+	if d.Get("disable_delete").(bool) {
+		log.Printf("[DEBUG] Not deleting generic endpoint %q, disable_delete is set", path)
+		return nil
+	}
+
+	log.Printf("[DEBUG] Deleting generic endpoint %q from Vault", path)
+	if _, err := client.Logical().Delete(namespacedPath(d.Get("namespace").(string), path)); err != nil {
+		return fmt.Errorf("error deleting %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestGenericEndpointPresentFields(t *testing.T) {
+	written := map[string]interface{}{
+		"ttl":     "1h",
+		"enabled": true,
+	}
+	read := map[string]interface{}{
+		"ttl":       "3600",
+		"enabled":   true,
+		"max_ttl":   "0",
+		"algorithm": "sha2-256",
+	}
+	expected := map[string]interface{}{
+		"ttl":     "3600",
+		"enabled": true,
+	}
+	if got := genericEndpointPresentFields(written, read); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestGenericEndpointWriteFields(t *testing.T) {
+	data := map[string]interface{}{
+		"serial_number": "1a:2b",
+		"expiration":    json.Number("1600000000"),
+		"ca_chain":      []interface{}{"a", "b"},
+		"private_key":   "secret",
+	}
+	values, strs, err := genericEndpointWriteFields([]string{"serial_number", "expiration", "ca_chain", "missing"}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedValues := map[string]interface{}{
+		"serial_number": "1a:2b",
+		"expiration":    json.Number("1600000000"),
+		"ca_chain":      []interface{}{"a", "b"},
+	}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("expected %v, got %v", expectedValues, values)
+	}
+	expectedStrs := map[string]string{
+		"serial_number": "1a:2b",
+		"expiration":    "1600000000",
+		"ca_chain":      `["a","b"]`,
+	}
+	if !reflect.DeepEqual(strs, expectedStrs) {
+		t.Errorf("expected %v, got %v", expectedStrs, strs)
+	}
+}
+
+func TestGenericEndpoint_writeFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "vault_generic_endpoint" "test" {
+	path = "sys/tools/random"
+	data_json = "{\"bytes\": 16}"
+	disable_read = true
+	disable_delete = true
+	write_fields = ["random_bytes"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vault_generic_endpoint.test", "write_data.random_bytes"),
+					resource.TestMatchResourceAttr("vault_generic_endpoint.test", "write_data_json", regexp.MustCompile(`^\{"random_bytes":".+"\}$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestGenericEndpoint_ignoreAbsentFields(t *testing.T) {
+	backend := acctest.RandomWithPrefix("approle")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testGenericEndpointConfig(backend, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_generic_endpoint.test", "data_json", `{"token_ttl":300}`),
+				),
+			},
+			{
+				// Without ignore_absent_fields, the defaults returned by
+				// Vault show up as drift.
+				Config:             testGenericEndpointConfig(backend, false),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testGenericEndpointConfig(backend string, ignoreAbsent bool) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "approle" {
+	path = "%s"
+	type = "approle"
+}
+
+resource "vault_generic_endpoint" "test" {
+	path = "auth/${vault_auth_backend.approle.path}/role/test"
+	data_json = "{\"token_ttl\": 300}"
+	ignore_absent_fields = %t
+}
+`, backend, ignoreAbsent)
+}
+
+func TestGenericEndpoint_wrappingTTL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "vault_generic_endpoint" "test" {
+	path = "auth/token/create"
+	data_json = "{\"policies\": [\"default\"], \"ttl\": \"10m\"}"
+	disable_read = true
+	disable_delete = true
+	wrapping_ttl = "60s"
+}
+`,
+				Check: func(s *terraform.State) error {
+					rs := s.RootModule().Resources["vault_generic_endpoint.test"]
+					token := rs.Primary.Attributes["wrapping_token"]
+					if token == "" {
+						return fmt.Errorf("no wrapping_token set")
+					}
+
+					client := testProvider.Meta().(*api.Client)
+					secret, err := client.Logical().Unwrap(token)
+					if err != nil {
+						return fmt.Errorf("error unwrapping %q: %s", token, err)
+					}
+					if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+						return fmt.Errorf("no token wrapped in %q: %v", token, secret)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestGenericEndpoint_storeHashOnly(t *testing.T) {
+	backend := acctest.RandomWithPrefix("approle")
+	config := func(secretIDTTL int) string {
+		return fmt.Sprintf(`
+resource "vault_auth_backend" "approle" {
+	path = "%s"
+	type = "approle"
+}
+
+resource "vault_generic_endpoint" "test" {
+	path = "auth/${vault_auth_backend.approle.path}/role/test"
+	data_json = "{\"secret_id_ttl\": %d}"
+	store_hash_only = true
+}
+`, backend, secretIDTTL)
+	}
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: config(300),
+				Check:  resource.TestCheckResourceAttr("vault_generic_endpoint.test", "data_json", hashDataJSON(`{"secret_id_ttl":300}`)),
+			},
+			{
+				Config:   config(300),
+				PlanOnly: true,
+			},
+			{
+				Config: config(600),
+				Check:  resource.TestCheckResourceAttr("vault_generic_endpoint.test", "data_json", hashDataJSON(`{"secret_id_ttl":600}`)),
+			},
+		},
+	})
+}
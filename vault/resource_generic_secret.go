@@ -1,10 +1,16 @@
 package vault
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"github.com/hashicorp/vault/api"
@@ -16,6 +22,9 @@ func genericSecretResource() *schema.Resource {
 		Update: genericSecretResourceWrite,
 		Delete: genericSecretResourceDelete,
 		Read:   genericSecretResourceRead,
+		Importer: &schema.ResourceImporter{
+			State: genericSecretResourceImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"path": &schema.Schema{
@@ -25,18 +34,78 @@ func genericSecretResource() *schema.Resource {
 				Description: "Full path where the generic secret will be written.",
 			},
 
+			"namespace": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Namespace of the secret, relative to the namespace of the provider.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
 			// Data is passed as JSON so that an arbitrary structure is
 			// possible, rather than forcing e.g. all values to be strings.
 			"data_json": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
 				Description: "JSON-encoded secret data to write.",
 				// We rebuild the attached JSON string to a simple singleline
 				// string. This makes terraform not want to change when an extra
 				// space is included in the JSON string. It is also necesarry
 				// when allow_read is true for comparing values.
-				StateFunc:    NormalizeDataJSON,
-				ValidateFunc: ValidateDataJSON,
+				StateFunc:     NormalizeDataJSON,
+				ValidateFunc:  ValidateDataJSON,
+				ConflictsWith: []string{"data", "data_json_template"},
+				// With store_hash_only, the state holds the hash of the data
+				// instead, which is compared with the hash of the config.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("store_hash_only").(bool) && new != "" && old == hashDataJSON(new)
+				},
+			},
+
+			"store_hash_only": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Store only a SHA256 hash of data_json in the state instead of the data.",
+				ConflictsWith: []string{"data", "data_json_template"},
+			},
+
+			"data": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Secret data to write, in the format given by data_format.",
+				ConflictsWith: []string{"data_json", "data_json_template"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return secretDataEquivalent(d.Get("data_format").(string), old, new)
+				},
+			},
+
+			"data_format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      secretDataFormatJSON,
+				Description:  "Format of the data attribute: json, kv or yaml.",
+				ValidateFunc: validateSecretDataFormat,
+			},
+
+			"data_json_template": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Go text/template rendering the JSON-encoded secret data to write.",
+				ConflictsWith: []string{"data_json", "data"},
+			},
+
+			"template_vars": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Variables available to data_json_template.",
 			},
 
 			"allow_read": &schema.Schema{
@@ -45,14 +114,205 @@ func genericSecretResource() *schema.Resource {
 				Default:     false,
 				Description: "True if the provided token is allowed to read the secret from vault",
 			},
+
+			"cache_read": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Read the secret only once after writing it, instead of on every refresh.",
+			},
+
+			"renew_lease": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Renew the lease of the cached read on refresh, when cache_read is set.",
+			},
+
+			"lease_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Lease identifier of the last read.",
+			},
+
+			"lease_duration": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Lease duration in seconds of the last read, relative to lease_start_time.",
+			},
+
+			"lease_start_time": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the secret was last read, using the clock of the system where Terraform was running.",
+			},
+
+			"lease_renewable": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the duration of the lease of the last read can be extended through renewal.",
+			},
+
+			"value": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Map of strings read from Vault, when allow_read is set.",
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "On create, import the secret if the path already holds data instead of overwriting it.",
+			},
+
+			"expected_data_json": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "JSON-encoded data the secret must currently hold for it to be written.",
+				StateFunc:    NormalizeDataJSON,
+				ValidateFunc: ValidateDataJSON,
+			},
+
+			"data_json_env": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of an environment variable with JSON-encoded data merged into the secret, and kept out of the state.",
+			},
+
+			"data_json_env_keys": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Keys written from data_json_env, which are left out of data_json.",
+			},
+
+			"ignore_fields": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Keys of the secret managed outside of Terraform, which are neither compared nor overwritten.",
+			},
+
+			"ignore_absent_fields": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Treat the keys of the secret that are not in the configured data like ignore_fields.",
+				ConflictsWith: []string{"store_hash_only"},
+			},
+
+			"rotation_period": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "Seconds after which the secret is written again, never if 0.",
+				ConflictsWith: []string{"adopt_existing"},
+			},
+
+			"rotation_triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary values that write the secret again when changed.",
+			},
+
+			"rotated_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the secret was last written, in RFC 3339 format.",
+			},
+
+			"cas": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Write secrets in KV version 2 mounts with check-and-set, failing if they changed since last written or read.",
+				ConflictsWith: []string{"rotation_period"},
+			},
+
+			"max_retries": maxRetriesSchema(),
+
+			"retry_on": retryOnSchema(),
+
+			"version": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current version of the secret, when written to a KV version 2 mount.",
+			},
+
+			"restore_version": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "Version of a secret in a KV version 2 mount whose data is written as the current version.",
+				ConflictsWith: []string{"data_json", "data", "data_json_template", "data_json_env"},
+			},
+
+			"delete_all_versions": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "On destroy, delete the metadata and all versions of secrets in KV version 2 mounts, instead of only the latest version.",
+			},
+
+			"destroy_versions": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Versions of secrets in KV version 2 mounts permanently destroyed on destroy instead of deleting the latest version, when delete_all_versions is false.",
+			},
+
+			"read_query": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Query parameters added to the requests reading the secret, e.g. version.",
+			},
+
+			"read_retry": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry the read after a write until the secret is readable, e.g. on performance standbys.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timeout": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     30,
+							Description: "Seconds to keep retrying before giving up.",
+						},
+						"key": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Only consider the secret readable once this key is present.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+// decodeDataJSON decodes a JSON object, keeping numbers as json.Number so
+// that large integers and precise decimals aren't rounded through float64.
+func decodeDataJSON(dataJSON string) (map[string]interface{}, error) {
+	dataMap := map[string]interface{}{}
+	dec := json.NewDecoder(strings.NewReader(dataJSON))
+	dec.UseNumber()
+	if err := dec.Decode(&dataMap); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the top-level JSON object")
+	}
+	return dataMap, nil
+}
+
 func ValidateDataJSON(configI interface{}, k string) ([]string, []error) {
 	dataJSON := configI.(string)
-	dataMap := map[string]interface{}{}
-	err := json.Unmarshal([]byte(dataJSON), &dataMap)
+	_, err := decodeDataJSON(dataJSON)
 	if err != nil {
 		return nil, []error{err}
 	}
@@ -62,8 +322,7 @@ func ValidateDataJSON(configI interface{}, k string) ([]string, []error) {
 func NormalizeDataJSON(configI interface{}) string {
 	dataJSON := configI.(string)
 
-	dataMap := map[string]interface{}{}
-	err := json.Unmarshal([]byte(dataJSON), &dataMap)
+	dataMap, err := decodeDataJSON(dataJSON)
 	if err != nil {
 		// The validate function should've taken care of this.
 		log.Printf("[ERROR] Invalid JSON data in vault_generic_secret: %s", err)
@@ -81,34 +340,327 @@ func NormalizeDataJSON(configI interface{}) string {
 }
 
 func genericSecretResourceWrite(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*api.Client)
+	client, err := resourceRetryClient(d, meta.(*api.Client))
+	if err != nil {
+		return err
+	}
 
 	path := d.Get("path").(string)
 
+	namespace := strings.Trim(d.Get("namespace").(string), "/")
+	if namespace != "" && strings.HasPrefix(strings.TrimLeft(path, "/"), namespace+"/") {
+		return fmt.Errorf("path %q already starts with namespace %q; the path is relative to the namespace and must not repeat it", path, namespace)
+	}
+
+	mount := genericSecretKVMount(client, namespace, path)
+	apiPath := namespacedPath(namespace, mount.dataPath(path))
+
 	var data map[string]interface{}
-	err := json.Unmarshal([]byte(d.Get("data_json").(string)), &data)
-	if err != nil {
-		return fmt.Errorf("data_json %#v syntax error: %s", d.Get("data_json"), err)
+	if version := d.Get("restore_version").(int); version > 0 {
+		data, err = genericSecretVersionData(client, mount, apiPath, version)
+		if err != nil {
+			return err
+		}
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("error encoding data as JSON: %s", err)
+		}
+		d.Set("data_json", string(dataJSON))
+	} else {
+		data, err = genericSecretResourceData(d)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The data from the environment is only merged once data_json has been
+	// set, so that it never ends up in the state.
+	envKeys := []string{}
+	if name, ok := d.GetOk("data_json_env"); ok {
+		envData, err := genericSecretEnvData(name.(string))
+		if err != nil {
+			return err
+		}
+		for k, v := range envData {
+			if _, ok := data[k]; ok {
+				return fmt.Errorf("key %q of environment variable %q is also set in the secret data", k, name)
+			}
+			data[k] = v
+			envKeys = append(envKeys, k)
+		}
+	}
+	d.Set("data_json_env_keys", envKeys)
+
+	if err := genericSecretKeepUnmanaged(d, client, mount, apiPath, data); err != nil {
+		return err
+	}
+
+	if d.IsNewResource() && d.Get("adopt_existing").(bool) {
+		adopted, err := genericSecretAdoptExisting(d, meta, client, mount, apiPath)
+		if adopted || err != nil {
+			return err
+		}
+	}
+
+	if expected, ok := d.GetOk("expected_data_json"); ok {
+		if err := genericSecretCheckExpected(client, mount, apiPath, expected.(string)); err != nil {
+			return err
+		}
+	}
+
+	body := kvWriteData(mount, data)
+	cas := d.Get("cas").(bool)
+	if cas {
+		if mount.Version != 2 {
+			return fmt.Errorf("cas is only supported for secrets in KV version 2 mounts, %q is not in one", path)
+		}
+		// New secrets must not exist yet, existing ones must still be at
+		// the version last written or read.
+		version := 0
+		if !d.IsNewResource() {
+			version = d.Get("version").(int)
+		}
+		body["options"] = map[string]interface{}{
+			"cas": version,
+		}
 	}
 
 	log.Printf("[DEBUG] Writing generic Vault secret to %s", path)
-	_, err = client.Logical().Write(path, data)
+	resp, err := client.Logical().Write(apiPath, body)
 	if err != nil {
+		if cas && strings.Contains(err.Error(), "check-and-set") {
+			return fmt.Errorf("secret %q was modified outside of Terraform since version %d, refresh it with allow_read to plan over the changes: %s", path, d.Get("version").(int), err)
+		}
 		return fmt.Errorf("error writing to Vault: %s", err)
 	}
 
 	d.SetId(path)
+	d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+
+	version := 0
+	if mount.Version == 2 && resp != nil {
+		version = intFromResponse(resp.Data["version"])
+	}
+	d.Set("version", version)
+
+	if d.Get("store_hash_only").(bool) {
+		d.Set("data_json", hashDataJSON(NormalizeDataJSON(d.Get("data_json").(string))))
+	}
+
+	// When retries are configured, don't report success until the
+	// written secret can actually be read back. Cached reads are taken
+	// right after writing, so that they match what was written.
+	if d.Get("allow_read").(bool) && (len(d.Get("read_retry").([]interface{})) > 0 || d.Get("cache_read").(bool)) {
+		d.Set("lease_start_time", "")
+		return genericSecretRead(d, meta, d.Get("read_retry").([]interface{}))
+	}
 
 	return nil
 }
 
+// genericSecretKeepUnmanaged adds to data the keys of the secret at
+// apiPath that are managed outside of Terraform, with their current values,
+// so that writing data doesn't remove them. Keys that were in the
+// previously written data are managed by Terraform and removed when no
+// longer configured.
+func genericSecretKeepUnmanaged(d *schema.ResourceData, client *api.Client, mount *kvMount, apiPath string, data map[string]interface{}) error {
+	path := d.Get("path").(string)
+	ignored := d.Get("ignore_fields").(*schema.Set)
+	ignoreAbsent := d.Get("ignore_absent_fields").(bool)
+	if ignored.Len() == 0 && !ignoreAbsent {
+		return nil
+	}
+
+	for _, k := range ignored.List() {
+		if _, ok := data[k.(string)]; ok {
+			return fmt.Errorf("key %q of ignore_fields is also set in the secret data", k)
+		}
+	}
+
+	previous := map[string]interface{}{}
+	if old, _ := d.GetChange("data_json"); old.(string) != "" && !d.IsNewResource() {
+		if oldData, err := decodeDataJSON(old.(string)); err == nil {
+			previous = oldData
+		}
+	}
+
+	log.Printf("[DEBUG] Reading %s from Vault to keep the keys managed outside of Terraform", path)
+	secret, err := client.Logical().Read(apiPath)
+	if err != nil {
+		return fmt.Errorf("error reading %q from Vault: %s", path, err)
+	}
+	current, _ := kvSecretData(mount, secret)
+	for k, v := range current {
+		if _, ok := data[k]; ok {
+			continue
+		}
+		if _, ok := previous[k]; ok && !ignored.Contains(k) {
+			continue
+		}
+		if ignored.Contains(k) || ignoreAbsent {
+			data[k] = v
+		}
+	}
+
+	return nil
+}
+
+// genericSecretAdoptExisting takes over the secret at the configured path
+// when it already holds data, reading it into the state instead of
+// writing the configured data, so that the next plan shows how it differs.
+// It reports whether the secret was adopted. Checking and writing are
+// separate requests, so a writer racing in between is still overwritten.
+func genericSecretAdoptExisting(d *schema.ResourceData, meta interface{}, client *api.Client, mount *kvMount, apiPath string) (bool, error) {
+	path := d.Get("path").(string)
+
+	log.Printf("[DEBUG] Checking for an existing secret at %s to adopt", path)
+	existing, err := client.Logical().Read(apiPath)
+	if err != nil {
+		return false, fmt.Errorf("error checking for an existing secret at %q to adopt: %s", path, err)
+	}
+	if existingData, _ := kvSecretData(mount, existing); len(existingData) == 0 {
+		return false, nil
+	}
+	if !d.Get("allow_read").(bool) {
+		return false, fmt.Errorf("secret %q already exists; adopt_existing can only import it when allow_read is set", path)
+	}
+
+	log.Printf("[DEBUG] Adopting existing secret at %s", path)
+	d.SetId(path)
+	d.Set("lease_start_time", "")
+	return true, genericSecretResourceRead(d, meta)
+}
+
+// hashDataJSON returns the hash stored in place of normalized JSON data
+// when store_hash_only is set.
+func hashDataJSON(dataJSON string) string {
+	sum := sha256.Sum256([]byte(dataJSON))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// genericSecretCheckExpected fails unless the secret at path currently
+// holds the expected JSON-encoded data, treating a missing secret as an
+// empty object. The check and the following write are separate requests,
+// so this guards against overwriting changes made outside of Terraform
+// since they were last seen, not against concurrent writers.
+func genericSecretCheckExpected(client *api.Client, mount *kvMount, path, expected string) error {
+	expectedData, err := decodeDataJSON(expected)
+	if err != nil {
+		return fmt.Errorf("error decoding expected_data_json: %s", err)
+	}
+
+	log.Printf("[DEBUG] Reading %s from Vault to compare with the expected data", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading %q from Vault: %s", path, err)
+	}
+	currentData := map[string]interface{}{}
+	if data, _ := kvSecretData(mount, secret); data != nil {
+		currentData = data
+	}
+
+	if !secretDataEqual(currentData, expectedData) {
+		return fmt.Errorf("refusing to write %q: the secret doesn't hold the data given in expected_data_json, "+
+			"it may have been changed outside of Terraform", path)
+	}
+
+	return nil
+}
+
+// secretDataEqual compares secret data by its JSON encoding, which orders
+// keys and keeps json.Number values as given.
+func secretDataEqual(a, b map[string]interface{}) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
+
+// genericSecretEnvData decodes the JSON object held by the named
+// environment variable.
+func genericSecretEnvData(name string) (map[string]interface{}, error) {
+	raw, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %q of data_json_env is not set", name)
+	}
+	data, err := decodeDataJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding JSON from environment variable %q: %s", name, err)
+	}
+	return data, nil
+}
+
+// genericSecretResourceData returns the secret data to write, decoded from
+// either data_json or data.
+func genericSecretResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
+	if raw, ok := d.GetOk("data"); ok {
+		format := d.Get("data_format").(string)
+		data, err := parseSecretData(format, raw.(string))
+		if err != nil {
+			return nil, fmt.Errorf("data is not valid %s: %s", format, err)
+		}
+
+		// data_json always holds the normalized form of the data, so that
+		// it can be referenced the same way regardless of the format used.
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding data as JSON: %s", err)
+		}
+		d.Set("data_json", string(dataJSON))
+
+		return data, nil
+	}
+
+	if raw, ok := d.GetOk("data_json_template"); ok {
+		data, err := renderSecretDataTemplate(raw.(string), d.Get("template_vars").(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("data_json_template: %s", err)
+		}
+
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding data as JSON: %s", err)
+		}
+		d.Set("data_json", string(dataJSON))
+
+		return data, nil
+	}
+
+	if raw, ok := d.GetOk("data_json"); ok {
+		data, err := decodeDataJSON(raw.(string))
+		if err != nil {
+			return nil, fmt.Errorf("data_json syntax error: %s", err)
+		}
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("one of data_json, data or data_json_template must be set")
+}
+
 func genericSecretResourceDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*api.Client)
+	client, err := resourceRetryClient(d, meta.(*api.Client))
+	if err != nil {
+		return err
+	}
 
 	path := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	// Secrets in KV version 2 mounts are deleted along with all of their
+	// versions, unless only some of them are destroyed or the latest one
+	// deleted.
+	mount := genericSecretKVMount(client, namespace, path)
+	if mount.Version == 2 && !d.Get("delete_all_versions").(bool) {
+		return genericSecretDeleteVersions(client, mount, namespace, path, d.Get("destroy_versions").([]interface{}))
+	}
 
 	log.Printf("[DEBUG] Deleting vault_generic_secret from %q", path)
-	_, err := client.Logical().Delete(path)
+	_, err = client.Logical().Delete(namespacedPath(namespace, mount.metadataPath(path)))
 	if err != nil {
 		return fmt.Errorf("error deleting %q from Vault: %q", path, err)
 	}
@@ -116,24 +668,208 @@ func genericSecretResourceDelete(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+// genericSecretDeleteVersions permanently destroys the given versions of a
+// secret in a KV version 2 mount, or soft-deletes its latest version if
+// none are given, so that it can still be undeleted.
+func genericSecretDeleteVersions(client *api.Client, mount *kvMount, namespace, path string, versions []interface{}) error {
+	if len(versions) == 0 {
+		log.Printf("[DEBUG] Deleting the latest version of %q", path)
+		if _, err := client.Logical().Delete(namespacedPath(namespace, mount.dataPath(path))); err != nil {
+			return fmt.Errorf("error deleting the latest version of %q from Vault: %s", path, err)
+		}
+		return nil
+	}
+
+	log.Printf("[DEBUG] Destroying versions %v of %q", versions, path)
+	_, err := client.Logical().Write(namespacedPath(namespace, mount.destroyPath(path)), map[string]interface{}{
+		"versions": versions,
+	})
+	if err != nil {
+		return fmt.Errorf("error destroying versions %v of %q in Vault: %s", versions, path, err)
+	}
+	return nil
+}
+
+// genericSecretResourceImport imports the secret at the path given as ID,
+// reading its data into data_json. Imported secrets are read on refresh,
+// as there would be no way to tell what they hold otherwise.
+func genericSecretResourceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	path := d.Id()
+
+	d.Set("path", path)
+	d.Set("allow_read", true)
+
+	// Defaults aren't applied to imported resources, so the arguments
+	// having one are set to it.
+	d.Set("data_format", secretDataFormatJSON)
+	d.Set("delete_all_versions", true)
+	d.Set("max_retries", -1)
+	for _, k := range []string{"store_hash_only", "cache_read", "renew_lease", "adopt_existing", "ignore_absent_fields", "cas"} {
+		d.Set(k, false)
+	}
+
+	if err := genericSecretResourceRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("no secret found at %q", path)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// genericSecretRotationDue reports whether a secret written at rotatedAt
+// has to be written again after period seconds. Secrets never written by
+// Terraform, e.g. imported ones, aren't due.
+func genericSecretRotationDue(rotatedAt string, period int, now time.Time) bool {
+	if period <= 0 || rotatedAt == "" {
+		return false
+	}
+	written, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false
+	}
+	return !now.Before(written.Add(time.Duration(period) * time.Second))
+}
+
 func genericSecretResourceRead(d *schema.ResourceData, meta interface{}) error {
+	return genericSecretRead(d, meta, nil)
+}
+
+// genericSecretRead reads the secret into d, retrying as configured by
+// retryI. Only the read right after a write is retried: on refresh, a
+// secret that isn't there was deleted outside of Terraform, and is removed
+// from the state right away.
+func genericSecretRead(d *schema.ResourceData, meta interface{}, retryI []interface{}) error {
 	allowed_to_read := d.Get("allow_read").(bool)
 	path := d.Get("path").(string)
 
+	// Like certificates about to expire, secrets due for rotation are
+	// removed from the state, so that they are written again. They are
+	// left in Vault until then.
+	if rotatedAt := d.Get("rotated_at").(string); genericSecretRotationDue(rotatedAt, d.Get("rotation_period").(int), time.Now()) {
+		log.Printf("[WARN] Secret %q was written at %s, removing from state to rotate it", path, rotatedAt)
+		d.SetId("")
+		return nil
+	}
+
 	if allowed_to_read {
-		client := meta.(*api.Client)
+		client, err := resourceRetryClient(d, meta.(*api.Client))
+		if err != nil {
+			return err
+		}
+
+		// Endpoints generating dynamic secrets create a new lease on every
+		// read, so with cache_read only the first one is taken and later
+		// refreshes keep its result.
+		if d.Get("cache_read").(bool) && d.Get("lease_start_time").(string) != "" {
+			log.Printf("[DEBUG] Using cached read of %s", path)
+			if leaseID := d.Get("lease_id").(string); leaseID != "" && d.Get("renew_lease").(bool) && d.Get("lease_renewable").(bool) {
+				log.Printf("[DEBUG] Renewing lease %q", leaseID)
+				renewal, err := client.Sys().Renew(leaseID, 0)
+				if err != nil {
+					log.Printf("[WARN] Error renewing lease %q of %s, it may have expired: %s", leaseID, path, err)
+				} else if renewal != nil {
+					d.Set("lease_duration", renewal.LeaseDuration)
+					d.Set("lease_start_time", time.Now().Format(time.RFC3339))
+				}
+			}
+			d.SetId(path)
+			return nil
+		}
+
+		namespace := d.Get("namespace").(string)
+		mount := genericSecretKVMount(client, namespace, path)
+		apiPath := namespacedPath(namespace, mount.dataPath(path))
 
 		log.Printf("[DEBUG] Reading %s from Vault", path)
-		secret, err := client.Logical().Read(path)
+		secret, err := genericSecretReadWithRetry(client, mount, apiPath, d.Get("read_query").(map[string]interface{}), retryI)
 		if err != nil {
 			return fmt.Errorf("error reading from Vault: %s", err)
 		}
+		data, version := kvSecretData(mount, secret)
+		if secret == nil || (mount.Version == 2 && data == nil) {
+			log.Printf("[WARN] Secret %q not found, removing from state", path)
+			d.SetId("")
+			return nil
+		}
+		d.Set("version", version)
+
+		// Keys written from the environment are known only by name, so
+		// that their values are never copied into the state.
+		secretData := make(map[string]interface{}, len(data))
+		for k, v := range data {
+			secretData[k] = v
+		}
+		envKeys := d.Get("data_json_env_keys").(*schema.Set).List()
+		for _, k := range envKeys {
+			delete(secretData, k.(string))
+		}
+		for _, k := range d.Get("ignore_fields").(*schema.Set).List() {
+			delete(secretData, k.(string))
+		}
+		// As when importing there is nothing written to compare with yet,
+		// everything found is kept.
+		if written := d.Get("data_json").(string); d.Get("ignore_absent_fields").(bool) && written != "" {
+			writtenData, err := decodeDataJSON(written)
+			if err != nil {
+				return fmt.Errorf("error decoding data_json of %q: %s", path, err)
+			}
+			secretData = genericEndpointPresentFields(writtenData, secretData)
+		}
 
-		jsonDataBytes, err := json.Marshal(secret.Data)
+		jsonDataBytes, err := json.Marshal(secretData)
 		if err != nil {
 			return fmt.Errorf("Error marshaling JSON for %q: %s", path, err)
 		}
-		d.Set("data_json", string(jsonDataBytes))
+		if d.Get("store_hash_only").(bool) {
+			// A different hash still shows that the secret drifted,
+			// without the data ever being stored.
+			d.Set("data_json", hashDataJSON(string(jsonDataBytes)))
+		} else {
+			d.Set("data_json", string(jsonDataBytes))
+			d.Set("value", secretDataStringMap(secretData))
+		}
+		d.Set("lease_id", secret.LeaseID)
+		d.Set("lease_duration", secret.LeaseDuration)
+		d.Set("lease_start_time", time.Now().Format(time.RFC3339))
+		d.Set("lease_renewable", secret.Renewable)
+
+		if _, ok := d.GetOk("data"); ok {
+			data, err := formatSecretData(d.Get("data_format").(string), secretData)
+			if err != nil {
+				return fmt.Errorf("Error formatting data for %q: %s", path, err)
+			}
+			d.Set("data", data)
+		}
+
+		// The template can't be recovered from the secret, so drift is
+		// reported by replacing it with the data found in Vault, which
+		// renders as itself. The next plan then shows the difference and
+		// rewrites the secret from the configured template.
+		if tmpl, ok := d.GetOk("data_json_template"); ok {
+			rendered, err := renderSecretDataTemplate(tmpl.(string), d.Get("template_vars").(map[string]interface{}))
+			if err != nil {
+				return fmt.Errorf("data_json_template: %s", err)
+			}
+			renderedBytes, err := json.Marshal(rendered)
+			if err != nil {
+				return fmt.Errorf("Error marshaling JSON for %q: %s", path, err)
+			}
+			if string(renderedBytes) != string(jsonDataBytes) {
+				d.Set("data_json_template", string(jsonDataBytes))
+			}
+		}
+
+		// Likewise, a secret that no longer holds the data of the restored
+		// version is reported as not restored, so that the next plan
+		// restores it again.
+		if restored := d.Get("restore_version").(int); restored > 0 {
+			restoredData, err := genericSecretVersionData(client, mount, apiPath, restored)
+			if err != nil || !secretDataEqual(restoredData, data) {
+				d.Set("restore_version", 0)
+			}
+		}
 	} else {
 		log.Printf("[WARN] vault_generic_secret does not automatically refresh if allow_read is set to false")
 	}
@@ -141,3 +877,87 @@ func genericSecretResourceRead(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(path)
 	return nil
 }
+
+// genericSecretReadWithRetry reads the secret at path. If a read_retry block
+// is configured, reads that return no data, or data without the configured
+// key, are retried until the timeout expires. This covers replicated setups
+// where a secret isn't immediately readable after being written.
+func genericSecretReadWithRetry(client *api.Client, mount *kvMount, path string, query map[string]interface{}, retryI []interface{}) (*api.Secret, error) {
+	if len(retryI) == 0 {
+		return readWithQuery(client, path, query)
+	}
+
+	retry := retryI[0].(map[string]interface{})
+	timeout := time.Duration(retry["timeout"].(int)) * time.Second
+	key := retry["key"].(string)
+
+	var secret *api.Secret
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		var err error
+		secret, err = readWithQuery(client, path, query)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		data, _ := kvSecretData(mount, secret)
+		if len(data) == 0 {
+			log.Printf("[DEBUG] Secret %q not readable yet, retrying", path)
+			return resource.RetryableError(fmt.Errorf("no data found at %q", path))
+		}
+		if _, ok := data[key]; key != "" && !ok {
+			log.Printf("[DEBUG] Secret %q has no key %q yet, retrying", path, key)
+			return resource.RetryableError(fmt.Errorf("key %q not found at %q", key, path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// kvAPIEndpoints are the endpoints of a KV version 2 mount. Paths already
+// addressing one of them, like the data/ paths that had to be used before
+// these mounts were supported natively, are used as-is.
+var kvAPIEndpoints = []string{"config", "data", "delete", "destroy", "metadata", "undelete"}
+
+// genericSecretKVMount returns the KV mount that the secret at path is
+// read from and written to. When the mount can't be looked up, e.g.
+// because the token may not read it, the path is used as-is.
+func genericSecretKVMount(client *api.Client, namespace, path string) *kvMount {
+	mount, err := kvMountInNamespace(client, namespace, path)
+	if err != nil {
+		log.Printf("[WARN] Using %q as-is: %s", path, err)
+		return &kvMount{Version: 1}
+	}
+	if mount.Version != 2 {
+		return mount
+	}
+
+	endpoint := strings.SplitN(mount.relativePath(path), "/", 2)[0]
+	for _, e := range kvAPIEndpoints {
+		if endpoint == e {
+			return &kvMount{Version: 1}
+		}
+	}
+	return mount
+}
+
+// genericSecretVersionData reads the data of the given version of a secret
+// in a KV version 2 mount.
+func genericSecretVersionData(client *api.Client, mount *kvMount, path string, version int) (map[string]interface{}, error) {
+	if mount.Version != 2 {
+		return nil, fmt.Errorf("restore_version can only be used with secrets in KV version 2 mounts")
+	}
+
+	log.Printf("[DEBUG] Reading version %d of %s from Vault", version, path)
+	secret, err := readWithQuery(client, path, map[string]interface{}{"version": version})
+	if err != nil {
+		return nil, fmt.Errorf("error reading version %d of %q from Vault: %s", version, path, err)
+	}
+	data, _ := kvSecretData(mount, secret)
+	if data == nil {
+		return nil, fmt.Errorf("version %d of %q has no data, it may not exist or have been deleted", version, path)
+	}
+	return data, nil
+}
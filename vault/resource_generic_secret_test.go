@@ -2,8 +2,13 @@ package vault
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/acctest"
 	r "github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 
@@ -106,3 +111,860 @@ func testResourceGenericSecret_updateCheck(s *terraform.State) error {
 
 	return nil
 }
+
+func TestNormalizeDataJSON(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{`{"zip": "zap"}`, `{"zip":"zap"}`},
+		{`{"b": 1, "a": 2}`, `{"a":2,"b":1}`},
+		{`{"big": 9007199254740993}`, `{"big":9007199254740993}`},
+		{`{"precise": 0.10000000000000000555}`, `{"precise":0.10000000000000000555}`},
+		{`{"ts": 1500000000123456789}`, `{"ts":1500000000123456789}`},
+		{`{"nested": {"id": 12345678901234567890}}`, `{"nested":{"id":12345678901234567890}}`},
+	}
+
+	for _, tc := range cases {
+		if got := NormalizeDataJSON(tc.input); got != tc.want {
+			t.Errorf("NormalizeDataJSON(%s) = %s; want %s", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestValidateDataJSON(t *testing.T) {
+	if _, errs := ValidateDataJSON(`{"big": 9007199254740993}`, "data_json"); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if _, errs := ValidateDataJSON(`{"zip": `, "data_json"); len(errs) == 0 {
+		t.Errorf("expected an error for malformed JSON")
+	}
+	if _, errs := ValidateDataJSON(`{"zip": "zap"} {}`, "data_json"); len(errs) == 0 {
+		t.Errorf("expected an error for trailing data")
+	}
+	if _, errs := ValidateDataJSON(`["zip"]`, "data_json"); len(errs) == 0 {
+		t.Errorf("expected an error for a non-object JSON value")
+	}
+}
+
+func TestResourceGenericSecret_readRetry(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "vault_generic_secret" "test" {
+    path = "secret/read-retry"
+    allow_read = true
+    read_retry {
+        timeout = 10
+        key = "zip"
+    }
+    data_json = <<EOT
+{
+    "zip": "zap"
+}
+EOT
+}
+`,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"zip":"zap"}`),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "read_retry.0.key", "zip"),
+				),
+			},
+			r.TestStep{
+				// Refreshes aren't retried, so a deleted secret is removed
+				// from the state without waiting for the timeout.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if _, err := client.Logical().Delete("secret/read-retry"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: `
+resource "vault_generic_secret" "test" {
+    path = "secret/read-retry"
+    allow_read = true
+    read_retry {
+        timeout = 10
+        key = "zip"
+    }
+    data_json = <<EOT
+{
+    "zip": "zap"
+}
+EOT
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceGenericSecret_dataFormatKV(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "vault_generic_secret" "test" {
+    path = "secret/data-format"
+    allow_read = true
+    data_format = "kv"
+    data = <<EOT
+zip=zap
+foo=bar
+EOT
+}
+`,
+				Check: r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"foo":"bar","zip":"zap"}`),
+			},
+		},
+	})
+}
+
+func TestResourceGenericSecret_dataJSONTemplate(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "vault_generic_secret" "test" {
+    path = "secret/data-json-template"
+    allow_read = true
+    data_json_template = "{\"user\": \"{{.user}}\", \"password\": {{json .password}}}"
+    template_vars {
+        user = "admin"
+        password = "s3cr3t"
+    }
+}
+`,
+				Check: r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"password":"s3cr3t","user":"admin"}`),
+			},
+		},
+	})
+}
+
+// Namespaces require Vault Enterprise, but repeating the namespace in the
+// path is rejected before any request is made.
+func TestResourceGenericSecret_namespaceInPath(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "vault_generic_secret" "test" {
+    namespace = "team"
+    path = "team/secret/foo"
+    data_json = "{\"foo\": \"bar\"}"
+}
+`,
+				ExpectError: regexp.MustCompile("must not repeat it"),
+			},
+		},
+	})
+}
+
+func TestGenericSecretEnvData(t *testing.T) {
+	os.Setenv("TEST_GENERIC_SECRET_ENV_DATA", `{"password": "s3cr3t"}`)
+	defer os.Unsetenv("TEST_GENERIC_SECRET_ENV_DATA")
+
+	data, err := genericSecretEnvData("TEST_GENERIC_SECRET_ENV_DATA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["password"] != "s3cr3t" {
+		t.Errorf("unexpected data %#v", data)
+	}
+
+	if _, err := genericSecretEnvData("TEST_GENERIC_SECRET_ENV_DATA_UNSET"); err == nil {
+		t.Errorf("expected an error for an unset environment variable")
+	}
+
+	os.Setenv("TEST_GENERIC_SECRET_ENV_DATA", "not json")
+	if _, err := genericSecretEnvData("TEST_GENERIC_SECRET_ENV_DATA"); err == nil {
+		t.Errorf("expected an error for invalid JSON")
+	}
+}
+
+func TestResourceGenericSecret_dataJSONEnv(t *testing.T) {
+	const env = "TEST_ACC_GENERIC_SECRET_DATA_JSON_ENV"
+	os.Setenv(env, `{"password": "s3cr3t"}`)
+	defer os.Unsetenv(env)
+
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "secret/data-json-env"
+    allow_read = true
+    data_json = "{\"user\": \"admin\"}"
+    data_json_env = "%s"
+}
+`, env),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"user":"admin"}`),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json_env_keys.#", "1"),
+					func(s *terraform.State) error {
+						client := testProvider.Meta().(*api.Client)
+						secret, err := client.Logical().Read("secret/data-json-env")
+						if err != nil {
+							return err
+						}
+						if secret == nil || secret.Data["password"] != "s3cr3t" {
+							return fmt.Errorf("expected the password from the environment to be written")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestSecretDataEqual(t *testing.T) {
+	a, _ := decodeDataJSON(`{"a": 1, "b": "x"}`)
+	b, _ := decodeDataJSON(`{"b": "x", "a": 1}`)
+	c, _ := decodeDataJSON(`{"a": 2, "b": "x"}`)
+	if !secretDataEqual(a, b) {
+		t.Errorf("expected %v and %v to be equal", a, b)
+	}
+	if secretDataEqual(a, c) {
+		t.Errorf("expected %v and %v to differ", a, c)
+	}
+	if !secretDataEqual(map[string]interface{}{}, map[string]interface{}{}) {
+		t.Errorf("expected empty data to be equal")
+	}
+}
+
+func TestResourceGenericSecret_expectedDataJSON(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/expected")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testResourceGenericSecret_expectedDataJSONConfig(path, `{"v": "1"}`, `{}`),
+			},
+			r.TestStep{
+				Config: testResourceGenericSecret_expectedDataJSONConfig(path, `{"v": "2"}`, `{"v": "1"}`),
+			},
+			r.TestStep{
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if _, err := client.Logical().Write(path, map[string]interface{}{"v": "external"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      testResourceGenericSecret_expectedDataJSONConfig(path, `{"v": "3"}`, `{"v": "2"}`),
+				ExpectError: regexp.MustCompile("doesn't hold the data given in expected_data_json"),
+			},
+		},
+	})
+}
+
+func testResourceGenericSecret_expectedDataJSONConfig(path, data, expected string) string {
+	return fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    data_json = %q
+    expected_data_json = %q
+}
+`, path, data, expected)
+}
+
+func TestResourceGenericSecret_adoptExisting(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/adopt")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if _, err := client.Logical().Write(path, map[string]interface{}{"owner": "other"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testResourceGenericSecret_adoptExistingConfig(path, true),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"owner":"other"}`),
+					func(*terraform.State) error {
+						client := testProvider.Meta().(*api.Client)
+						secret, err := client.Logical().Read(path)
+						if err != nil {
+							return err
+						}
+						if secret == nil || secret.Data["owner"] != "other" {
+							return fmt.Errorf("adopted secret was overwritten: %v", secret)
+						}
+						return nil
+					},
+				),
+				// The adopted data differs from the configuration.
+				ExpectNonEmptyPlan: true,
+			},
+			r.TestStep{
+				Config: testResourceGenericSecret_adoptExistingConfig(path, true),
+				Check:  r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"owner":"me"}`),
+			},
+		},
+	})
+}
+
+func TestResourceGenericSecret_adoptExistingWithoutRead(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/adopt")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if _, err := client.Logical().Write(path, map[string]interface{}{"owner": "other"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      testResourceGenericSecret_adoptExistingConfig(path, false),
+				ExpectError: regexp.MustCompile("adopt_existing can only import it when allow_read is set"),
+			},
+		},
+	})
+}
+
+func testResourceGenericSecret_adoptExistingConfig(path string, allowRead bool) string {
+	return fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    adopt_existing = true
+    allow_read = %t
+    data_json = "{\"owner\": \"me\"}"
+}
+`, path, allowRead)
+}
+
+func TestResourceGenericSecret_retryPolicy(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/retry")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    allow_read = true
+    max_retries = 5
+    retry_on = [412, 429, 503]
+    data_json = "{\"zip\": \"zap\"}"
+}
+`, path),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"zip":"zap"}`),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "retry_on.#", "3"),
+				),
+			},
+			r.TestStep{
+				Config: fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    allow_read = true
+    max_retries = 0
+    data_json = "{\"zip\": \"zap\"}"
+}
+`, path),
+				Check: r.TestCheckResourceAttr("vault_generic_secret.test", "max_retries", "0"),
+			},
+		},
+	})
+}
+
+func TestResourceGenericSecret_value(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "vault_generic_secret" "test" {
+    path = "secret/value"
+    allow_read = true
+    data_json = "{\"password\": \"s3cr3t\", \"port\": 5432}"
+}
+`,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "value.%", "2"),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "value.password", "s3cr3t"),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "value.port", "5432"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceGenericSecret_cacheRead(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/cache-read")
+	config := fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    allow_read = true
+    cache_read = true
+    data_json = "{\"v\": \"1\"}"
+}
+`, path)
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: config,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"v":"1"}`),
+					r.TestCheckResourceAttrSet("vault_generic_secret.test", "lease_start_time"),
+				),
+			},
+			r.TestStep{
+				// The secret isn't read again on refresh, so changes made
+				// outside of Terraform aren't seen.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if _, err := client.Logical().Write(path, map[string]interface{}{"v": "external"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestHashDataJSON(t *testing.T) {
+	hash := hashDataJSON(NormalizeDataJSON(`{"b": 2, "a": "x"}`))
+	if hash != hashDataJSON(NormalizeDataJSON(`{"a":"x","b":2}`)) {
+		t.Errorf("expected equivalent JSON to have the same hash")
+	}
+	if hash == hashDataJSON(NormalizeDataJSON(`{"a":"y","b":2}`)) {
+		t.Errorf("expected different JSON to have different hashes")
+	}
+	if !strings.HasPrefix(hash, "sha256:") || len(hash) != len("sha256:")+64 {
+		t.Errorf("unexpected hash %q", hash)
+	}
+}
+
+func TestResourceGenericSecret_storeHashOnly(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/store-hash-only")
+	config := func(value string) string {
+		return fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    allow_read = true
+    store_hash_only = true
+    data_json = "{\"password\": \"%s\"}"
+}
+`, path, value)
+	}
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: config("s3cr3t"),
+				Check:  r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", hashDataJSON(`{"password":"s3cr3t"}`)),
+			},
+			r.TestStep{
+				Config:   config("s3cr3t"),
+				PlanOnly: true,
+			},
+			r.TestStep{
+				// Drift is detected from the hash of the data in Vault.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if _, err := client.Logical().Write(path, map[string]interface{}{"password": "external"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config("s3cr3t"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			r.TestStep{
+				Config: config("n3w"),
+				Check:  r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", hashDataJSON(`{"password":"n3w"}`)),
+			},
+		},
+	})
+}
+
+func TestResourceGenericSecret_kvV2(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testResourceGenericSecret_kvV2Config(mount, `data_json = "{\"zip\":\"zap\"}"`),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"zip":"zap"}`),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "version", "1"),
+					func(*terraform.State) error {
+						client := testProvider.Meta().(*api.Client)
+						secret, err := client.Logical().Read(mount + "/data/foo")
+						if err != nil {
+							return err
+						}
+						if secret == nil {
+							return fmt.Errorf("secret not written to %s/data/foo", mount)
+						}
+						if data, _ := secret.Data["data"].(map[string]interface{}); data["zip"] != "zap" {
+							return fmt.Errorf("unexpected data %v", secret.Data)
+						}
+						return nil
+					},
+				),
+			},
+			r.TestStep{
+				Config: testResourceGenericSecret_kvV2Config(mount, `data_json = "{\"zip\":\"zoop\"}"`),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"zip":"zoop"}`),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "version", "2"),
+				),
+			},
+			r.TestStep{
+				Config: testResourceGenericSecret_kvV2Config(mount, `restore_version = 1`),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"zip":"zap"}`),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "version", "3"),
+				),
+			},
+			r.TestStep{
+				// Changing the secret outside of Terraform undoes the
+				// restore, which is applied again.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					data := map[string]interface{}{"data": map[string]interface{}{"zip": "other"}}
+					if _, err := client.Logical().Write(mount+"/data/foo", data); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testResourceGenericSecret_kvV2Config(mount, `restore_version = 1`),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"zip":"zap"}`),
+					r.TestCheckResourceAttr("vault_generic_secret.test", "version", "5"),
+				),
+			},
+		},
+	})
+}
+
+func testResourceGenericSecret_kvV2Config(mount, data string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+    path = "%s"
+    type = "kv"
+    options = {
+        version = "2"
+    }
+}
+
+resource "vault_generic_secret" "test" {
+    path = "${vault_mount.kv.path}/foo"
+    allow_read = true
+    %s
+}
+`, mount, data)
+}
+
+func TestResourceGenericSecret_kvV2DeleteVersions(t *testing.T) {
+	if os.Getenv(r.TestEnvVar) == "" {
+		t.Skip(fmt.Sprintf("Acceptance tests skipped unless env '%s' set", r.TestEnvVar))
+	}
+	testAccPreCheck(t)
+
+	// The mount is managed outside of Terraform so that the versions left
+	// behind can be checked once the secret is destroyed.
+	client, err := api.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mount := acctest.RandomWithPrefix("kv")
+	_, err = client.Logical().Write("sys/mounts/"+mount, map[string]interface{}{
+		"type":    "kv",
+		"options": map[string]interface{}{"version": "2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Sys().Unmount(mount)
+
+	for _, tc := range []struct {
+		name     string
+		settings string
+		check    func(versions map[string]interface{}) error
+	}{
+		{"soft-delete", "", func(versions map[string]interface{}) error {
+			v, _ := versions["2"].(map[string]interface{})
+			if v["deletion_time"] == "" || v["destroyed"] == true {
+				return fmt.Errorf("version 2 wasn't only deleted: %v", v)
+			}
+			if v, _ := versions["1"].(map[string]interface{}); v["deletion_time"] != "" {
+				return fmt.Errorf("version 1 was deleted: %v", v)
+			}
+			return nil
+		}},
+		{"destroy", "destroy_versions = [1]", func(versions map[string]interface{}) error {
+			if v, _ := versions["1"].(map[string]interface{}); v["destroyed"] != true {
+				return fmt.Errorf("version 1 wasn't destroyed: %v", v)
+			}
+			if v, _ := versions["2"].(map[string]interface{}); v["deletion_time"] != "" || v["destroyed"] == true {
+				return fmt.Errorf("version 2 was deleted: %v", v)
+			}
+			return nil
+		}},
+	} {
+		path := mount + "/" + tc.name
+		r.Test(t, r.TestCase{
+			Providers: testProviders,
+			PreCheck:  func() { testAccPreCheck(t) },
+			CheckDestroy: func(*terraform.State) error {
+				secret, err := client.Logical().Read(mount + "/metadata/" + tc.name)
+				if err != nil {
+					return err
+				}
+				if secret == nil {
+					return fmt.Errorf("metadata of %q was deleted", path)
+				}
+				versions, _ := secret.Data["versions"].(map[string]interface{})
+				return tc.check(versions)
+			},
+			Steps: []r.TestStep{
+				r.TestStep{
+					Config: testResourceGenericSecret_kvV2DeleteVersionsConfig(path, "zap", tc.settings),
+				},
+				r.TestStep{
+					Config: testResourceGenericSecret_kvV2DeleteVersionsConfig(path, "zoop", tc.settings),
+					Check:  r.TestCheckResourceAttr("vault_generic_secret.test", "version", "2"),
+				},
+			},
+		})
+	}
+}
+
+func testResourceGenericSecret_kvV2DeleteVersionsConfig(path, value, settings string) string {
+	return fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    allow_read = true
+    data_json = "{\"zip\":\"%s\"}"
+    delete_all_versions = false
+    %s
+}
+`, path, value, settings)
+}
+
+func TestResourceGenericSecret_ignoreFields(t *testing.T) {
+	path := acctest.RandomWithPrefix("secret/ignore-fields")
+	config := func(value, ignore string) string {
+		return fmt.Sprintf(`
+resource "vault_generic_secret" "test" {
+    path = "%s"
+    allow_read = true
+    data_json = "{\"password\": \"%s\"}"
+%s
+}
+`, path, value, ignore)
+	}
+	externalWrite := func(data map[string]interface{}) func() {
+		return func() {
+			client := testProvider.Meta().(*api.Client)
+			if _, err := client.Logical().Write(path, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	checkVault := func(key, want string) r.TestCheckFunc {
+		return func(s *terraform.State) error {
+			client := testProvider.Meta().(*api.Client)
+			secret, err := client.Logical().Read(path)
+			if err != nil {
+				return err
+			}
+			if secret == nil || secret.Data[key] != want {
+				return fmt.Errorf("%s of %q is not %q: %v", key, path, want, secret)
+			}
+			return nil
+		}
+	}
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: config("s3cr3t", `ignore_fields = ["rotated_at"]`),
+				Check:  r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"password":"s3cr3t"}`),
+			},
+			r.TestStep{
+				// Ignored keys written by applications aren't drift.
+				PreConfig: externalWrite(map[string]interface{}{"password": "s3cr3t", "rotated_at": "now"}),
+				Config:    config("s3cr3t", `ignore_fields = ["rotated_at"]`),
+				PlanOnly:  true,
+			},
+			r.TestStep{
+				// Nor are they removed by writes.
+				Config: config("n3w", `ignore_fields = ["rotated_at"]`),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"password":"n3w"}`),
+					checkVault("rotated_at", "now"),
+				),
+			},
+			r.TestStep{
+				PreConfig: externalWrite(map[string]interface{}{"password": "n3w", "rotated_at": "now", "owner": "app"}),
+				Config:    config("n3w", `ignore_absent_fields = true`),
+				PlanOnly:  true,
+			},
+			r.TestStep{
+				Config: config("n3w3r", `ignore_absent_fields = true`),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "data_json", `{"password":"n3w3r"}`),
+					checkVault("owner", "app"),
+					checkVault("rotated_at", "now"),
+				),
+			},
+		},
+	})
+}
+
+func TestGenericSecretRotationDue(t *testing.T) {
+	now := time.Unix(1500000000, 0).UTC()
+	tests := []struct {
+		rotatedAt string
+		period    int
+		want      bool
+	}{
+		{now.Add(-time.Hour).Format(time.RFC3339), 3600, true},
+		{now.Add(-time.Hour).Format(time.RFC3339), 3601, false},
+		{now.Add(-time.Hour).Format(time.RFC3339), 0, false},
+		{"", 60, false},
+		{"yesterday", 60, false},
+	}
+	for _, test := range tests {
+		got := genericSecretRotationDue(test.rotatedAt, test.period, now)
+		if got != test.want {
+			t.Errorf("rotation of secret written at %q every %ds is due: %t; want %t",
+				test.rotatedAt, test.period, got, test.want)
+		}
+	}
+}
+
+func TestResourceGenericSecret_rotationTriggers(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testResourceGenericSecret_kvV2Config(mount, `
+    data_json = "{\"zip\":\"zap\"}"
+    rotation_triggers = {
+        month = "2019-01"
+    }
+`),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("vault_generic_secret.test", "version", "1"),
+					r.TestCheckResourceAttrSet("vault_generic_secret.test", "rotated_at"),
+				),
+			},
+			r.TestStep{
+				// The same data is written again as a new version.
+				Config: testResourceGenericSecret_kvV2Config(mount, `
+    data_json = "{\"zip\":\"zap\"}"
+    rotation_triggers = {
+        month = "2019-02"
+    }
+`),
+				Check: r.TestCheckResourceAttr("vault_generic_secret.test", "version", "2"),
+			},
+			r.TestStep{
+				// Rotations due are planned as writes of the secret.
+				Config: testResourceGenericSecret_kvV2Config(mount, `
+    data_json = "{\"zip\":\"zap\"}"
+    rotation_period = 5
+    rotation_triggers = {
+        month = "2019-02"
+    }
+`),
+			},
+			r.TestStep{
+				PreConfig: func() { time.Sleep(6 * time.Second) },
+				Config: testResourceGenericSecret_kvV2Config(mount, `
+    data_json = "{\"zip\":\"zap\"}"
+    rotation_period = 5
+    rotation_triggers = {
+        month = "2019-02"
+    }
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceGenericSecret_cas(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testResourceGenericSecret_casConfig(mount, `{"zip":"zap"}`),
+				Check:  r.TestCheckResourceAttr("vault_generic_secret.test", "version", "1"),
+			},
+			r.TestStep{
+				Config: testResourceGenericSecret_casConfig(mount, `{"zip":"zop"}`),
+				Check:  r.TestCheckResourceAttr("vault_generic_secret.test", "version", "2"),
+			},
+			r.TestStep{
+				// A write made outside of Terraform isn't overwritten.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					_, err := client.Logical().Write(mount+"/data/foo", map[string]interface{}{
+						"data": map[string]interface{}{"zip": "out-of-band"},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      testResourceGenericSecret_casConfig(mount, `{"zip":"zup"}`),
+				ExpectError: regexp.MustCompile("modified outside of Terraform since version 2"),
+			},
+		},
+	})
+}
+
+func testResourceGenericSecret_casConfig(mount, data string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+    path = "%s"
+    type = "kv"
+    options = {
+        version = "2"
+    }
+}
+
+resource "vault_generic_secret" "test" {
+    path = "${vault_mount.kv.path}/foo"
+    cas = true
+    data_json = %q
+}
+`, mount, data)
+}
@@ -0,0 +1,186 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func githubAuthBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: githubAuthBackendCreate,
+		Update: githubAuthBackendUpdate,
+		Delete: githubAuthBackendDelete,
+		Read:   githubAuthBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "github",
+				Description: "Path to enable the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the backend.",
+			},
+
+			"organization": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "GitHub organization users must be members of to log in.",
+			},
+
+			"base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "API endpoint of GitHub Enterprise, the public API if not set.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "TTL in seconds of the tokens issued by the backend, the system default if 0.",
+			},
+
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL in seconds of the tokens issued by the backend, the system default if 0.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The accessor of the backend.",
+			},
+		},
+	}
+}
+
+func githubAuthBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Enabling GitHub auth backend at %q", path)
+	if err := client.Sys().EnableAuth(path, "github", d.Get("description").(string)); err != nil {
+		return fmt.Errorf("error enabling GitHub auth backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if err := githubAuthBackendWriteConfig(client, d); err != nil {
+		return err
+	}
+
+	return githubAuthBackendRead(d, meta)
+}
+
+func githubAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("description") {
+		if err := authBackendTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("organization") || d.HasChange("base_url") ||
+		d.HasChange("ttl") || d.HasChange("max_ttl") {
+		if err := githubAuthBackendWriteConfig(client, d); err != nil {
+			return err
+		}
+	}
+
+	return githubAuthBackendRead(d, meta)
+}
+
+// githubAuthBackendWriteConfig writes the organization and token settings
+// of the backend, which are replaced all together on every write.
+func githubAuthBackendWriteConfig(client *api.Client, d *schema.ResourceData) error {
+	path := "auth/" + d.Id() + "/config"
+
+	log.Printf("[DEBUG] Writing GitHub auth backend config %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"organization": d.Get("organization").(string),
+		"base_url":     d.Get("base_url").(string),
+		"ttl":          fmt.Sprintf("%ds", d.Get("ttl").(int)),
+		"max_ttl":      fmt.Sprintf("%ds", d.Get("max_ttl").(int)),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing GitHub auth backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func githubAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading GitHub auth backend %q", path)
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("error reading auth backends from Vault: %s", err)
+	}
+	auth, ok := auths[path+"/"]
+	if !ok {
+		log.Printf("[WARN] GitHub auth backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("description", auth.Description)
+
+	config, err := client.Logical().Read("auth/" + path + "/config")
+	if err != nil {
+		return fmt.Errorf("error reading GitHub auth backend config %q: %s", path, err)
+	}
+	if config != nil {
+		d.Set("organization", config.Data["organization"])
+		d.Set("base_url", config.Data["base_url"])
+		// Vault versions before 1.2 return the TTLs as duration strings.
+		d.Set("ttl", durationSecondsFromResponse(tokenSetting(config.Data, "token_ttl", "ttl")))
+		d.Set("max_ttl", durationSecondsFromResponse(tokenSetting(config.Data, "token_max_ttl", "max_ttl")))
+	}
+
+	accessor, err := authBackendAccessor(client, path)
+	if err != nil {
+		return err
+	}
+	d.Set("accessor", accessor)
+
+	return nil
+}
+
+func githubAuthBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Disabling GitHub auth backend %q", path)
+	if err := client.Sys().DisableAuth(path); err != nil {
+		return fmt.Errorf("error disabling GitHub auth backend %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestGithubAuthBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("github")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testGithubAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testGithubAuthBackendConfig(path, "test", "example", 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_github_auth_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_github_auth_backend.test", "description", "test"),
+					resource.TestCheckResourceAttr("vault_github_auth_backend.test", "organization", "example"),
+					resource.TestCheckResourceAttr("vault_github_auth_backend.test", "ttl", "0"),
+					resource.TestCheckResourceAttrSet("vault_github_auth_backend.test", "accessor"),
+				),
+			},
+			{
+				Config: testGithubAuthBackendConfig(path, "updated", "example-org", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_github_auth_backend.test", "description", "updated"),
+					resource.TestCheckResourceAttr("vault_github_auth_backend.test", "organization", "example-org"),
+					resource.TestCheckResourceAttr("vault_github_auth_backend.test", "ttl", "3600"),
+				),
+			},
+			{
+				ResourceName:      "vault_github_auth_backend.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testGithubAuthBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_github_auth_backend" {
+			continue
+		}
+		if _, ok := auths[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("GitHub auth backend %q still enabled", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testGithubAuthBackendConfig(path, description, organization string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_github_auth_backend" "test" {
+	path = "%s"
+	description = "%s"
+	organization = "%s"
+	ttl = %d
+	max_ttl = 7200
+}
+`, path, description, organization, ttl)
+}
@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var githubTeamFromPathRegex = regexp.MustCompile("^auth/(.+)/map/teams/([^/]+)$")
+
+func githubTeamResource() *schema.Resource {
+	return &schema.Resource{
+		Create: githubTeamWrite,
+		Update: githubTeamWrite,
+		Delete: githubTeamDelete,
+		Read:   githubTeamRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "github",
+				Description: "Path of the GitHub auth backend the team belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"team": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Slug of the GitHub team within the organization of the backend.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the tokens of the members of the team.",
+			},
+		},
+	}
+}
+
+func githubTeamPath(backend, team string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/map/teams/" + team
+}
+
+func githubTeamWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := githubTeamPath(d.Get("backend").(string), d.Get("team").(string))
+
+	log.Printf("[DEBUG] Writing GitHub team %q to Vault", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"value": strings.Join(toStringArray(d.Get("policies").(*schema.Set).List()), ","),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing GitHub team %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return githubTeamRead(d, meta)
+}
+
+func githubTeamRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := githubTeamFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid GitHub team ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading GitHub team %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading GitHub team %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] GitHub team %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("team", res[2])
+	if err := d.Set("policies", flattenStringList(secret.Data["value"])); err != nil {
+		return fmt.Errorf("error setting policies of GitHub team %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func githubTeamDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting GitHub team %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting GitHub team %q: %s", path, err)
+	}
+
+	return nil
+}
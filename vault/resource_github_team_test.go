@@ -0,0 +1,73 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestGithubTeam(t *testing.T) {
+	backend := acctest.RandomWithPrefix("github")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testGithubTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testGithubTeamConfig(backend, `["default", "dev"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_github_team.test", "id", "auth/"+backend+"/map/teams/example"),
+					resource.TestCheckResourceAttr("vault_github_team.test", "policies.#", "2"),
+				),
+			},
+			{
+				Config: testGithubTeamConfig(backend, `["admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_github_team.test", "policies.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_github_team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testGithubTeamDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_github_team" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for GitHub team %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("GitHub team %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testGithubTeamConfig(backend, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_github_auth_backend" "test" {
+	path = "%s"
+	organization = "example"
+}
+
+resource "vault_github_team" "test" {
+	backend = "${vault_github_auth_backend.test.path}"
+	team = "example"
+	policies = %s
+}
+`, backend, policies)
+}
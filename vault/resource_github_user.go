@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var githubUserFromPathRegex = regexp.MustCompile("^auth/(.+)/map/users/([^/]+)$")
+
+func githubUserResource() *schema.Resource {
+	return &schema.Resource{
+		Create: githubUserWrite,
+		Update: githubUserWrite,
+		Delete: githubUserDelete,
+		Read:   githubUserRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "github",
+				Description: "Path of the GitHub auth backend the user belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"user": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Slug of the GitHub user within the organization of the backend.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the tokens of the user, on top of those of their teams.",
+			},
+		},
+	}
+}
+
+func githubUserPath(backend, user string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/map/users/" + user
+}
+
+func githubUserWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := githubUserPath(d.Get("backend").(string), d.Get("user").(string))
+
+	log.Printf("[DEBUG] Writing GitHub user %q to Vault", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"value": strings.Join(toStringArray(d.Get("policies").(*schema.Set).List()), ","),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing GitHub user %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return githubUserRead(d, meta)
+}
+
+func githubUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := githubUserFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid GitHub user ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading GitHub user %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading GitHub user %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] GitHub user %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("user", res[2])
+	if err := d.Set("policies", flattenStringList(secret.Data["value"])); err != nil {
+		return fmt.Errorf("error setting policies of GitHub user %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func githubUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting GitHub user %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting GitHub user %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestGithubUser(t *testing.T) {
+	backend := acctest.RandomWithPrefix("github")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testGithubUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testGithubUserConfig(backend, `["default", "dev"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_github_user.test", "id", "auth/"+backend+"/map/users/example"),
+					resource.TestCheckResourceAttr("vault_github_user.test", "policies.#", "2"),
+				),
+			},
+			{
+				Config: testGithubUserConfig(backend, `["admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_github_user.test", "policies.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_github_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testGithubUserDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_github_user" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for GitHub user %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("GitHub user %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testGithubUserConfig(backend, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_github_auth_backend" "test" {
+	path = "%s"
+	organization = "example"
+}
+
+resource "vault_github_user" "test" {
+	backend = "${vault_github_auth_backend.test.path}"
+	user = "example"
+	policies = %s
+}
+`, backend, policies)
+}
@@ -0,0 +1,136 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const identityEntityPath = "identity/entity"
+
+func identityEntityResource() *schema.Resource {
+	return &schema.Resource{
+		Create: identityEntityCreate,
+		Update: identityEntityUpdate,
+		Delete: identityEntityDelete,
+		Read:   identityEntityRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the entity.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the entity.",
+			},
+
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Metadata associated with the entity.",
+			},
+
+			"disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the entity is disabled. Tokens of a disabled entity can't be used until it is enabled again.",
+			},
+		},
+	}
+}
+
+func identityEntityData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"policies": d.Get("policies").(*schema.Set).List(),
+		"metadata": d.Get("metadata").(map[string]interface{}),
+		"disabled": d.Get("disabled").(bool),
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		data["name"] = v.(string)
+	}
+
+	return data
+}
+
+func identityEntityCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Creating identity entity %q in Vault", d.Get("name").(string))
+	secret, err := client.Logical().Write(identityEntityPath, identityEntityData(d))
+	if err != nil {
+		return fmt.Errorf("error creating identity entity: %s", err)
+	}
+	if secret == nil || secret.Data["id"] == nil {
+		return fmt.Errorf("no ID returned when creating identity entity")
+	}
+
+	d.SetId(secret.Data["id"].(string))
+
+	return identityEntityRead(d, meta)
+}
+
+func identityEntityUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Updating identity entity %q in Vault", id)
+	if _, err := client.Logical().Write(identityEntityPath+"/id/"+id, identityEntityData(d)); err != nil {
+		return fmt.Errorf("error updating identity entity %q: %s", id, err)
+	}
+
+	return identityEntityRead(d, meta)
+}
+
+func identityEntityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Reading identity entity %q from Vault", id)
+	secret, err := client.Logical().Read(identityEntityPath + "/id/" + id)
+	if err != nil {
+		return fmt.Errorf("error reading identity entity %q: %s", id, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Identity entity %q not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", secret.Data["name"])
+	d.Set("metadata", secret.Data["metadata"])
+	d.Set("disabled", secret.Data["disabled"])
+	if err := d.Set("policies", flattenStringList(secret.Data["policies"])); err != nil {
+		return fmt.Errorf("error setting policies of identity entity %q: %s", id, err)
+	}
+
+	return nil
+}
+
+func identityEntityDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Deleting identity entity %q from Vault", id)
+	if _, err := client.Logical().Delete(identityEntityPath + "/id/" + id); err != nil {
+		return fmt.Errorf("error deleting identity entity %q: %s", id, err)
+	}
+
+	return nil
+}
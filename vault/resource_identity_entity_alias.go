@@ -0,0 +1,117 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const identityEntityAliasPath = "identity/entity-alias"
+
+func identityEntityAliasResource() *schema.Resource {
+	return &schema.Resource{
+		Create: identityEntityAliasCreate,
+		Update: identityEntityAliasUpdate,
+		Delete: identityEntityAliasDelete,
+		Read:   identityEntityAliasRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the alias, the name of the user in the auth backend, e.g. the username of userpass or LDAP.",
+			},
+
+			"mount_accessor": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Accessor of the auth backend the alias belongs to.",
+			},
+
+			"canonical_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the entity the alias belongs to.",
+			},
+		},
+	}
+}
+
+func identityEntityAliasData(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":           d.Get("name").(string),
+		"mount_accessor": d.Get("mount_accessor").(string),
+		"canonical_id":   d.Get("canonical_id").(string),
+	}
+}
+
+func identityEntityAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Creating identity entity alias %q in Vault", d.Get("name").(string))
+	secret, err := client.Logical().Write(identityEntityAliasPath, identityEntityAliasData(d))
+	if err != nil {
+		return fmt.Errorf("error creating identity entity alias: %s", err)
+	}
+	if secret == nil || secret.Data["id"] == nil {
+		return fmt.Errorf("no ID returned when creating identity entity alias")
+	}
+
+	d.SetId(secret.Data["id"].(string))
+
+	return identityEntityAliasRead(d, meta)
+}
+
+func identityEntityAliasUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Updating identity entity alias %q in Vault", id)
+	if _, err := client.Logical().Write(identityEntityAliasPath+"/id/"+id, identityEntityAliasData(d)); err != nil {
+		return fmt.Errorf("error updating identity entity alias %q: %s", id, err)
+	}
+
+	return identityEntityAliasRead(d, meta)
+}
+
+func identityEntityAliasRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Reading identity entity alias %q from Vault", id)
+	secret, err := client.Logical().Read(identityEntityAliasPath + "/id/" + id)
+	if err != nil {
+		return fmt.Errorf("error reading identity entity alias %q: %s", id, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Identity entity alias %q not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", secret.Data["name"])
+	d.Set("mount_accessor", secret.Data["mount_accessor"])
+	d.Set("canonical_id", secret.Data["canonical_id"])
+
+	return nil
+}
+
+func identityEntityAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Deleting identity entity alias %q from Vault", id)
+	if _, err := client.Logical().Delete(identityEntityAliasPath + "/id/" + id); err != nil {
+		return fmt.Errorf("error deleting identity entity alias %q: %s", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestIdentityEntityAlias_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("alias")
+	authPath := acctest.RandomWithPrefix("userpass")
+	var id string
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testIdentityEntityAliasDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testIdentityEntityAliasConfig(authPath, name),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupID("vault_identity_entity_alias.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_entity_alias.test", "name", name),
+					resource.TestCheckResourceAttrPair("vault_identity_entity_alias.test", "mount_accessor", "vault_auth_backend.userpass", "accessor"),
+					resource.TestCheckResourceAttrPair("vault_identity_entity_alias.test", "canonical_id", "vault_identity_entity.test", "id"),
+				),
+			},
+			{
+				Config: testIdentityEntityAliasConfig(authPath, name+"-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_entity_alias.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_entity_alias.test", "name", name+"-renamed"),
+				),
+			},
+			{
+				ResourceName:      "vault_identity_entity_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testIdentityEntityAliasDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_identity_entity_alias" {
+			continue
+		}
+		secret, err := client.Logical().Read(identityEntityAliasPath + "/id/" + rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("identity entity alias %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testIdentityEntityAliasConfig(authPath, name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "userpass" {
+	type = "userpass"
+	path = "%s"
+}
+
+resource "vault_identity_entity" "test" {
+	policies = ["default"]
+}
+
+resource "vault_identity_entity_alias" "test" {
+	name = "%s"
+	mount_accessor = "${vault_auth_backend.userpass.accessor}"
+	canonical_id = "${vault_identity_entity.test.id}"
+}
+`, authPath, name)
+}
@@ -0,0 +1,151 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestIdentityEntity_disable(t *testing.T) {
+	name := acctest.RandomWithPrefix("entity")
+	authPath := acctest.RandomWithPrefix("userpass")
+	var id, token string
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testIdentityEntityDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testIdentityEntityConfig(name, authPath, false),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupID("vault_identity_entity.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_entity.test", "name", name),
+					resource.TestCheckResourceAttr("vault_identity_entity.test", "disabled", "false"),
+					testIdentityEntityLogin(authPath, &id, &token),
+					testIdentityEntityTokenUsable(&token, true),
+				),
+			},
+			{
+				Config: testIdentityEntityConfig(name, authPath, true),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_entity.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_entity.test", "disabled", "true"),
+					testIdentityEntityTokenUsable(&token, false),
+				),
+			},
+			{
+				Config: testIdentityEntityConfig(name, authPath, false),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_entity.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_entity.test", "disabled", "false"),
+					testIdentityEntityTokenUsable(&token, true),
+				),
+			},
+			{
+				ResourceName:      "vault_identity_entity.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testIdentityEntityLogin links a userpass user to the entity and logs in
+// with it, so that the resulting token belongs to the entity.
+func testIdentityEntityLogin(authPath string, id, token *string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+
+		if _, err := client.Logical().Write("auth/"+authPath+"/users/offboarded", map[string]interface{}{
+			"password": "secret",
+		}); err != nil {
+			return err
+		}
+
+		auths, err := client.Logical().Read("sys/auth")
+		if err != nil {
+			return err
+		}
+		mount, _ := auths.Data[authPath+"/"].(map[string]interface{})
+		if mount == nil || mount["accessor"] == nil {
+			return fmt.Errorf("no accessor found for auth backend %q", authPath)
+		}
+
+		if _, err := client.Logical().Write("identity/entity-alias", map[string]interface{}{
+			"name":           "offboarded",
+			"canonical_id":   *id,
+			"mount_accessor": mount["accessor"],
+		}); err != nil {
+			return err
+		}
+
+		login, err := client.Logical().Write("auth/"+authPath+"/login/offboarded", map[string]interface{}{
+			"password": "secret",
+		})
+		if err != nil {
+			return err
+		}
+		if login == nil || login.Auth == nil {
+			return fmt.Errorf("no token returned by login")
+		}
+		*token = login.Auth.ClientToken
+		return nil
+	}
+}
+
+func testIdentityEntityTokenUsable(token *string, usable bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client, err := api.NewClient(nil)
+		if err != nil {
+			return err
+		}
+		client.SetToken(*token)
+
+		_, err = client.Logical().Read("auth/token/lookup-self")
+		switch {
+		case usable && err != nil:
+			return fmt.Errorf("expected the token of the entity to be usable: %s", err)
+		case !usable && err == nil:
+			return fmt.Errorf("expected the token of the disabled entity to be rejected")
+		}
+		return nil
+	}
+}
+
+func testIdentityEntityDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_identity_entity" {
+			continue
+		}
+		secret, err := client.Logical().Read(identityEntityPath + "/id/" + rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("identity entity %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testIdentityEntityConfig(name, authPath string, disabled bool) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "userpass" {
+	type = "userpass"
+	path = "%s"
+}
+
+resource "vault_identity_entity" "test" {
+	name = "%s"
+	policies = ["default"]
+	disabled = %t
+	depends_on = ["vault_auth_backend.userpass"]
+}
+`, authPath, name, disabled)
+}
@@ -0,0 +1,168 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const identityGroupPath = "identity/group"
+
+func identityGroupResource() *schema.Resource {
+	return &schema.Resource{
+		Create: identityGroupCreate,
+		Update: identityGroupUpdate,
+		Delete: identityGroupDelete,
+		Read:   identityGroupRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the group.",
+			},
+
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "internal",
+				Description: "Type of the group, internal or external.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if value := v.(string); value != "internal" && value != "external" {
+						errs = append(errs, fmt.Errorf("%s must be internal or external, got %q", k, value))
+					}
+					return
+				},
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the group.",
+			},
+
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Metadata associated with the group.",
+			},
+
+			"member_entity_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the entities that are members of the group. Only for internal groups.",
+			},
+
+			"member_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the groups that are members of the group. Only for internal groups.",
+			},
+		},
+	}
+}
+
+func identityGroupData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"type":     d.Get("type").(string),
+		"policies": d.Get("policies").(*schema.Set).List(),
+		"metadata": d.Get("metadata").(map[string]interface{}),
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		data["name"] = v.(string)
+	}
+
+	// Membership of external groups is managed through group aliases, and
+	// Vault rejects member lists for them.
+	if d.Get("type").(string) == "internal" {
+		data["member_entity_ids"] = d.Get("member_entity_ids").(*schema.Set).List()
+		data["member_group_ids"] = d.Get("member_group_ids").(*schema.Set).List()
+	}
+
+	return data
+}
+
+func identityGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Creating identity group %q in Vault", d.Get("name").(string))
+	secret, err := client.Logical().Write(identityGroupPath, identityGroupData(d))
+	if err != nil {
+		return fmt.Errorf("error creating identity group: %s", err)
+	}
+	if secret == nil || secret.Data["id"] == nil {
+		return fmt.Errorf("no ID returned when creating identity group")
+	}
+
+	d.SetId(secret.Data["id"].(string))
+
+	return identityGroupRead(d, meta)
+}
+
+func identityGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Updating identity group %q in Vault", id)
+	if _, err := client.Logical().Write(identityGroupPath+"/id/"+id, identityGroupData(d)); err != nil {
+		return fmt.Errorf("error updating identity group %q: %s", id, err)
+	}
+
+	return identityGroupRead(d, meta)
+}
+
+func identityGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Reading identity group %q from Vault", id)
+	secret, err := client.Logical().Read(identityGroupPath + "/id/" + id)
+	if err != nil {
+		return fmt.Errorf("error reading identity group %q: %s", id, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Identity group %q not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", secret.Data["name"])
+	d.Set("type", secret.Data["type"])
+	d.Set("metadata", secret.Data["metadata"])
+	for _, k := range []string{"policies", "member_entity_ids", "member_group_ids"} {
+		if err := d.Set(k, flattenStringList(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of identity group %q: %s", k, id, err)
+		}
+	}
+
+	return nil
+}
+
+func identityGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Deleting identity group %q from Vault", id)
+	if _, err := client.Logical().Delete(identityGroupPath + "/id/" + id); err != nil {
+		return fmt.Errorf("error deleting identity group %q: %s", id, err)
+	}
+
+	return nil
+}
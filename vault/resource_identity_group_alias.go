@@ -0,0 +1,120 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const identityGroupAliasPath = "identity/group-alias"
+
+func identityGroupAliasResource() *schema.Resource {
+	return &schema.Resource{
+		Create: identityGroupAliasCreate,
+		Update: identityGroupAliasUpdate,
+		Delete: identityGroupAliasDelete,
+		Read:   identityGroupAliasRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the alias, the name of the group in the auth backend, e.g. the name of a GitHub team or LDAP group.",
+			},
+
+			"mount_accessor": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Accessor of the auth backend the alias belongs to.",
+			},
+
+			"canonical_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the external group the alias belongs to.",
+			},
+		},
+	}
+}
+
+// identityGroupAliasData returns the fields of the alias. Vault only
+// accepts aliases of external groups, whose membership is then taken from
+// the auth backend on login.
+func identityGroupAliasData(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":           d.Get("name").(string),
+		"mount_accessor": d.Get("mount_accessor").(string),
+		"canonical_id":   d.Get("canonical_id").(string),
+	}
+}
+
+func identityGroupAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Creating identity group alias %q in Vault", d.Get("name").(string))
+	secret, err := client.Logical().Write(identityGroupAliasPath, identityGroupAliasData(d))
+	if err != nil {
+		return fmt.Errorf("error creating identity group alias: %s", err)
+	}
+	if secret == nil || secret.Data["id"] == nil {
+		return fmt.Errorf("no ID returned when creating identity group alias")
+	}
+
+	d.SetId(secret.Data["id"].(string))
+
+	return identityGroupAliasRead(d, meta)
+}
+
+func identityGroupAliasUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Updating identity group alias %q in Vault", id)
+	if _, err := client.Logical().Write(identityGroupAliasPath+"/id/"+id, identityGroupAliasData(d)); err != nil {
+		return fmt.Errorf("error updating identity group alias %q: %s", id, err)
+	}
+
+	return identityGroupAliasRead(d, meta)
+}
+
+func identityGroupAliasRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Reading identity group alias %q from Vault", id)
+	secret, err := client.Logical().Read(identityGroupAliasPath + "/id/" + id)
+	if err != nil {
+		return fmt.Errorf("error reading identity group alias %q: %s", id, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Identity group alias %q not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", secret.Data["name"])
+	d.Set("mount_accessor", secret.Data["mount_accessor"])
+	d.Set("canonical_id", secret.Data["canonical_id"])
+
+	return nil
+}
+
+func identityGroupAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	id := d.Id()
+
+	log.Printf("[DEBUG] Deleting identity group alias %q from Vault", id)
+	if _, err := client.Logical().Delete(identityGroupAliasPath + "/id/" + id); err != nil {
+		return fmt.Errorf("error deleting identity group alias %q: %s", id, err)
+	}
+
+	return nil
+}
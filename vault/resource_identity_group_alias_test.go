@@ -0,0 +1,83 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestIdentityGroupAlias_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("team")
+	authPath := acctest.RandomWithPrefix("userpass")
+	var id string
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testIdentityGroupAliasDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testIdentityGroupAliasConfig(authPath, name),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupID("vault_identity_group_alias.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group_alias.test", "name", name),
+					resource.TestCheckResourceAttrPair("vault_identity_group_alias.test", "mount_accessor", "vault_auth_backend.userpass", "accessor"),
+					resource.TestCheckResourceAttrPair("vault_identity_group_alias.test", "canonical_id", "vault_identity_group.test", "id"),
+				),
+			},
+			{
+				Config: testIdentityGroupAliasConfig(authPath, name+"-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_group_alias.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group_alias.test", "name", name+"-renamed"),
+				),
+			},
+			{
+				ResourceName:      "vault_identity_group_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testIdentityGroupAliasDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_identity_group_alias" {
+			continue
+		}
+		secret, err := client.Logical().Read(identityGroupAliasPath + "/id/" + rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("identity group alias %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testIdentityGroupAliasConfig(authPath, name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "userpass" {
+	type = "userpass"
+	path = "%s"
+}
+
+resource "vault_identity_group" "test" {
+	type = "external"
+	policies = ["default"]
+}
+
+resource "vault_identity_group_alias" "test" {
+	name = "%s"
+	mount_accessor = "${vault_auth_backend.userpass.accessor}"
+	canonical_id = "${vault_identity_group.test.id}"
+}
+`, authPath, name)
+}
@@ -0,0 +1,146 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestIdentityGroup_inPlaceUpdate(t *testing.T) {
+	name := acctest.RandomWithPrefix("group")
+	var id string
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testIdentityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testIdentityGroupConfig(name, `["dev"]`, "red", `[]`, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupID("vault_identity_group.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "name", name),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "metadata.team", "red"),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "member_entity_ids.#", "0"),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "member_group_ids.#", "0"),
+				),
+			},
+			{
+				Config: testIdentityGroupConfig(name+"-renamed", `["dev"]`, "red", `[]`, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_group.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "name", name+"-renamed"),
+				),
+			},
+			{
+				Config: testIdentityGroupConfig(name+"-renamed", `["dev", "ops"]`, "red", `[]`, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_group.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "policies.#", "2"),
+				),
+			},
+			{
+				Config: testIdentityGroupConfig(name+"-renamed", `["dev", "ops"]`, "blue", `[]`, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_group.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "metadata.team", "blue"),
+				),
+			},
+			{
+				Config: testIdentityGroupConfig(name+"-renamed", `["dev", "ops"]`, "blue", `[]`, `["${vault_identity_group.member.id}"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_group.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "member_group_ids.#", "1"),
+				),
+			},
+			{
+				Config: testIdentityGroupConfig(name+"-renamed", `["dev", "ops"]`, "blue", `["${vault_identity_entity.member.id}"]`, `["${vault_identity_group.member.id}"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_group.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "member_entity_ids.#", "1"),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "member_group_ids.#", "1"),
+				),
+			},
+			{
+				Config: testIdentityGroupConfig(name+"-renamed", `["dev", "ops"]`, "blue", `[]`, `["${vault_identity_group.member.id}"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testIdentityGroupSameID("vault_identity_group.test", &id),
+					resource.TestCheckResourceAttr("vault_identity_group.test", "member_entity_ids.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "vault_identity_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testIdentityGroupID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testIdentityGroupSameID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", name)
+		}
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("group was recreated: ID changed from %q to %q", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testIdentityGroupDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_identity_group" {
+			continue
+		}
+		secret, err := client.Logical().Read(identityGroupPath + "/id/" + rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("identity group %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testIdentityGroupConfig(name, policies, team, memberEntities, memberGroups string) string {
+	return fmt.Sprintf(`
+resource "vault_identity_entity" "member" {
+	name = "%s-member"
+}
+
+resource "vault_identity_group" "member" {
+	name = "%s-member"
+}
+
+resource "vault_identity_group" "test" {
+	name = "%s"
+	policies = %s
+	metadata {
+		team = "%s"
+	}
+	member_entity_ids = %s
+	member_group_ids = %s
+}
+`, name, name, name, policies, team, memberEntities, memberGroups)
+}
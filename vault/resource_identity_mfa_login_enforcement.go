@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const identityMFALoginEnforcementPath = "identity/mfa/login-enforcement"
+
+// identityMFALoginEnforcementBindingFields are the lists of auth methods
+// and identities the enforcement applies to.
+var identityMFALoginEnforcementBindingFields = []string{
+	"auth_method_accessors",
+	"auth_method_types",
+	"identity_entity_ids",
+	"identity_group_ids",
+}
+
+func identityMFALoginEnforcementResource() *schema.Resource {
+	return &schema.Resource{
+		Create: identityMFALoginEnforcementWrite,
+		Update: identityMFALoginEnforcementWrite,
+		Delete: identityMFALoginEnforcementDelete,
+		Read:   identityMFALoginEnforcementRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the login enforcement.",
+			},
+
+			"mfa_method_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the MFA methods, any of which must be satisfied on login.",
+			},
+
+			"auth_method_accessors": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Accessors of the auth mounts that require MFA.",
+			},
+
+			"auth_method_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Types of the auth methods that require MFA, e.g. userpass.",
+			},
+
+			"identity_entity_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the entities that require MFA.",
+			},
+
+			"identity_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the groups whose members require MFA.",
+			},
+
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID assigned by Vault to the login enforcement.",
+			},
+		},
+	}
+}
+
+func identityMFALoginEnforcementWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Get("name").(string)
+	path := identityMFALoginEnforcementPath + "/" + name
+
+	data := map[string]interface{}{
+		"mfa_method_ids": d.Get("mfa_method_ids").(*schema.Set).List(),
+	}
+	bound := false
+	for _, k := range identityMFALoginEnforcementBindingFields {
+		v := d.Get(k).(*schema.Set).List()
+		data[k] = v
+		bound = bound || len(v) > 0
+	}
+	if !bound {
+		return fmt.Errorf("login enforcement %q must set at least one of auth_method_accessors, auth_method_types, identity_entity_ids or identity_group_ids", name)
+	}
+
+	log.Printf("[DEBUG] Writing MFA login enforcement %q to Vault", name)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing MFA login enforcement %q: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return identityMFALoginEnforcementRead(d, meta)
+}
+
+func identityMFALoginEnforcementRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Reading MFA login enforcement %q from Vault", name)
+	secret, err := client.Logical().Read(identityMFALoginEnforcementPath + "/" + name)
+	if err != nil {
+		return fmt.Errorf("error reading MFA login enforcement %q: %s", name, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] MFA login enforcement %q not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("uuid", secret.Data["id"])
+	for _, k := range append([]string{"mfa_method_ids"}, identityMFALoginEnforcementBindingFields...) {
+		if err := d.Set(k, flattenStringList(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of MFA login enforcement %q: %s", k, name, err)
+		}
+	}
+
+	return nil
+}
+
+func identityMFALoginEnforcementDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Deleting MFA login enforcement %q from Vault", name)
+	if _, err := client.Logical().Delete(identityMFALoginEnforcementPath + "/" + name); err != nil {
+		return fmt.Errorf("error deleting MFA login enforcement %q: %s", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestIdentityMFALoginEnforcement(t *testing.T) {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skip(fmt.Sprintf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar))
+	}
+	testAccPreCheck(t)
+
+	// There is no resource for MFA methods yet, so the one enforced is
+	// created directly through the API.
+	client, err := api.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, err := client.Logical().Write("identity/mfa/method/totp", map[string]interface{}{
+		"issuer": "terraform",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	methodID := method.Data["method_id"].(string)
+	defer client.Logical().Delete("identity/mfa/method/totp/" + methodID)
+
+	name := acctest.RandomWithPrefix("enforcement")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testIdentityMFALoginEnforcementDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testIdentityMFALoginEnforcementConfig(name, methodID, `auth_method_types = ["userpass"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_identity_mfa_login_enforcement.test", "mfa_method_ids.#", "1"),
+					resource.TestCheckResourceAttr("vault_identity_mfa_login_enforcement.test", "auth_method_types.#", "1"),
+					resource.TestCheckResourceAttr("vault_identity_mfa_login_enforcement.test", "identity_group_ids.#", "0"),
+					resource.TestCheckResourceAttrSet("vault_identity_mfa_login_enforcement.test", "uuid"),
+				),
+			},
+			{
+				Config: testIdentityMFALoginEnforcementConfig(name, methodID, `
+	auth_method_types = ["userpass", "ldap"]
+	identity_group_ids = ["${vault_identity_group.test.id}"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_identity_mfa_login_enforcement.test", "auth_method_types.#", "2"),
+					resource.TestCheckResourceAttr("vault_identity_mfa_login_enforcement.test", "identity_group_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_identity_mfa_login_enforcement.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testIdentityMFALoginEnforcementConfig(name, methodID, ""),
+				ExpectError: regexp.MustCompile("must set at least one of"),
+			},
+		},
+	})
+}
+
+func testIdentityMFALoginEnforcementDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_identity_mfa_login_enforcement" {
+			continue
+		}
+		secret, err := client.Logical().Read(identityMFALoginEnforcementPath + "/" + rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("MFA login enforcement %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testIdentityMFALoginEnforcementConfig(name, methodID, bindings string) string {
+	return fmt.Sprintf(`
+resource "vault_identity_group" "test" {
+	name = "%s"
+}
+
+resource "vault_identity_mfa_login_enforcement" "test" {
+	name = "%s"
+	mfa_method_ids = ["%s"]
+	%s
+}
+`, name, name, methodID, bindings)
+}
@@ -0,0 +1,170 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const identityOIDCClientPathPrefix = "identity/oidc/client/"
+
+func identityOIDCClientResource() *schema.Resource {
+	return &schema.Resource{
+		Create: identityOIDCClientWrite,
+		Update: identityOIDCClientWrite,
+		Delete: identityOIDCClientDelete,
+		Read:   identityOIDCClientRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the client.",
+			},
+
+			"key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "Name of the key used to sign tokens issued to this client.",
+			},
+
+			"redirect_uris": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Redirection URIs allowed for this client.",
+			},
+
+			"assignments": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Names of the assignments of entities and groups allowed to authenticate with this client.",
+			},
+
+			"id_token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "TTL in seconds of the ID tokens issued to this client.",
+			},
+
+			"access_token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "TTL in seconds of the access tokens issued to this client.",
+			},
+
+			"client_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Type of the client: confidential or public.",
+			},
+
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary value that rotates the client credentials when changed.",
+			},
+
+			"client_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the client.",
+			},
+
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Secret of the client. Empty for public clients.",
+			},
+		},
+	}
+}
+
+func identityOIDCClientWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Get("name").(string)
+	path := identityOIDCClientPathPrefix + name
+
+	data := map[string]interface{}{
+		"key":           d.Get("key").(string),
+		"redirect_uris": d.Get("redirect_uris").(*schema.Set).List(),
+		"assignments":   d.Get("assignments").(*schema.Set).List(),
+	}
+	if v, ok := d.GetOk("id_token_ttl"); ok {
+		data["id_token_ttl"] = v.(int)
+	}
+	if v, ok := d.GetOk("access_token_ttl"); ok {
+		data["access_token_ttl"] = v.(int)
+	}
+	if v, ok := d.GetOk("client_type"); ok {
+		data["client_type"] = v.(string)
+	}
+
+	log.Printf("[DEBUG] Writing identity OIDC client %q to Vault", name)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing identity OIDC client %q: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return identityOIDCClientRead(d, meta)
+}
+
+func identityOIDCClientRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Reading identity OIDC client %q from Vault", name)
+	secret, err := client.Logical().Read(identityOIDCClientPathPrefix + name)
+	if err != nil {
+		return fmt.Errorf("error reading identity OIDC client %q: %s", name, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Identity OIDC client %q not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("key", secret.Data["key"])
+	d.Set("redirect_uris", flattenStringList(secret.Data["redirect_uris"]))
+	d.Set("assignments", flattenStringList(secret.Data["assignments"]))
+	d.Set("id_token_ttl", intFromResponse(secret.Data["id_token_ttl"]))
+	d.Set("access_token_ttl", intFromResponse(secret.Data["access_token_ttl"]))
+	d.Set("client_type", secret.Data["client_type"])
+	d.Set("client_id", secret.Data["client_id"])
+	d.Set("client_secret", secret.Data["client_secret"])
+
+	return nil
+}
+
+func identityOIDCClientDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Deleting identity OIDC client %q from Vault", name)
+	if _, err := client.Logical().Delete(identityOIDCClientPathPrefix + name); err != nil {
+		return fmt.Errorf("error deleting identity OIDC client %q: %s", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestIdentityOIDCClient(t *testing.T) {
+	name := acctest.RandomWithPrefix("client")
+	var clientID, clientSecret string
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testIdentityOIDCClientConfig(name, "https://example.com/callback", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_identity_oidc_client.test", "name", name),
+					resource.TestCheckResourceAttr("vault_identity_oidc_client.test", "redirect_uris.#", "1"),
+					resource.TestCheckResourceAttr("vault_identity_oidc_client.test", "id_token_ttl", "3600"),
+					testIdentityOIDCClientCredentials(&clientID, &clientSecret, false),
+				),
+			},
+			{
+				// Updates other than the trigger keep the credentials.
+				Config: testIdentityOIDCClientConfig(name, "https://example.com/other", "1"),
+				Check:  testIdentityOIDCClientCredentials(&clientID, &clientSecret, false),
+			},
+			{
+				Config: testIdentityOIDCClientConfig(name, "https://example.com/other", "2"),
+				Check:  testIdentityOIDCClientCredentials(&clientID, &clientSecret, true),
+			},
+			{
+				ResourceName:            "vault_identity_oidc_client.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotation_trigger"},
+			},
+		},
+	})
+}
+
+// testIdentityOIDCClientCredentials checks whether the client credentials
+// changed since the previous step, and records them for the next one.
+func testIdentityOIDCClientCredentials(clientID, clientSecret *string, wantRotated bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources["vault_identity_oidc_client.test"]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+		attrs := resourceState.Primary.Attributes
+		if attrs["client_secret"] == "" {
+			return fmt.Errorf("expected a client secret")
+		}
+
+		if *clientSecret != "" {
+			rotated := attrs["client_secret"] != *clientSecret
+			if rotated != wantRotated {
+				return fmt.Errorf("expected rotated to be %t, client secret was %q and is %q", wantRotated, *clientSecret, attrs["client_secret"])
+			}
+			if !rotated && attrs["client_id"] != *clientID {
+				return fmt.Errorf("expected client ID %q to be kept, got %q", *clientID, attrs["client_id"])
+			}
+		}
+
+		*clientID = attrs["client_id"]
+		*clientSecret = attrs["client_secret"]
+		return nil
+	}
+}
+
+func testIdentityOIDCClientConfig(name, redirectURI, trigger string) string {
+	return fmt.Sprintf(`
+resource "vault_identity_oidc_client" "test" {
+	name = "%s"
+	redirect_uris = ["%s"]
+	id_token_ttl = 3600
+	rotation_trigger = "%s"
+}
+`, name, redirectURI, trigger)
+}
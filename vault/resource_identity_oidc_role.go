@@ -0,0 +1,208 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const identityOIDCRolePathPrefix = "identity/oidc/role/"
+
+var (
+	identityTemplatePlaceholderRegex = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+	// identityTemplatePathRegexes describe the references Vault accepts
+	// in identity token templates.
+	identityTemplatePathRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`^identity\.entity\.(id|name)$`),
+		regexp.MustCompile(`^identity\.entity\.groups\.(ids|names)$`),
+		regexp.MustCompile(`^identity\.entity\.metadata(\.[^.\s]+)?$`),
+		regexp.MustCompile(`^identity\.entity\.aliases\.[^.\s]+\.(id|name)$`),
+		regexp.MustCompile(`^identity\.entity\.aliases\.[^.\s]+\.(metadata|custom_metadata)(\.[^.\s]+)?$`),
+		regexp.MustCompile(`^identity\.groups\.(ids|names)\.[^.\s]+\.(id|name)$`),
+		regexp.MustCompile(`^identity\.groups\.(ids|names)\.[^.\s]+\.metadata(\.[^.\s]+)?$`),
+		regexp.MustCompile(`^time\.now$`),
+		regexp.MustCompile(`^time\.now\.(plus|minus)\.[0-9]+[smhd]?$`),
+	}
+)
+
+func identityOIDCRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: identityOIDCRoleWrite,
+		Update: identityOIDCRoleWrite,
+		Delete: identityOIDCRoleDelete,
+		Read:   identityOIDCRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the key used to sign tokens issued for this role.",
+			},
+
+			"template": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "JSON template for additional claims of tokens issued for this role.",
+				ValidateFunc: validateIdentityTemplate,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeIdentityTemplate(old) == normalizeIdentityTemplate(new)
+				},
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "TTL in seconds of tokens issued for this role.",
+			},
+
+			"client_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The value used as the audience of tokens issued for this role.",
+			},
+		},
+	}
+}
+
+// validateIdentityTemplate checks that an identity token template renders
+// to a JSON object and warns about references that Vault's templating
+// doesn't know, since those silently render as empty claims.
+func validateIdentityTemplate(v interface{}, k string) (ws []string, errs []error) {
+	template := v.(string)
+	if strings.TrimSpace(template) == "" {
+		return
+	}
+
+	if strings.Count(template, "{{") != strings.Count(template, "}}") {
+		errs = append(errs, fmt.Errorf("%s: unbalanced template delimiters", k))
+		return
+	}
+
+	for _, match := range identityTemplatePlaceholderRegex.FindAllStringSubmatch(template, -1) {
+		if !validIdentityTemplatePath(match[1]) {
+			ws = append(ws, fmt.Sprintf("%s: %q is not a known identity template reference and will render as empty", k, match[1]))
+		}
+	}
+
+	// Placeholders may appear unquoted, e.g. for lists of group names, so
+	// the JSON is checked with every placeholder replaced by a literal.
+	rendered := identityTemplatePlaceholderRegex.ReplaceAllString(template, "null")
+	var claims map[string]interface{}
+	if err := json.Unmarshal([]byte(rendered), &claims); err != nil {
+		errs = append(errs, fmt.Errorf("%s: template is not a valid JSON object: %s", k, err))
+	}
+
+	return
+}
+
+func validIdentityTemplatePath(path string) bool {
+	for _, re := range identityTemplatePathRegexes {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeIdentityTemplate strips the insignificant whitespace from a
+// template, which Vault doesn't preserve. Whitespace is only insignificant
+// outside of JSON strings, and placeholders may appear unquoted, so the
+// template can't be compacted as JSON and is scanned instead.
+func normalizeIdentityTemplate(template string) string {
+	var b strings.Builder
+	inString, escaped := false, false
+	for _, r := range template {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case !inString && unicode.IsSpace(r):
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func identityOIDCRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Get("name").(string)
+	path := identityOIDCRolePathPrefix + name
+
+	data := map[string]interface{}{
+		"key":      d.Get("key").(string),
+		"template": d.Get("template").(string),
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		data["ttl"] = v.(int)
+	}
+
+	log.Printf("[DEBUG] Writing identity OIDC role %q to Vault", name)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing identity OIDC role %q: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return identityOIDCRoleRead(d, meta)
+}
+
+func identityOIDCRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Reading identity OIDC role %q from Vault", name)
+	secret, err := client.Logical().Read(identityOIDCRolePathPrefix + name)
+	if err != nil {
+		return fmt.Errorf("error reading identity OIDC role %q: %s", name, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Identity OIDC role %q not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("key", secret.Data["key"])
+	d.Set("template", secret.Data["template"])
+	d.Set("ttl", intFromResponse(secret.Data["ttl"]))
+	d.Set("client_id", secret.Data["client_id"])
+
+	return nil
+}
+
+func identityOIDCRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Deleting identity OIDC role %q from Vault", name)
+	if _, err := client.Logical().Delete(identityOIDCRolePathPrefix + name); err != nil {
+		return fmt.Errorf("error deleting identity OIDC role %q: %s", name, err)
+	}
+
+	return nil
+}
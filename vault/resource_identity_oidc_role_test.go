@@ -0,0 +1,110 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestValidateIdentityTemplate(t *testing.T) {
+	cases := []struct {
+		template  string
+		wantWarns int
+		wantErrs  int
+	}{
+		{``, 0, 0},
+		{`{"name": {{identity.entity.name}}}`, 0, 0},
+		{`{"team": "{{identity.entity.metadata.team}}"}`, 0, 0},
+		{`{"groups": {{identity.entity.groups.names}}, "id": "{{ identity.entity.id }}"}`, 0, 0},
+		{`{"user": {{identity.entity.aliases.auth_userpass_1234.name}}}`, 0, 0},
+		{`{"nbf": {{time.now}}, "exp": {{time.now.plus.1h}}}`, 0, 0},
+		{`{"group": {{identity.groups.ids.6f1f6bc4-0e3a-4e1c-8c8e-2f5d2a1b9c7d.name}}}`, 0, 0},
+		{`{"group": {{identity.groups.names.admins.id}}, "team": {{identity.groups.names.admins.metadata.team}}}`, 0, 0},
+		{`{"groups": {{identity.groups.ids.6f1f6bc4-0e3a-4e1c-8c8e-2f5d2a1b9c7d.metadata}}}`, 0, 0},
+		{`{"groups": {{identity.groups.names.admins}}}`, 1, 0},
+		{`{"groups": {{identity.groups.name.admins.id}}}`, 1, 0},
+		{`{"team": {{identity.entity.metdata.team}}}`, 1, 0},
+		{`{"team": {{identity.entity.metadata.team}}, "x": {{identity.entity.nmae}}}`, 1, 0},
+		{`{"name": {{identity.entity.name}}`, 0, 1},
+		{`{"name": {{identity.entity.name}`, 0, 1},
+		{`["{{identity.entity.name}}"]`, 0, 1},
+	}
+
+	for _, tc := range cases {
+		ws, errs := validateIdentityTemplate(tc.template, "template")
+		if len(ws) != tc.wantWarns {
+			t.Errorf("%s: got warnings %v; want %d", tc.template, ws, tc.wantWarns)
+		}
+		if len(errs) != tc.wantErrs {
+			t.Errorf("%s: got errors %v; want %d", tc.template, errs, tc.wantErrs)
+		}
+	}
+}
+
+func TestNormalizeIdentityTemplate(t *testing.T) {
+	cases := []struct {
+		old, new string
+		want     bool
+	}{
+		{`{"name": {{identity.entity.name}}}`, "{\n  \"name\":{{identity.entity.name}}\n}", true},
+		{`{"team": "{{identity.entity.metadata.team}}"}`, `{ "team" : "{{identity.entity.metadata.team}}" }`, true},
+		{`{"greeting": "hello world"}`, `{"greeting": "helloworld"}`, false},
+		{`{"quote": "a \" b"}`, `{"quote": "a \"b"}`, false},
+		{`{"quote": "a \\" , "b": 1}`, `{"quote":"a \\","b":1}`, true},
+	}
+
+	for _, tc := range cases {
+		got := normalizeIdentityTemplate(tc.old) == normalizeIdentityTemplate(tc.new)
+		if got != tc.want {
+			t.Errorf("%s and %s: got equal %t; want %t", tc.old, tc.new, got, tc.want)
+		}
+	}
+}
+
+func TestIdentityOIDCRole(t *testing.T) {
+	name := acctest.RandomWithPrefix("role")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testIdentityOIDCRoleConfig(name, `{"team": {{identity.entity.metadata.team}}}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_identity_oidc_role.test", "name", name),
+					resource.TestCheckResourceAttr("vault_identity_oidc_role.test", "ttl", "3600"),
+					resource.TestCheckResourceAttrSet("vault_identity_oidc_role.test", "client_id"),
+				),
+			},
+			{
+				Config: testIdentityOIDCRoleConfig(name, `{"groups": {{identity.entity.groups.names}}}`),
+				Check:  resource.TestCheckResourceAttr("vault_identity_oidc_role.test", "template", `{"groups": {{identity.entity.groups.names}}}`),
+			},
+			{
+				ResourceName:      "vault_identity_oidc_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testIdentityOIDCRoleConfig(name, template string) string {
+	return fmt.Sprintf(`
+resource "vault_generic_secret" "key" {
+	path = "identity/oidc/key/%s"
+	data_json = <<EOT
+{"allowed_client_ids": "*"}
+EOT
+}
+
+resource "vault_identity_oidc_role" "test" {
+	name = "%s"
+	key = "%s"
+	ttl = 3600
+	template = %q
+	depends_on = ["vault_generic_secret.key"]
+}
+`, name, name, name, template)
+}
@@ -0,0 +1,258 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func jwtAuthBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: jwtAuthBackendCreate,
+		Update: jwtAuthBackendUpdate,
+		Delete: jwtAuthBackendDelete,
+		Read:   jwtAuthBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "jwt",
+				Description: "Path to enable the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "jwt",
+				Description: "Type of the backend, jwt or oidc. Both support the same configuration.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					switch v.(string) {
+					case "jwt", "oidc":
+					default:
+						errs = append(errs, fmt.Errorf("%s must be one of jwt or oidc, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the backend.",
+			},
+
+			"oidc_discovery_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "OIDC discovery URL of the provider, without the .well-known/openid-configuration suffix.",
+			},
+
+			"oidc_discovery_ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM-encoded CA certificates of the OIDC discovery URL, the system ones if not set.",
+			},
+
+			"oidc_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Client ID of Vault in the OIDC provider, for OIDC logins.",
+			},
+
+			"oidc_client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Client secret of Vault in the OIDC provider, for OIDC logins.",
+			},
+
+			"jwks_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the JSON Web Key Set the JWTs are validated with.",
+			},
+
+			"jwks_ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM-encoded CA certificates of the JWKS URL, the system ones if not set.",
+			},
+
+			"jwt_validation_pubkeys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "PEM-encoded public keys the JWTs are validated with, when not using a discovery or JWKS URL.",
+			},
+
+			"bound_issuer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Issuer that the JWTs must have.",
+			},
+
+			"default_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Role used when logging in without one.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The accessor of the backend.",
+			},
+		},
+	}
+}
+
+// jwtAuthBackendConfigFields are the string settings of the backend config,
+// excluding the client secret, which Vault never returns.
+var jwtAuthBackendConfigFields = []string{
+	"oidc_discovery_url",
+	"oidc_discovery_ca_pem",
+	"oidc_client_id",
+	"jwks_url",
+	"jwks_ca_pem",
+	"bound_issuer",
+	"default_role",
+}
+
+func jwtAuthBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+	authType := d.Get("type").(string)
+
+	log.Printf("[DEBUG] Enabling JWT auth backend of type %q at %q", authType, path)
+	if err := client.Sys().EnableAuth(path, authType, d.Get("description").(string)); err != nil {
+		return fmt.Errorf("error enabling JWT auth backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if err := jwtAuthBackendWriteConfig(client, d); err != nil {
+		return err
+	}
+
+	return jwtAuthBackendRead(d, meta)
+}
+
+func jwtAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("description") {
+		if err := authBackendTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	changed := d.HasChange("oidc_client_secret") || d.HasChange("jwt_validation_pubkeys")
+	for _, k := range jwtAuthBackendConfigFields {
+		changed = changed || d.HasChange(k)
+	}
+	if changed {
+		if err := jwtAuthBackendWriteConfig(client, d); err != nil {
+			return err
+		}
+	}
+
+	return jwtAuthBackendRead(d, meta)
+}
+
+// jwtAuthBackendWriteConfig writes the key sources and defaults of the
+// backend, which are replaced all together on every write.
+func jwtAuthBackendWriteConfig(client *api.Client, d *schema.ResourceData) error {
+	path := "auth/" + d.Id() + "/config"
+
+	data := map[string]interface{}{
+		"oidc_client_secret":     d.Get("oidc_client_secret").(string),
+		"jwt_validation_pubkeys": toStringArray(d.Get("jwt_validation_pubkeys").([]interface{})),
+	}
+	for _, k := range jwtAuthBackendConfigFields {
+		data[k] = d.Get(k).(string)
+	}
+
+	log.Printf("[DEBUG] Writing JWT auth backend config %q", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing JWT auth backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func jwtAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading JWT auth backend %q", path)
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("error reading auth backends from Vault: %s", err)
+	}
+	auth, ok := auths[path+"/"]
+	if !ok {
+		log.Printf("[WARN] JWT auth backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("type", auth.Type)
+	d.Set("description", auth.Description)
+
+	config, err := client.Logical().Read("auth/" + path + "/config")
+	if err != nil {
+		return fmt.Errorf("error reading JWT auth backend config %q: %s", path, err)
+	}
+	if config != nil {
+		// Settings missing in older versions of the plugin are kept from
+		// the configuration.
+		for _, k := range jwtAuthBackendConfigFields {
+			if v, ok := config.Data[k]; ok {
+				d.Set(k, v)
+			}
+		}
+		if err := d.Set("jwt_validation_pubkeys", flattenStringList(config.Data["jwt_validation_pubkeys"])); err != nil {
+			return fmt.Errorf("error setting jwt_validation_pubkeys of JWT auth backend %q: %s", path, err)
+		}
+	}
+
+	accessor, err := authBackendAccessor(client, path)
+	if err != nil {
+		return err
+	}
+	d.Set("accessor", accessor)
+
+	return nil
+}
+
+func jwtAuthBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Disabling JWT auth backend %q", path)
+	if err := client.Sys().DisableAuth(path); err != nil {
+		return fmt.Errorf("error disabling JWT auth backend %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var jwtAuthBackendRoleFromPathRegex = regexp.MustCompile("^auth/(.+)/role/([^/]+)$")
+
+func jwtAuthBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: jwtAuthBackendRoleWrite,
+		Update: jwtAuthBackendRoleWrite,
+		Delete: jwtAuthBackendRoleDelete,
+		Read:   jwtAuthBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "jwt",
+				Description: "Path of the JWT auth backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"role_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"role_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Type of the role, jwt for logins with a JWT or oidc for OIDC single sign-on.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					switch v.(string) {
+					case "jwt", "oidc":
+					default:
+						errs = append(errs, fmt.Errorf("%s must be one of jwt or oidc, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"user_claim": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Claim used as the name of the entity alias of the users.",
+			},
+
+			"groups_claim": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Claim used as the names of the group aliases of the users.",
+			},
+
+			"bound_subject": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Subject that the JWTs must have.",
+			},
+
+			"bound_audiences": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Audiences the JWTs must have one of.",
+			},
+
+			"bound_claims": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Claims the JWTs must have, with their values.",
+			},
+
+			"claim_mappings": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Claims copied to the metadata of the entity aliases, with the metadata keys they are copied to.",
+			},
+
+			"allowed_redirect_uris": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Redirect URIs allowed in the OIDC authentication flow.",
+			},
+
+			"oidc_scopes": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "OIDC scopes requested in addition to openid.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies of the tokens issued for the role.",
+			},
+
+			"token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_bound_cidrs": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "CIDR blocks of the addresses allowed to use the tokens issued for the role.",
+			},
+
+			"period": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Period in seconds of the tokens issued for the role, making them periodic if set.",
+			},
+		},
+	}
+}
+
+func jwtAuthBackendRolePath(backend, name string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/role/" + strings.Trim(name, "/")
+}
+
+func jwtAuthBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := jwtAuthBackendRolePath(d.Get("backend").(string), d.Get("role_name").(string))
+
+	data := map[string]interface{}{
+		"user_claim":            d.Get("user_claim").(string),
+		"groups_claim":          d.Get("groups_claim").(string),
+		"bound_subject":         d.Get("bound_subject").(string),
+		"bound_audiences":       d.Get("bound_audiences").(*schema.Set).List(),
+		"bound_claims":          d.Get("bound_claims").(map[string]interface{}),
+		"claim_mappings":        d.Get("claim_mappings").(map[string]interface{}),
+		"allowed_redirect_uris": d.Get("allowed_redirect_uris").(*schema.Set).List(),
+		"oidc_scopes":           d.Get("oidc_scopes").(*schema.Set).List(),
+		"policies":              d.Get("policies").(*schema.Set).List(),
+		"ttl":                   d.Get("token_ttl").(int),
+		"max_ttl":               d.Get("token_max_ttl").(int),
+		"period":                d.Get("period").(int),
+		"bound_cidrs":           d.Get("token_bound_cidrs").(*schema.Set).List(),
+	}
+	// The default type of the role depends on the version of the plugin.
+	if v, ok := d.GetOk("role_type"); ok {
+		data["role_type"] = v.(string)
+	}
+
+	log.Printf("[DEBUG] Writing JWT auth backend role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing JWT auth backend role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return jwtAuthBackendRoleRead(d, meta)
+}
+
+func jwtAuthBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := jwtAuthBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid JWT auth backend role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading JWT auth backend role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading JWT auth backend role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] JWT auth backend role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("role_name", res[2])
+	d.Set("role_type", secret.Data["role_type"])
+	d.Set("user_claim", secret.Data["user_claim"])
+	d.Set("groups_claim", secret.Data["groups_claim"])
+	d.Set("bound_subject", secret.Data["bound_subject"])
+	d.Set("token_ttl", intFromResponse(tokenSetting(secret.Data, "token_ttl", "ttl")))
+	d.Set("token_max_ttl", intFromResponse(tokenSetting(secret.Data, "token_max_ttl", "max_ttl")))
+	d.Set("period", intFromResponse(tokenSetting(secret.Data, "token_period", "period")))
+
+	for _, k := range []string{"bound_claims", "claim_mappings"} {
+		if err := d.Set(k, secret.Data[k]); err != nil {
+			return fmt.Errorf("error setting %s of JWT auth backend role %q: %s", k, path, err)
+		}
+	}
+
+	sets := map[string]interface{}{
+		"bound_audiences":       secret.Data["bound_audiences"],
+		"allowed_redirect_uris": secret.Data["allowed_redirect_uris"],
+		"oidc_scopes":           secret.Data["oidc_scopes"],
+		"policies":              tokenSetting(secret.Data, "token_policies", "policies"),
+		"token_bound_cidrs":     tokenSetting(secret.Data, "token_bound_cidrs", "bound_cidrs"),
+	}
+	for k, v := range sets {
+		if err := d.Set(k, flattenStringList(v)); err != nil {
+			return fmt.Errorf("error setting %s of JWT auth backend role %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func jwtAuthBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting JWT auth backend role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting JWT auth backend role %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestJWTAuthBackendRole(t *testing.T) {
+	backend := acctest.RandomWithPrefix("jwt")
+	pubkey := testJWTAuthBackendPublicKey(t)
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testJWTAuthBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testJWTAuthBackendRoleConfig(backend, pubkey, `
+	role_type = "jwt"
+	user_claim = "sub"
+	bound_audiences = ["vault"]
+	bound_claims = {
+		team = "dev"
+	}
+	policies = ["default", "dev"]
+	token_ttl = 300
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "id", "auth/"+backend+"/role/test"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "role_type", "jwt"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "user_claim", "sub"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "bound_audiences.#", "1"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "bound_claims.%", "1"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "bound_claims.team", "dev"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "token_ttl", "300"),
+				),
+			},
+			{
+				Config: testJWTAuthBackendRoleConfig(backend, pubkey, `
+	role_type = "jwt"
+	user_claim = "email"
+	groups_claim = "groups"
+	bound_audiences = ["vault", "other"]
+	claim_mappings = {
+		name = "display_name"
+	}
+	policies = ["dev"]
+	token_ttl = 600
+	token_max_ttl = 1200
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "user_claim", "email"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "groups_claim", "groups"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "bound_audiences.#", "2"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "bound_claims.%", "0"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "claim_mappings.name", "display_name"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend_role.test", "token_max_ttl", "1200"),
+				),
+			},
+			{
+				ResourceName:      "vault_jwt_auth_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testJWTAuthBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_jwt_auth_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for JWT auth backend role %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("JWT auth backend role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testJWTAuthBackendRoleConfig(backend, pubkey, fields string) string {
+	return fmt.Sprintf(`
+resource "vault_jwt_auth_backend" "jwt" {
+	path = "%s"
+	jwt_validation_pubkeys = [%q]
+}
+
+resource "vault_jwt_auth_backend_role" "test" {
+	backend = "${vault_jwt_auth_backend.jwt.path}"
+	role_name = "test"
+%s
+}
+`, backend, pubkey, fields)
+}
@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+// The backends are configured with validation public keys, as discovery and
+// JWKS URLs are fetched by Vault when written.
+func TestJWTAuthBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("jwt")
+	pubkey := testJWTAuthBackendPublicKey(t)
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testJWTAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testJWTAuthBackendConfig(path, "test", pubkey, "https://issuer.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "type", "jwt"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "description", "test"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "jwt_validation_pubkeys.#", "1"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "jwt_validation_pubkeys.0", pubkey),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "bound_issuer", "https://issuer.example.com"),
+					resource.TestCheckResourceAttrSet("vault_jwt_auth_backend.test", "accessor"),
+				),
+			},
+			{
+				Config: testJWTAuthBackendConfig(path, "updated", pubkey, "https://other.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "description", "updated"),
+					resource.TestCheckResourceAttr("vault_jwt_auth_backend.test", "bound_issuer", "https://other.example.com"),
+				),
+			},
+			{
+				ResourceName:      "vault_jwt_auth_backend.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testJWTAuthBackendPublicKey returns a PEM-encoded RSA public key.
+func testJWTAuthBackendPublicKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func testJWTAuthBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_jwt_auth_backend" {
+			continue
+		}
+		if _, ok := auths[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("JWT auth backend %q still enabled", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testJWTAuthBackendConfig(path, description, pubkey, issuer string) string {
+	return fmt.Sprintf(`
+resource "vault_jwt_auth_backend" "test" {
+	path = "%s"
+	description = "%s"
+	jwt_validation_pubkeys = [%q]
+	bound_issuer = "%s"
+}
+`, path, description, pubkey, issuer)
+}
@@ -0,0 +1,162 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var kubernetesAuthBackendConfigFromPathRegex = regexp.MustCompile("^auth/(.+)/config$")
+
+func kubernetesAuthBackendConfigResource() *schema.Resource {
+	return &schema.Resource{
+		Create: kubernetesAuthBackendConfigWrite,
+		Update: kubernetesAuthBackendConfigWrite,
+		Delete: kubernetesAuthBackendConfigDelete,
+		Read:   kubernetesAuthBackendConfigRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "kubernetes",
+				Description: "Path of the Kubernetes auth backend to configure.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"kubernetes_host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "URL of the Kubernetes API server, e.g. https://192.168.99.100:8443.",
+			},
+
+			"kubernetes_ca_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM-encoded CA certificate of the Kubernetes API server.",
+			},
+
+			"token_reviewer_jwt": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "JWT of the service account used to review the tokens of the clients logging in.",
+			},
+
+			"pem_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "PEM-encoded public keys used to verify the signatures of service account tokens.",
+			},
+
+			"issuer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expected issuer of the service account tokens.",
+			},
+
+			"disable_iss_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't validate the issuer of service account tokens.",
+			},
+
+			"disable_local_ca_jwt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't use the CA certificate and service account token of the pod Vault runs in as defaults.",
+			},
+		},
+	}
+}
+
+func kubernetesAuthBackendConfigPath(backend string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/config"
+}
+
+func kubernetesAuthBackendConfigWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := kubernetesAuthBackendConfigPath(d.Get("backend").(string))
+
+	data := map[string]interface{}{
+		"kubernetes_host":        d.Get("kubernetes_host").(string),
+		"kubernetes_ca_cert":     d.Get("kubernetes_ca_cert").(string),
+		"pem_keys":               toStringArray(d.Get("pem_keys").([]interface{})),
+		"issuer":                 d.Get("issuer").(string),
+		"disable_iss_validation": d.Get("disable_iss_validation").(bool),
+		"disable_local_ca_jwt":   d.Get("disable_local_ca_jwt").(bool),
+	}
+	// The JWT is never returned by Vault, so it is only sent when it
+	// changed, leaving it in place otherwise.
+	if d.IsNewResource() || d.HasChange("token_reviewer_jwt") {
+		data["token_reviewer_jwt"] = d.Get("token_reviewer_jwt").(string)
+	}
+
+	log.Printf("[DEBUG] Writing Kubernetes auth backend config %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing Kubernetes auth backend config %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return kubernetesAuthBackendConfigRead(d, meta)
+}
+
+func kubernetesAuthBackendConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := kubernetesAuthBackendConfigFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid Kubernetes auth backend config ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading Kubernetes auth backend config %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading Kubernetes auth backend config %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Kubernetes auth backend config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("kubernetes_host", secret.Data["kubernetes_host"])
+	d.Set("kubernetes_ca_cert", secret.Data["kubernetes_ca_cert"])
+	d.Set("issuer", secret.Data["issuer"])
+	if v, ok := secret.Data["disable_iss_validation"]; ok {
+		d.Set("disable_iss_validation", v)
+	}
+	if v, ok := secret.Data["disable_local_ca_jwt"]; ok {
+		d.Set("disable_local_ca_jwt", v)
+	}
+	if err := d.Set("pem_keys", flattenStringList(secret.Data["pem_keys"])); err != nil {
+		return fmt.Errorf("error setting pem_keys of Kubernetes auth backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+// kubernetesAuthBackendConfigDelete only removes the config from the state,
+// as the backend has no way to delete it. Disabling the backend does.
+func kubernetesAuthBackendConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Leaving Kubernetes auth backend config %q in Vault", d.Id())
+	return nil
+}
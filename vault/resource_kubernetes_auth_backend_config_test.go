@@ -0,0 +1,64 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestKubernetesAuthBackendConfig(t *testing.T) {
+	backend := acctest.RandomWithPrefix("kubernetes")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKubernetesAuthBackendConfigConfig(backend, `
+	kubernetes_host = "https://192.168.99.100:8443"
+	token_reviewer_jwt = "reviewer-jwt"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_config.test", "id", "auth/"+backend+"/config"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_config.test", "kubernetes_host", "https://192.168.99.100:8443"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_config.test", "token_reviewer_jwt", "reviewer-jwt"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_config.test", "disable_iss_validation", "false"),
+				),
+			},
+			{
+				Config: testKubernetesAuthBackendConfigConfig(backend, `
+	kubernetes_host = "https://k8s.example.com"
+	token_reviewer_jwt = "reviewer-jwt"
+	issuer = "kubernetes/serviceaccount"
+	disable_iss_validation = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_config.test", "kubernetes_host", "https://k8s.example.com"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_config.test", "issuer", "kubernetes/serviceaccount"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_config.test", "disable_iss_validation", "true"),
+				),
+			},
+			{
+				ResourceName:            "vault_kubernetes_auth_backend_config.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token_reviewer_jwt"},
+			},
+		},
+	})
+}
+
+func testKubernetesAuthBackendConfigConfig(backend, settings string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "kubernetes" {
+	type = "kubernetes"
+	path = "%s"
+}
+
+resource "vault_kubernetes_auth_backend_config" "test" {
+	backend = "${vault_auth_backend.kubernetes.path}"
+%s
+}
+`, backend, settings)
+}
@@ -0,0 +1,187 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var kubernetesAuthBackendRoleFromPathRegex = regexp.MustCompile("^auth/(.+)/role/([^/]+)$")
+
+func kubernetesAuthBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: kubernetesAuthBackendRoleWrite,
+		Update: kubernetesAuthBackendRoleWrite,
+		Delete: kubernetesAuthBackendRoleDelete,
+		Read:   kubernetesAuthBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "kubernetes",
+				Description: "Path of the Kubernetes auth backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"role_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"bound_service_account_names": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Names of the service accounts allowed to log in, or * for any.",
+			},
+
+			"bound_service_account_namespaces": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Namespaces of the service accounts allowed to log in, or * for any.",
+			},
+
+			"audience": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Audience that the service account tokens must have.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies of the tokens issued for the role.",
+			},
+
+			"token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL of the tokens issued for the role in seconds.",
+			},
+
+			"token_bound_cidrs": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "CIDR blocks of the addresses allowed to use the tokens issued for the role.",
+			},
+
+			"period": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Period in seconds of the tokens issued for the role, making them periodic if set.",
+			},
+		},
+	}
+}
+
+func kubernetesAuthBackendRolePath(backend, name string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/role/" + strings.Trim(name, "/")
+}
+
+func kubernetesAuthBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := kubernetesAuthBackendRolePath(d.Get("backend").(string), d.Get("role_name").(string))
+
+	data := map[string]interface{}{
+		"bound_service_account_names":      d.Get("bound_service_account_names").(*schema.Set).List(),
+		"bound_service_account_namespaces": d.Get("bound_service_account_namespaces").(*schema.Set).List(),
+		"policies":                         d.Get("policies").(*schema.Set).List(),
+		"ttl":                              d.Get("token_ttl").(int),
+		"max_ttl":                          d.Get("token_max_ttl").(int),
+		"period":                           d.Get("period").(int),
+		"bound_cidrs":                      d.Get("token_bound_cidrs").(*schema.Set).List(),
+		"audience":                         d.Get("audience").(string),
+	}
+
+	log.Printf("[DEBUG] Writing Kubernetes auth backend role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing Kubernetes auth backend role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return kubernetesAuthBackendRoleRead(d, meta)
+}
+
+func kubernetesAuthBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := kubernetesAuthBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid Kubernetes auth backend role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading Kubernetes auth backend role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading Kubernetes auth backend role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Kubernetes auth backend role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("role_name", res[2])
+	d.Set("audience", secret.Data["audience"])
+	d.Set("token_ttl", intFromResponse(tokenSetting(secret.Data, "token_ttl", "ttl")))
+	d.Set("token_max_ttl", intFromResponse(tokenSetting(secret.Data, "token_max_ttl", "max_ttl")))
+	d.Set("period", intFromResponse(tokenSetting(secret.Data, "token_period", "period")))
+
+	sets := map[string]interface{}{
+		"bound_service_account_names":      secret.Data["bound_service_account_names"],
+		"bound_service_account_namespaces": secret.Data["bound_service_account_namespaces"],
+		"policies":                         tokenSetting(secret.Data, "token_policies", "policies"),
+		"token_bound_cidrs":                tokenSetting(secret.Data, "token_bound_cidrs", "bound_cidrs"),
+	}
+	for k, v := range sets {
+		if err := d.Set(k, flattenStringList(v)); err != nil {
+			return fmt.Errorf("error setting %s of Kubernetes auth backend role %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func kubernetesAuthBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting Kubernetes auth backend role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting Kubernetes auth backend role %q: %s", path, err)
+	}
+
+	return nil
+}
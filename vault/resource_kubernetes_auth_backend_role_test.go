@@ -0,0 +1,92 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestKubernetesAuthBackendRole(t *testing.T) {
+	backend := acctest.RandomWithPrefix("kubernetes")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testKubernetesAuthBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKubernetesAuthBackendRoleConfig(backend, `
+	bound_service_account_names = ["app"]
+	bound_service_account_namespaces = ["default", "staging"]
+	policies = ["default", "dev"]
+	token_ttl = 300
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "id", "auth/"+backend+"/role/test"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "bound_service_account_names.#", "1"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "bound_service_account_namespaces.#", "2"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "token_ttl", "300"),
+				),
+			},
+			{
+				Config: testKubernetesAuthBackendRoleConfig(backend, `
+	bound_service_account_names = ["*"]
+	bound_service_account_namespaces = ["default"]
+	policies = ["dev"]
+	token_ttl = 600
+	token_max_ttl = 1200
+	token_bound_cidrs = ["10.0.0.0/8"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "bound_service_account_namespaces.#", "1"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "token_ttl", "600"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "token_max_ttl", "1200"),
+					resource.TestCheckResourceAttr("vault_kubernetes_auth_backend_role.test", "token_bound_cidrs.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_kubernetes_auth_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testKubernetesAuthBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_kubernetes_auth_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for Kubernetes auth backend role %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("Kubernetes auth backend role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testKubernetesAuthBackendRoleConfig(backend, settings string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "kubernetes" {
+	type = "kubernetes"
+	path = "%s"
+}
+
+resource "vault_kubernetes_auth_backend_role" "test" {
+	backend = "${vault_auth_backend.kubernetes.path}"
+	role_name = "test"
+%s
+}
+`, backend, settings)
+}
@@ -0,0 +1,82 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func kvSecretSubtreeResource() *schema.Resource {
+	return &schema.Resource{
+		Create: kvSecretSubtreeCreate,
+		Delete: kvSecretSubtreeDelete,
+		Read:   kvSecretSubtreeRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Prefix under which all secrets are deleted when this resource is destroyed.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+		},
+	}
+}
+
+func kvSecretSubtreeCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(strings.Trim(d.Get("path").(string), "/"))
+
+	return kvSecretSubtreeRead(d, meta)
+}
+
+func kvSecretSubtreeRead(d *schema.ResourceData, meta interface{}) error {
+	// The subtree only exists to be torn down, so there is nothing in
+	// Vault to refresh it from.
+	d.Set("path", d.Id())
+
+	return nil
+}
+
+func kvSecretSubtreeDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	prefix := d.Id()
+
+	mount, err := kvMountForPath(client, prefix)
+	if err != nil {
+		return err
+	}
+
+	paths, err := listKVSubtree(client, mount, prefix)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting %d secrets under %q from Vault", len(paths), prefix)
+
+	// Keep going on failures so that a single undeletable secret doesn't
+	// leave the rest of the tree behind, and report every failure at once.
+	var result error
+	for _, path := range paths {
+		// For KV version 2 deleting the metadata destroys all versions of
+		// the secret; deleting the data would only soft-delete the latest.
+		if _, err := client.Logical().Delete(mount.metadataPath(path)); err != nil {
+			result = multierror.Append(result, fmt.Errorf("error deleting %q: %s", path, err))
+		}
+	}
+	if result != nil {
+		return fmt.Errorf("error deleting secrets under %q from Vault: %s", prefix, result)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestResourceKVSecretSubtree(t *testing.T) {
+	prefix := "secret/" + acctest.RandomWithPrefix("subtree")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testResourceKVSecretSubtree_checkDestroy(prefix),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "vault_kv_secret_subtree" "test" {
+	path = "%s"
+}
+`, prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kv_secret_subtree.test", "path", prefix),
+					testResourceKVSecretSubtree_populate(prefix),
+				),
+			},
+		},
+	})
+}
+
+// testResourceKVSecretSubtree_populate writes secrets under the prefix
+// out-of-band, as an application would, so that destroy has to find them.
+func testResourceKVSecretSubtree_populate(prefix string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		mount, err := kvMountForPath(client, prefix)
+		if err != nil {
+			return err
+		}
+		for _, p := range []string{"a", "nested/b", "nested/deeper/c"} {
+			_, err := client.Logical().Write(mount.dataPath(prefix+"/"+p), kvWriteData(mount, map[string]interface{}{"zip": "zap"}))
+			if err != nil {
+				return fmt.Errorf("error writing %q: %s", p, err)
+			}
+		}
+		return nil
+	}
+}
+
+func testResourceKVSecretSubtree_checkDestroy(prefix string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		mount, err := kvMountForPath(client, prefix)
+		if err != nil {
+			return err
+		}
+		paths, err := listKVSubtree(client, mount, prefix)
+		if err != nil {
+			return err
+		}
+		if len(paths) != 0 {
+			return fmt.Errorf("secrets remain under %q: %v", prefix, paths)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,251 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var kvSecretV2IDRegex = regexp.MustCompile("^(.+?)/data/(.+)$")
+
+func kvSecretV2Resource() *schema.Resource {
+	return &schema.Resource{
+		Create: kvSecretV2Write,
+		Update: kvSecretV2Write,
+		Delete: kvSecretV2Delete,
+		Read:   kvSecretV2Read,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"mount": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the KV version 2 mount.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the secret, relative to the mount.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"data_json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "JSON-encoded secret data to write.",
+				StateFunc:    NormalizeDataJSON,
+				ValidateFunc: ValidateDataJSON,
+			},
+
+			"custom_metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary string metadata of the secret.",
+			},
+
+			"max_versions": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of versions kept, 0 for the default of the mount.",
+			},
+
+			"cas_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether writes must use check-and-set.",
+			},
+
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the secret data last written or read, the one replaced by check-and-set writes.",
+			},
+
+			"disable_read": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't read the secret data back, only its metadata.",
+			},
+		},
+	}
+}
+
+// kvSecretV2Mount returns the KV mount given in the configuration.
+func kvSecretV2Mount(d *schema.ResourceData) *kvMount {
+	return &kvMount{
+		Path:    strings.Trim(d.Get("mount").(string), "/") + "/",
+		Version: 2,
+	}
+}
+
+func kvSecretV2Write(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	mount := kvSecretV2Mount(d)
+	secretPath := mount.Path + strings.Trim(d.Get("name").(string), "/")
+	path := mount.dataPath(secretPath)
+
+	if d.IsNewResource() || d.HasChange("data_json") {
+		data, err := decodeDataJSON(d.Get("data_json").(string))
+		if err != nil {
+			return fmt.Errorf("data_json syntax error: %s", err)
+		}
+		body := kvWriteData(mount, data)
+
+		// With check-and-set required, updates must name the version they
+		// replace, which is the one in the state so that versions written
+		// since it was read make the write fail. New secrets have no
+		// version yet, and their metadata is only written afterwards.
+		if !d.IsNewResource() && d.Get("cas_required").(bool) {
+			body["options"] = map[string]interface{}{
+				"cas": d.Get("version").(int),
+			}
+		}
+
+		log.Printf("[DEBUG] Writing KV secret %q to Vault", path)
+		resp, err := client.Logical().Write(path, body)
+		if err != nil {
+			return fmt.Errorf("error writing KV secret %q: %s", path, err)
+		}
+		if resp != nil {
+			d.Set("version", intFromResponse(resp.Data["version"]))
+		}
+	}
+
+	d.SetId(path)
+
+	if d.IsNewResource() || d.HasChange("custom_metadata") || d.HasChange("max_versions") || d.HasChange("cas_required") {
+		metadataPath := mount.metadataPath(secretPath)
+		data := map[string]interface{}{
+			"custom_metadata": d.Get("custom_metadata").(map[string]interface{}),
+		}
+		// Changes back to 0 or false have to be sent too, or Vault keeps
+		// the previous value.
+		for _, k := range []string{"max_versions", "cas_required"} {
+			if v, ok := d.GetOk(k); ok || d.HasChange(k) {
+				data[k] = v
+			}
+		}
+
+		log.Printf("[DEBUG] Writing KV metadata %q to Vault", metadataPath)
+		if _, err := client.Logical().Write(metadataPath, data); err != nil {
+			return fmt.Errorf("error writing KV metadata %q: %s", metadataPath, err)
+		}
+	}
+
+	return kvSecretV2Read(d, meta)
+}
+
+func kvSecretV2Read(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := kvSecretV2IDRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid KV secret ID %q, expected <mount>/data/<name>", path)
+	}
+	mount := &kvMount{Path: res[1] + "/", Version: 2}
+	secretPath := mount.Path + res[2]
+	metadataPath := mount.metadataPath(secretPath)
+
+	log.Printf("[DEBUG] Reading KV metadata %q from Vault", metadataPath)
+	metadata, err := client.Logical().Read(metadataPath)
+	if err != nil {
+		return fmt.Errorf("error reading KV metadata %q: %s", metadataPath, err)
+	}
+	if metadata == nil {
+		log.Printf("[WARN] KV secret %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("mount", res[1])
+	d.Set("name", res[2])
+	d.Set("max_versions", intFromResponse(metadata.Data["max_versions"]))
+	d.Set("cas_required", metadata.Data["cas_required"])
+	if v, ok := metadata.Data["custom_metadata"].(map[string]interface{}); ok {
+		d.Set("custom_metadata", v)
+	} else {
+		d.Set("custom_metadata", map[string]interface{}{})
+	}
+
+	// Without read access to the data, the configured data is kept as is
+	// and changes made outside of Terraform go unnoticed, so the version
+	// is only taken from the metadata when there is none yet, as on import.
+	if d.Get("disable_read").(bool) {
+		if d.Get("version").(int) == 0 {
+			d.Set("version", intFromResponse(metadata.Data["current_version"]))
+		}
+		return nil
+	}
+
+	log.Printf("[DEBUG] Reading KV secret %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading KV secret %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] KV secret %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	// Deleted versions are returned with no data. They are reported as
+	// empty, so that the next apply writes the data again.
+	data, _ := secret.Data["data"].(map[string]interface{})
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON for %q: %s", path, err)
+	}
+	d.Set("data_json", string(dataJSON))
+
+	if v, ok := secret.Data["metadata"].(map[string]interface{}); ok {
+		d.Set("version", intFromResponse(v["version"]))
+	}
+
+	return nil
+}
+
+func kvSecretV2Delete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := kvSecretV2IDRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid KV secret ID %q, expected <mount>/data/<name>", path)
+	}
+	mount := &kvMount{Path: res[1] + "/", Version: 2}
+	metadataPath := mount.metadataPath(mount.Path + res[2])
+
+	// Deleting the metadata destroys all versions of the secret.
+	log.Printf("[DEBUG] Deleting KV secret %q from Vault", metadataPath)
+	if _, err := client.Logical().Delete(metadataPath); err != nil {
+		return fmt.Errorf("error deleting KV secret %q: %s", metadataPath, err)
+	}
+
+	return nil
+}
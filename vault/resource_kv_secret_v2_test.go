@@ -0,0 +1,192 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestKVSecretV2(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKVSecretV2Config(mount, `{"zip": "zap"}`, "team-a", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "data_json", `{"zip":"zap"}`),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "custom_metadata.owner", "team-a"),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "max_versions", "5"),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "version", "1"),
+				),
+			},
+			{
+				Config: testKVSecretV2Config(mount, `{"zip": "zoop"}`, "team-b", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "data_json", `{"zip":"zoop"}`),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "custom_metadata.owner", "team-b"),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "version", "2"),
+				),
+			},
+			{
+				ResourceName:            "vault_kv_secret_v2.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disable_read"},
+			},
+		},
+	})
+}
+
+func TestKVSecretV2_disableRead(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKVSecretV2Config(mount, `{"zip": "zap"}`, "team-a", true),
+			},
+			{
+				// Changes to the data made outside of Terraform aren't seen,
+				// but metadata is still reconciled.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					_, err := client.Logical().Write(mount+"/data/test", map[string]interface{}{
+						"data": map[string]interface{}{"zip": "external"},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   testKVSecretV2Config(mount, `{"zip": "zap"}`, "team-a", true),
+				PlanOnly: true,
+			},
+			{
+				Config: testKVSecretV2Config(mount, `{"zip": "zap"}`, "team-b", true),
+				Check:  resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "custom_metadata.owner", "team-b"),
+			},
+		},
+	})
+}
+
+// Check-and-set writes replace the version in the state, so they fail when
+// another version was written since, which the test can only arrange when
+// the data isn't read back.
+func TestKVSecretV2_casRequired(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKVSecretV2Config_casRequired(mount, `{"zip": "zap"}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "cas_required", "true"),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "version", "1"),
+				),
+			},
+			{
+				Config: testKVSecretV2Config_casRequired(mount, `{"zip": "zoop"}`),
+				Check:  resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "version", "2"),
+			},
+			{
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					_, err := client.Logical().Write(mount+"/data/test", map[string]interface{}{
+						"data":    map[string]interface{}{"zip": "external"},
+						"options": map[string]interface{}{"cas": 2},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      testKVSecretV2Config_casRequired(mount, `{"zip": "zap"}`),
+				ExpectError: regexp.MustCompile("check-and-set"),
+			},
+		},
+	})
+}
+
+func TestKVSecretV2_metadataReset(t *testing.T) {
+	mount := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKVSecretV2Config_metadata(mount, 5, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "max_versions", "5"),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "cas_required", "true"),
+				),
+			},
+			{
+				Config: testKVSecretV2Config_metadata(mount, 0, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "max_versions", "0"),
+					resource.TestCheckResourceAttr("vault_kv_secret_v2.test", "cas_required", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testKVSecretV2Config(mount, data, owner string, disableRead bool) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+	path = "%s"
+	type = "kv-v2"
+}
+
+resource "vault_kv_secret_v2" "test" {
+	mount = "${vault_mount.kv.path}"
+	name = "test"
+	data_json = %q
+	max_versions = 5
+	disable_read = %t
+	custom_metadata {
+		owner = "%s"
+	}
+}
+`, mount, data, disableRead, owner)
+}
+
+func testKVSecretV2Config_casRequired(mount, data string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+	path = "%s"
+	type = "kv-v2"
+}
+
+resource "vault_kv_secret_v2" "test" {
+	mount = "${vault_mount.kv.path}"
+	name = "test"
+	data_json = %q
+	cas_required = true
+	disable_read = true
+}
+`, mount, data)
+}
+
+func testKVSecretV2Config_metadata(mount string, maxVersions int, casRequired bool) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "kv" {
+	path = "%s"
+	type = "kv-v2"
+}
+
+resource "vault_kv_secret_v2" "test" {
+	mount = "${vault_mount.kv.path}"
+	name = "test"
+	data_json = "{\"zip\": \"zap\"}"
+	max_versions = %d
+	cas_required = %t
+}
+`, mount, maxVersions, casRequired)
+}
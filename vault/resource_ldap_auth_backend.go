@@ -0,0 +1,314 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// ldapAuthBackendStringFields and ldapAuthBackendBoolFields are the
+// settings of the backend that are written to and read from its config as
+// is.
+var (
+	ldapAuthBackendStringFields = []string{
+		"url",
+		"tls_min_version",
+		"tls_max_version",
+		"certificate",
+		"binddn",
+		"userdn",
+		"userattr",
+		"upndomain",
+		"groupfilter",
+		"groupdn",
+		"groupattr",
+	}
+	ldapAuthBackendBoolFields = []string{
+		"starttls",
+		"insecure_tls",
+		"discoverdn",
+		"deny_null_bind",
+	}
+)
+
+func ldapAuthBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: ldapAuthBackendCreate,
+		Update: ldapAuthBackendUpdate,
+		Delete: ldapAuthBackendDelete,
+		Read:   ldapAuthBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ldap",
+				Description: "Path to enable the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the backend.",
+			},
+
+			"url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "URL of the LDAP server, e.g. ldaps://ldap.example.com, or a comma-separated list of URLs tried in order.",
+			},
+
+			"starttls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Issue a StartTLS command after connecting to the server.",
+			},
+
+			"tls_min_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Minimum TLS version of the connection, e.g. tls12.",
+			},
+
+			"tls_max_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TLS version of the connection, e.g. tls12.",
+			},
+
+			"insecure_tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the verification of the certificate of the server.",
+			},
+
+			"certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "PEM-encoded CA certificate used to verify the certificate of the server.",
+			},
+
+			"binddn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "DN of the object to bind as to search for users and groups.",
+			},
+
+			"bindpass": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of binddn.",
+			},
+
+			"userdn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Base DN under which to search for users.",
+			},
+
+			"userattr": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Attribute of user objects matching the username given when logging in, e.g. uid or sAMAccountName.",
+			},
+
+			"discoverdn": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Discover the DN of users with an anonymous search before binding as them.",
+			},
+
+			"deny_null_bind": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Reject logins with an empty password, which many servers accept as anonymous binds.",
+			},
+
+			"upndomain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "userPrincipalDomain used to bind as users with Active Directory.",
+			},
+
+			"groupfilter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Go template of the LDAP filter used to search for the groups of a user.",
+			},
+
+			"groupdn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Base DN under which to search for groups.",
+			},
+
+			"groupattr": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Attribute of group objects holding the group names, e.g. cn.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The accessor of the backend.",
+			},
+		},
+	}
+}
+
+func ldapAuthBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Enabling LDAP auth backend at %q", path)
+	if err := client.Sys().EnableAuth(path, "ldap", d.Get("description").(string)); err != nil {
+		return fmt.Errorf("error enabling LDAP auth backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if err := ldapAuthBackendWriteConfig(client, d); err != nil {
+		return err
+	}
+
+	return ldapAuthBackendRead(d, meta)
+}
+
+func ldapAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("description") {
+		if err := authBackendTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	changed := d.HasChange("bindpass")
+	for _, k := range append(ldapAuthBackendStringFields, ldapAuthBackendBoolFields...) {
+		changed = changed || d.HasChange(k)
+	}
+	if changed {
+		if err := ldapAuthBackendWriteConfig(client, d); err != nil {
+			return err
+		}
+	}
+
+	return ldapAuthBackendRead(d, meta)
+}
+
+// ldapAuthBackendWriteConfig writes the config of the backend. String
+// settings that aren't configured are left out so the backend keeps its
+// defaults for them, while the bind password is always written as Vault
+// versions before 1.0 replace the whole config on every write.
+func ldapAuthBackendWriteConfig(client *api.Client, d *schema.ResourceData) error {
+	path := "auth/" + d.Id() + "/config"
+
+	data := map[string]interface{}{
+		"bindpass": d.Get("bindpass").(string),
+	}
+	for _, k := range ldapAuthBackendStringFields {
+		if v, ok := d.GetOk(k); ok {
+			data[k] = v.(string)
+		}
+	}
+	for _, k := range ldapAuthBackendBoolFields {
+		data[k] = d.Get(k).(bool)
+	}
+
+	log.Printf("[DEBUG] Writing LDAP auth backend config %q", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing LDAP auth backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func ldapAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading LDAP auth backend %q", path)
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("error reading auth backends from Vault: %s", err)
+	}
+	auth, ok := auths[path+"/"]
+	if !ok {
+		log.Printf("[WARN] LDAP auth backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("description", auth.Description)
+
+	// The bind password is never returned, so it is kept from the
+	// configuration.
+	config, err := client.Logical().Read("auth/" + path + "/config")
+	if err != nil {
+		return fmt.Errorf("error reading LDAP auth backend config %q: %s", path, err)
+	}
+	if config != nil {
+		for _, k := range ldapAuthBackendStringFields {
+			v, _ := config.Data[k].(string)
+			d.Set(k, v)
+		}
+		for _, k := range ldapAuthBackendBoolFields {
+			v, _ := config.Data[k].(bool)
+			d.Set(k, v)
+		}
+	}
+
+	accessor, err := authBackendAccessor(client, path)
+	if err != nil {
+		return err
+	}
+	d.Set("accessor", accessor)
+
+	return nil
+}
+
+func ldapAuthBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Disabling LDAP auth backend %q", path)
+	if err := client.Sys().DisableAuth(path); err != nil {
+		return fmt.Errorf("error disabling LDAP auth backend %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var ldapAuthBackendGroupFromPathRegex = regexp.MustCompile("^auth/(.+)/groups/([^/]+)$")
+
+func ldapAuthBackendGroupResource() *schema.Resource {
+	return &schema.Resource{
+		Create: ldapAuthBackendGroupWrite,
+		Update: ldapAuthBackendGroupWrite,
+		Delete: ldapAuthBackendGroupDelete,
+		Read:   ldapAuthBackendGroupRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ldap",
+				Description: "Path of the LDAP auth backend the group belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"groupname": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the LDAP group.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the tokens of the members of the group.",
+			},
+		},
+	}
+}
+
+func ldapAuthBackendGroupPath(backend, groupname string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/groups/" + groupname
+}
+
+func ldapAuthBackendGroupWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := ldapAuthBackendGroupPath(d.Get("backend").(string), d.Get("groupname").(string))
+
+	log.Printf("[DEBUG] Writing LDAP group %q to Vault", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"policies": strings.Join(toStringArray(d.Get("policies").(*schema.Set).List()), ","),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing LDAP group %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return ldapAuthBackendGroupRead(d, meta)
+}
+
+func ldapAuthBackendGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := ldapAuthBackendGroupFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid LDAP group ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading LDAP group %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading LDAP group %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] LDAP group %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("groupname", res[2])
+	if err := d.Set("policies", flattenStringList(secret.Data["policies"])); err != nil {
+		return fmt.Errorf("error setting policies of LDAP group %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func ldapAuthBackendGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting LDAP group %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting LDAP group %q: %s", path, err)
+	}
+
+	return nil
+}
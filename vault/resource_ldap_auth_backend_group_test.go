@@ -0,0 +1,73 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestLDAPAuthBackendGroup(t *testing.T) {
+	backend := acctest.RandomWithPrefix("ldap")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testLDAPAuthBackendGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLDAPAuthBackendGroupConfig(backend, `["default", "dev"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend_group.test", "id", "auth/"+backend+"/groups/example"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend_group.test", "policies.#", "2"),
+				),
+			},
+			{
+				Config: testLDAPAuthBackendGroupConfig(backend, `["admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend_group.test", "policies.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_ldap_auth_backend_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testLDAPAuthBackendGroupDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_ldap_auth_backend_group" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for LDAP group %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("LDAP group %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testLDAPAuthBackendGroupConfig(backend, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_ldap_auth_backend" "test" {
+	path = "%s"
+	url = "ldap://ldap.example.com"
+}
+
+resource "vault_ldap_auth_backend_group" "test" {
+	backend = "${vault_ldap_auth_backend.test.path}"
+	groupname = "example"
+	policies = %s
+}
+`, backend, policies)
+}
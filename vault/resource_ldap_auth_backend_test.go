@@ -0,0 +1,82 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestLDAPAuthBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("ldap")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testLDAPAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLDAPAuthBackendConfig(path, "ldap://ldap.example.com", "uid", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "url", "ldap://ldap.example.com"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "userattr", "uid"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "starttls", "false"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "deny_null_bind", "true"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "bindpass", "secret"),
+					resource.TestCheckResourceAttrSet("vault_ldap_auth_backend.test", "accessor"),
+				),
+			},
+			{
+				Config: testLDAPAuthBackendConfig(path, "ldaps://ldap.example.com", "sAMAccountName", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "url", "ldaps://ldap.example.com"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "userattr", "sAMAccountName"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend.test", "starttls", "true"),
+				),
+			},
+			{
+				ResourceName:            "vault_ldap_auth_backend.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bindpass"},
+			},
+		},
+	})
+}
+
+func testLDAPAuthBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_ldap_auth_backend" {
+			continue
+		}
+		if _, ok := auths[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("LDAP auth backend %q still enabled", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testLDAPAuthBackendConfig(path, url, userattr string, starttls bool) string {
+	return fmt.Sprintf(`
+resource "vault_ldap_auth_backend" "test" {
+	path = "%s"
+	description = "test"
+	url = "%s"
+	userattr = "%s"
+	starttls = %t
+	binddn = "cn=vault,ou=Users,dc=example,dc=com"
+	bindpass = "secret"
+	userdn = "ou=Users,dc=example,dc=com"
+	groupdn = "ou=Groups,dc=example,dc=com"
+}
+`, path, url, userattr, starttls)
+}
@@ -0,0 +1,131 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var ldapAuthBackendUserFromPathRegex = regexp.MustCompile("^auth/(.+)/users/([^/]+)$")
+
+func ldapAuthBackendUserResource() *schema.Resource {
+	return &schema.Resource{
+		Create: ldapAuthBackendUserWrite,
+		Update: ldapAuthBackendUserWrite,
+		Delete: ldapAuthBackendUserDelete,
+		Read:   ldapAuthBackendUserRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ldap",
+				Description: "Path of the LDAP auth backend the user belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the LDAP user.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the tokens of the user, on top of those of their groups.",
+			},
+
+			"groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Groups the user is considered a member of, on top of those found in LDAP.",
+			},
+		},
+	}
+}
+
+func ldapAuthBackendUserPath(backend, username string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/users/" + username
+}
+
+func ldapAuthBackendUserWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := ldapAuthBackendUserPath(d.Get("backend").(string), d.Get("username").(string))
+
+	log.Printf("[DEBUG] Writing LDAP user %q to Vault", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"policies": strings.Join(toStringArray(d.Get("policies").(*schema.Set).List()), ","),
+		"groups":   strings.Join(toStringArray(d.Get("groups").(*schema.Set).List()), ","),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing LDAP user %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return ldapAuthBackendUserRead(d, meta)
+}
+
+func ldapAuthBackendUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := ldapAuthBackendUserFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid LDAP user ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading LDAP user %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading LDAP user %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] LDAP user %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("username", res[2])
+	// Vault returns the groups as a comma-separated string and the policies
+	// as a list, flattenStringList handles both.
+	for _, k := range []string{"policies", "groups"} {
+		if err := d.Set(k, flattenStringList(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of LDAP user %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func ldapAuthBackendUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting LDAP user %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting LDAP user %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestLDAPAuthBackendUser(t *testing.T) {
+	backend := acctest.RandomWithPrefix("ldap")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testLDAPAuthBackendUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLDAPAuthBackendUserConfig(backend, `["default", "dev"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend_user.test", "id", "auth/"+backend+"/users/example"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend_user.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend_user.test", "groups.#", "1"),
+				),
+			},
+			{
+				Config: testLDAPAuthBackendUserConfig(backend, `["admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ldap_auth_backend_user.test", "policies.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_ldap_auth_backend_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testLDAPAuthBackendUserDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_ldap_auth_backend_user" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for LDAP user %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("LDAP user %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testLDAPAuthBackendUserConfig(backend, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_ldap_auth_backend" "test" {
+	path = "%s"
+	url = "ldap://ldap.example.com"
+}
+
+resource "vault_ldap_auth_backend_user" "test" {
+	backend = "${vault_ldap_auth_backend.test.path}"
+	username = "example"
+	policies = %s
+	groups = ["developers"]
+}
+`, backend, policies)
+}
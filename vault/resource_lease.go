@@ -0,0 +1,198 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func leaseResource() *schema.Resource {
+	return &schema.Resource{
+		Create: leaseCreate,
+		Update: leaseUpdate,
+		Delete: leaseDelete,
+		Read:   leaseRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the dynamic secret, e.g. database/creds/app.",
+			},
+
+			"data_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				Description:  "JSON-encoded data written to the path to generate the secret, which is read if not set.",
+				StateFunc:    NormalizeDataJSON,
+				ValidateFunc: ValidateDataJSON,
+			},
+
+			"renew_min_lease": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Renew the lease on refresh when it has less than this many seconds left, never if 0.",
+			},
+
+			"renew_increment": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "TTL in seconds requested when renewing the lease, its original TTL if 0.",
+			},
+
+			"value": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "String values of the secret.",
+			},
+
+			"value_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "JSON-encoded data of the secret, including values that aren't strings.",
+			},
+
+			"lease_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the lease of the secret.",
+			},
+
+			"lease_duration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Seconds the lease had left when last refreshed.",
+			},
+
+			"lease_renewable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the lease can be renewed.",
+			},
+
+			"lease_started": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the secret was last created or renewed, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func leaseCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	var secret *api.Secret
+	var err error
+	if v, ok := d.GetOk("data_json"); ok {
+		var data map[string]interface{}
+		data, err = decodeDataJSON(v.(string))
+		if err != nil {
+			return fmt.Errorf("data_json syntax error: %s", err)
+		}
+		log.Printf("[DEBUG] Writing %q to generate a secret", path)
+		secret, err = client.Logical().Write(path, data)
+	} else {
+		log.Printf("[DEBUG] Reading %q to generate a secret", path)
+		secret, err = client.Logical().Read(path)
+	}
+	if err != nil {
+		return fmt.Errorf("error generating secret with %q: %s", path, err)
+	}
+	if secret == nil || secret.LeaseID == "" {
+		return fmt.Errorf("no lease returned by %q, use vault_generic_secret for secrets without leases", path)
+	}
+
+	value, err := json.Marshal(secret.Data)
+	if err != nil {
+		return fmt.Errorf("error encoding secret of %q as JSON: %s", path, err)
+	}
+
+	d.SetId(secret.LeaseID)
+	d.Set("value", secretDataStringMap(secret.Data))
+	d.Set("value_json", string(value))
+	d.Set("lease_id", secret.LeaseID)
+	d.Set("lease_duration", secret.LeaseDuration)
+	d.Set("lease_renewable", secret.Renewable)
+	d.Set("lease_started", time.Now().UTC().Format(time.RFC3339))
+
+	return leaseRead(d, meta)
+}
+
+// leaseUpdate only stores the renewal settings, the secret itself can't be
+// changed.
+func leaseUpdate(d *schema.ResourceData, meta interface{}) error {
+	return leaseRead(d, meta)
+}
+
+// leaseNotFound reports whether err is the error that Vault returns when
+// looking up or renewing a lease that doesn't exist, e.g. because it expired
+// or was revoked.
+func leaseNotFound(err error) bool {
+	return strings.Contains(err.Error(), "invalid lease")
+}
+
+func leaseRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	leaseID := d.Id()
+
+	log.Printf("[DEBUG] Looking up lease %q", leaseID)
+	secret, err := client.Logical().Write("sys/leases/lookup", map[string]interface{}{
+		"lease_id": leaseID,
+	})
+	if err != nil && !leaseNotFound(err) {
+		return fmt.Errorf("error looking up lease %q: %s", leaseID, err)
+	}
+	if err != nil || secret == nil {
+		log.Printf("[WARN] Lease %q not found, removing from state", leaseID)
+		d.SetId("")
+		return nil
+	}
+
+	ttl := intFromResponse(secret.Data["ttl"])
+	renewable, _ := secret.Data["renewable"].(bool)
+	// Leases are renewed the same way as tokens.
+	if tokenNeedsRenewal(renewable, ttl, d.Get("renew_min_lease").(int)) {
+		log.Printf("[DEBUG] Renewing lease %q with %ds left", leaseID, ttl)
+		renewed, err := client.Sys().Renew(leaseID, d.Get("renew_increment").(int))
+		if err != nil {
+			return fmt.Errorf("error renewing lease %q: %s", leaseID, err)
+		}
+		if renewed != nil {
+			ttl = renewed.LeaseDuration
+			d.Set("lease_started", time.Now().UTC().Format(time.RFC3339))
+		}
+	}
+
+	d.Set("lease_id", leaseID)
+	d.Set("lease_duration", ttl)
+	d.Set("lease_renewable", renewable)
+
+	return nil
+}
+
+func leaseDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	leaseID := d.Id()
+
+	log.Printf("[DEBUG] Revoking lease %q", leaseID)
+	if err := client.Sys().Revoke(leaseID); err != nil && !leaseNotFound(err) {
+		return fmt.Errorf("error revoking lease %q: %s", leaseID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestLease(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	var leaseID string
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testLeaseCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLeaseConfig(backend, "app.example.com", 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vault_lease.test", "lease_id"),
+					resource.TestCheckResourceAttrSet("vault_lease.test", "value.certificate"),
+					resource.TestCheckResourceAttrSet("vault_lease.test", "value_json"),
+					resource.TestCheckResourceAttrSet("vault_lease.test", "lease_duration"),
+					func(s *terraform.State) error {
+						leaseID = s.Modules[0].Resources["vault_lease.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Changing the renewal settings doesn't generate a new
+				// secret. Certificate leases can't be renewed, so they are
+				// left as they are.
+				Config: testLeaseConfig(backend, "app.example.com", 7200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_lease.test", "renew_min_lease", "7200"),
+					resource.TestCheckResourceAttr("vault_lease.test", "lease_renewable", "false"),
+					func(s *terraform.State) error {
+						if got := s.Modules[0].Resources["vault_lease.test"].Primary.ID; got != leaseID {
+							return fmt.Errorf("secret was generated again, lease changed from %q to %q", leaseID, got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestLease_writeError(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testLeaseCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The role doesn't allow the common name, so Vault rejects
+				// the write.
+				Config:      testLeaseConfig(backend, "app.example.org", 0),
+				ExpectError: regexp.MustCompile("error generating secret"),
+			},
+		},
+	})
+}
+
+// Vault revokes the leases of the child token of the provider when it
+// expires, after max_lease_ttl_seconds, so the lease is gone on the next
+// run.
+func TestLease_childTokenExpired(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testLeaseCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLeaseProviderConfig(false) + testLeaseConfig(backend, "app.example.com", 0),
+			},
+			{
+				PreConfig:          func() { time.Sleep(15 * time.Second) },
+				Config:             testLeaseProviderConfig(false) + testLeaseConfig(backend, "app.example.com", 0),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// With skip_child_token, the lease belongs to the token of the provider and
+// survives past the time the child token would have expired.
+func TestLease_skipChildToken(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	var leaseID string
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testLeaseCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLeaseProviderConfig(true) + testLeaseConfig(backend, "app.example.com", 0),
+				Check: func(s *terraform.State) error {
+					leaseID = s.Modules[0].Resources["vault_lease.test"].Primary.ID
+					return nil
+				},
+			},
+			{
+				PreConfig: func() { time.Sleep(15 * time.Second) },
+				Config:    testLeaseProviderConfig(true) + testLeaseConfig(backend, "app.example.com", 0),
+				Check: func(s *terraform.State) error {
+					if got := s.Modules[0].Resources["vault_lease.test"].Primary.ID; got != leaseID {
+						return fmt.Errorf("secret was generated again, lease changed from %q to %q", leaseID, got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testLeaseCheckDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_lease" {
+			continue
+		}
+		_, err := client.Logical().Write("sys/leases/lookup", map[string]interface{}{
+			"lease_id": rs.Primary.ID,
+		})
+		if err == nil {
+			return fmt.Errorf("lease %q still exists", rs.Primary.ID)
+		}
+		if !leaseNotFound(err) {
+			return fmt.Errorf("error checking for lease %q: %s", rs.Primary.ID, err)
+		}
+	}
+	return nil
+}
+
+func testLeaseConfig(backend, commonName string, renewMinLease int) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "pki" {
+	path = "%s"
+	type = "pki"
+	max_lease_ttl_seconds = 86400
+}
+
+resource "vault_pki_secret_backend_root_cert" "test" {
+	backend = "${vault_mount.pki.path}"
+	type = "internal"
+	common_name = "Root CA"
+	ttl = 86400
+}
+
+resource "vault_pki_secret_backend_role" "test" {
+	backend = "${vault_pki_secret_backend_root_cert.test.backend}"
+	name = "test"
+	ttl = 3600
+	allowed_domains = ["example.com"]
+	allow_subdomains = true
+	generate_lease = true
+}
+
+resource "vault_lease" "test" {
+	path = "${vault_pki_secret_backend_role.test.backend}/issue/${vault_pki_secret_backend_role.test.name}"
+	data_json = "{\"common_name\": \"%s\"}"
+	renew_min_lease = %d
+}
+`, backend, commonName, renewMinLease)
+}
+
+// testLeaseProviderConfig configures the provider with a child token
+// expiring after 10 seconds, unless skipChildToken is set.
+func testLeaseProviderConfig(skipChildToken bool) string {
+	return fmt.Sprintf(`
+provider "vault" {
+	max_lease_ttl_seconds = 10
+	skip_child_token = %t
+}
+`, skipChildToken)
+}
@@ -0,0 +1,84 @@
+package vault
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func mfaDuoResource() *schema.Resource {
+	s := mfaMethodSchema("Duo", true)
+	s["secret_key"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Sensitive:   true,
+		Description: "Secret key of the Duo application.",
+	}
+	s["integration_key"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Sensitive:   true,
+		Description: "Integration key of the Duo application.",
+	}
+	s["api_hostname"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "API hostname of the Duo application.",
+	}
+	s["push_info"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Additional information shown in the Duo push, as a URL-encoded key/value list.",
+	}
+
+	return &schema.Resource{
+		Create: mfaDuoWrite,
+		Delete: mfaDuoDelete,
+		Read:   mfaDuoRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func mfaDuoWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := mfaMethodWrite(d, client, "duo", "Duo", map[string]interface{}{
+		"secret_key":      d.Get("secret_key").(string),
+		"integration_key": d.Get("integration_key").(string),
+		"api_hostname":    d.Get("api_hostname").(string),
+		"push_info":       d.Get("push_info").(string),
+	}); err != nil {
+		return err
+	}
+
+	return mfaDuoRead(d, meta)
+}
+
+func mfaDuoRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := mfaMethodRead(d, client, "duo", "Duo")
+	if err != nil || data == nil {
+		return err
+	}
+
+	// The keys are never returned, so they are kept from the configuration.
+	for _, k := range []string{"api_hostname", "push_info"} {
+		if v, ok := data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+
+	return nil
+}
+
+func mfaDuoDelete(d *schema.ResourceData, meta interface{}) error {
+	return mfaMethodDelete(d, meta.(*api.Client), "duo", "Duo")
+}
@@ -0,0 +1,55 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestMFADuo(t *testing.T) {
+	testAccMFAPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-duo")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testMFAMethodCheckDestroy("duo", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testMFADuoConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mfa_duo.test", "name", name),
+					resource.TestCheckResourceAttr("vault_mfa_duo.test", "api_hostname", "api-2b5c39f5.duosecurity.com"),
+					resource.TestCheckResourceAttr("vault_mfa_duo.test", "username_format", "{{alias.name}}@example.com"),
+					resource.TestCheckResourceAttrPair("vault_mfa_duo.test", "mount_accessor", "vault_auth_backend.userpass", "accessor"),
+				),
+			},
+			{
+				ResourceName:            "vault_mfa_duo.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_key", "integration_key"},
+			},
+		},
+	})
+}
+
+func testMFADuoConfig(name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "userpass" {
+	type = "userpass"
+	path = "%s"
+}
+
+resource "vault_mfa_duo" "test" {
+	name = "%s"
+	mount_accessor = "${vault_auth_backend.userpass.accessor}"
+	username_format = "{{alias.name}}@example.com"
+	secret_key = "test-secret-key"
+	integration_key = "test-integration-key"
+	api_hostname = "api-2b5c39f5.duosecurity.com"
+}
+`, name, name)
+}
@@ -0,0 +1,76 @@
+package vault
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func mfaOktaResource() *schema.Resource {
+	s := mfaMethodSchema("Okta", true)
+	s["org_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Name of the Okta organization.",
+	}
+	s["api_token"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Sensitive:   true,
+		Description: "Okta API token.",
+	}
+	s["base_url"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Base domain of the Okta API, okta.com if empty.",
+	}
+
+	return &schema.Resource{
+		Create: mfaOktaWrite,
+		Delete: mfaOktaDelete,
+		Read:   mfaOktaRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func mfaOktaWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := mfaMethodWrite(d, client, "okta", "Okta", map[string]interface{}{
+		"org_name":  d.Get("org_name").(string),
+		"api_token": d.Get("api_token").(string),
+		"base_url":  d.Get("base_url").(string),
+	}); err != nil {
+		return err
+	}
+
+	return mfaOktaRead(d, meta)
+}
+
+func mfaOktaRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := mfaMethodRead(d, client, "okta", "Okta")
+	if err != nil || data == nil {
+		return err
+	}
+
+	// The API token is never returned, so it is kept from the configuration.
+	for _, k := range []string{"org_name", "base_url"} {
+		if v, ok := data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+
+	return nil
+}
+
+func mfaOktaDelete(d *schema.ResourceData, meta interface{}) error {
+	return mfaMethodDelete(d, meta.(*api.Client), "okta", "Okta")
+}
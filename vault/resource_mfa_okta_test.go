@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestMFAOkta(t *testing.T) {
+	testAccMFAPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-okta")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testMFAMethodCheckDestroy("okta", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testMFAOktaConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mfa_okta.test", "name", name),
+					resource.TestCheckResourceAttr("vault_mfa_okta.test", "org_name", "dev-262778"),
+					resource.TestCheckResourceAttr("vault_mfa_okta.test", "base_url", "okta.com"),
+				),
+			},
+			{
+				ResourceName:            "vault_mfa_okta.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"api_token"},
+			},
+		},
+	})
+}
+
+func testMFAOktaConfig(name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "userpass" {
+	type = "userpass"
+	path = "%s"
+}
+
+resource "vault_mfa_okta" "test" {
+	name = "%s"
+	mount_accessor = "${vault_auth_backend.userpass.accessor}"
+	org_name = "dev-262778"
+	api_token = "test-api-token"
+	base_url = "okta.com"
+}
+`, name, name)
+}
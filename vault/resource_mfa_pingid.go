@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// mfaPingIDSettings are the settings Vault reads from the settings file of
+// a PingID method.
+var mfaPingIDSettings = []string{"idp_url", "admin_url", "authenticator_url", "org_alias", "use_signature"}
+
+func mfaPingIDResource() *schema.Resource {
+	s := mfaMethodSchema("PingID", true)
+	s["settings_file_base64"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Sensitive:   true,
+		Description: "Base64-encoded settings file of the PingID client integration.",
+	}
+	s["idp_url"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "IDP URL read from the settings file.",
+	}
+	s["admin_url"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Admin URL read from the settings file.",
+	}
+	s["authenticator_url"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Authenticator URL read from the settings file.",
+	}
+	s["org_alias"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Organization alias read from the settings file.",
+	}
+	s["use_signature"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether requests are signed, read from the settings file.",
+	}
+
+	return &schema.Resource{
+		Create: mfaPingIDWrite,
+		Delete: mfaPingIDDelete,
+		Read:   mfaPingIDRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func mfaPingIDWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := mfaMethodWrite(d, client, "pingid", "PingID", map[string]interface{}{
+		"settings_file_base64": d.Get("settings_file_base64").(string),
+	}); err != nil {
+		return err
+	}
+
+	return mfaPingIDRead(d, meta)
+}
+
+func mfaPingIDRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := mfaMethodRead(d, client, "pingid", "PingID")
+	if err != nil || data == nil {
+		return err
+	}
+
+	// The settings file is never returned, only the settings read from it.
+	for _, k := range mfaPingIDSettings {
+		if v, ok := data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+
+	return nil
+}
+
+func mfaPingIDDelete(d *schema.ResourceData, meta interface{}) error {
+	return mfaMethodDelete(d, meta.(*api.Client), "pingid", "PingID")
+}
@@ -0,0 +1,61 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestMFAPingID(t *testing.T) {
+	testAccMFAPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-pingid")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testMFAMethodCheckDestroy("pingid", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testMFAPingIDConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mfa_pingid.test", "name", name),
+					resource.TestCheckResourceAttr("vault_mfa_pingid.test", "idp_url", "https://idpxnyl3m.pingidentity.com/pingid"),
+					resource.TestCheckResourceAttr("vault_mfa_pingid.test", "org_alias", "181459b0-9fb1-4938-8c86-473cb6b6ccdb"),
+					resource.TestCheckResourceAttr("vault_mfa_pingid.test", "use_signature", "true"),
+				),
+			},
+			{
+				ResourceName:            "vault_mfa_pingid.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"settings_file_base64"},
+			},
+		},
+	})
+}
+
+const testMFAPingIDSettings = `use_base64_key=dGVzdC1rZXk=
+use_signature=true
+token=dGVzdC10b2tlbg==
+idp_url=https://idpxnyl3m.pingidentity.com/pingid
+org_alias=181459b0-9fb1-4938-8c86-473cb6b6ccdb
+admin_url=https://idpxnyl3m.pingidentity.com/pingid
+authenticator_url=https://authenticator.pingone.com/pingid/ppm
+`
+
+func testMFAPingIDConfig(name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "userpass" {
+	type = "userpass"
+	path = "%s"
+}
+
+resource "vault_mfa_pingid" "test" {
+	name = "%s"
+	mount_accessor = "${vault_auth_backend.userpass.accessor}"
+	settings_file_base64 = "${base64encode(%q)}"
+}
+`, name, name, testMFAPingIDSettings)
+}
@@ -0,0 +1,132 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func mfaTOTPResource() *schema.Resource {
+	s := mfaMethodSchema("TOTP", false)
+	s["issuer"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Name of the issuer of the TOTP keys, shown in authenticator apps.",
+	}
+	s["period"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     30,
+		Description: "Seconds each TOTP passcode is valid for.",
+	}
+	s["key_size"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     20,
+		Description: "Size in bytes of the generated keys.",
+	}
+	s["qr_size"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     200,
+		Description: "Size in pixels of the QR codes of the generated keys, none if 0.",
+	}
+	s["algorithm"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     "SHA1",
+		Description: "Hashing algorithm of the passcodes: SHA1, SHA256 or SHA512.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			switch v.(string) {
+			case "SHA1", "SHA256", "SHA512":
+			default:
+				errs = append(errs, fmt.Errorf("%s must be one of SHA1, SHA256 or SHA512, got %q", k, v))
+			}
+			return
+		},
+	}
+	s["digits"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     6,
+		Description: "Number of digits of the passcodes: 6 or 8.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			if d := v.(int); d != 6 && d != 8 {
+				errs = append(errs, fmt.Errorf("%s must be 6 or 8, got %d", k, d))
+			}
+			return
+		},
+	}
+	s["skew"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     1,
+		Description: "Number of periods before or after the current one whose passcodes are accepted: 0 or 1.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			if s := v.(int); s != 0 && s != 1 {
+				errs = append(errs, fmt.Errorf("%s must be 0 or 1, got %d", k, s))
+			}
+			return
+		},
+	}
+
+	return &schema.Resource{
+		Create: mfaTOTPWrite,
+		Delete: mfaTOTPDelete,
+		Read:   mfaTOTPRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+var mfaTOTPIntSettings = []string{"period", "key_size", "qr_size", "digits", "skew"}
+
+func mfaTOTPWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data := map[string]interface{}{
+		"issuer":    d.Get("issuer").(string),
+		"algorithm": d.Get("algorithm").(string),
+	}
+	for _, k := range mfaTOTPIntSettings {
+		data[k] = d.Get(k).(int)
+	}
+
+	if err := mfaMethodWrite(d, client, "totp", "TOTP", data); err != nil {
+		return err
+	}
+
+	return mfaTOTPRead(d, meta)
+}
+
+func mfaTOTPRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := mfaMethodRead(d, client, "totp", "TOTP")
+	if err != nil || data == nil {
+		return err
+	}
+
+	d.Set("issuer", data["issuer"])
+	d.Set("algorithm", data["algorithm"])
+	for _, k := range mfaTOTPIntSettings {
+		d.Set(k, intFromResponse(data[k]))
+	}
+
+	return nil
+}
+
+func mfaTOTPDelete(d *schema.ResourceData, meta interface{}) error {
+	return mfaMethodDelete(d, meta.(*api.Client), "totp", "TOTP")
+}
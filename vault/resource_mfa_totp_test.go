@@ -0,0 +1,100 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestMFATOTPValidation(t *testing.T) {
+	s := mfaTOTPResource().Schema
+	for _, c := range []struct {
+		field string
+		value interface{}
+		valid bool
+	}{
+		{"algorithm", "SHA256", true},
+		{"algorithm", "MD5", false},
+		{"digits", 8, true},
+		{"digits", 7, false},
+		{"skew", 0, true},
+		{"skew", 2, false},
+	} {
+		_, errs := s[c.field].ValidateFunc(c.value, c.field)
+		if got := len(errs) == 0; got != c.valid {
+			t.Errorf("%s %v valid = %t, expected %t", c.field, c.value, got, c.valid)
+		}
+	}
+}
+
+// testAccMFAPreCheck skips the test unless it runs against Vault
+// Enterprise, the only one with MFA methods.
+func testAccMFAPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+}
+
+func testMFAMethodCheckDestroy(methodType, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		secret, err := client.Logical().Read(mfaMethodPath(methodType, name))
+		if err != nil {
+			return fmt.Errorf("error checking for MFA method %q: %s", name, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("MFA method %q still exists", name)
+		}
+		return nil
+	}
+}
+
+func TestMFATOTP(t *testing.T) {
+	testAccMFAPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-totp")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testMFAMethodCheckDestroy("totp", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testMFATOTPConfig(name, "SHA1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mfa_totp.test", "name", name),
+					resource.TestCheckResourceAttr("vault_mfa_totp.test", "issuer", "Vault"),
+					resource.TestCheckResourceAttr("vault_mfa_totp.test", "period", "60"),
+					resource.TestCheckResourceAttr("vault_mfa_totp.test", "algorithm", "SHA1"),
+				),
+			},
+			{
+				Config: testMFATOTPConfig(name, "SHA256"),
+				Check:  resource.TestCheckResourceAttr("vault_mfa_totp.test", "algorithm", "SHA256"),
+			},
+			{
+				ResourceName:      "vault_mfa_totp.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testMFATOTPConfig(name, algorithm string) string {
+	return fmt.Sprintf(`
+resource "vault_mfa_totp" "test" {
+	name = "%s"
+	issuer = "Vault"
+	period = 60
+	algorithm = "%s"
+}
+`, name, algorithm)
+}
@@ -4,11 +4,17 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/vault/api"
 )
 
+// mountKVUpgradeTimeout bounds the wait for a KV version 1 mount to finish
+// upgrading its data to version 2.
+const mountKVUpgradeTimeout = 10 * time.Minute
+
 func mountResource() *schema.Resource {
 	return &schema.Resource{
 		Create: mountWrite,
@@ -38,7 +44,7 @@ func mountResource() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Required:    false,
-				ForceNew:    true,
+				ForceNew:    false,
 				Description: "Human-friendly description of the mount",
 			},
 
@@ -59,6 +65,53 @@ func mountResource() *schema.Resource {
 				ForceNew:    false,
 				Description: "Maximum possible lease duration for tokens and secrets in seconds",
 			},
+
+			"identity_token_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key used to sign plugin identity tokens for this mount",
+			},
+
+			"plugin_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the plugin running the backend, as registered in the plugin catalog",
+			},
+
+			"running_plugin_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the plugin currently running the backend",
+			},
+
+			"audit_non_hmac_request_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Request keys that are not HMAC'd by audit devices",
+			},
+
+			"audit_non_hmac_response_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Response keys that are not HMAC'd by audit devices",
+			},
+
+			"options": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Description: "Backend-specific options, such as the version of KV mounts",
+			},
+
+			"prevent_unmount": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to unmount the backend, and so destroy its data, while set",
+			},
 		},
 	}
 }
@@ -85,6 +138,129 @@ func mountWrite(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(path)
 
+	// Settings that the mount API of older Vault versions doesn't know
+	// about are applied by tuning the new mount.
+	if tune := mountTuneData(d, false); len(tune) > 0 {
+		if err := mountTune(client, path, tune); err != nil {
+			return err
+		}
+	}
+
+	// A KV mount tuned to version 2 upgrades even when it holds no data.
+	if version, _ := d.Get("options").(map[string]interface{})["version"].(string); version == "2" && d.Get("type").(string) == "kv" {
+		if err := mountWaitForKVUpgrade(client, path); err != nil {
+			return err
+		}
+	}
+
+	// The backend starts with the default version of the plugin, so it
+	// has to be reloaded to run the one that was just configured.
+	if d.Get("plugin_version").(string) != "" {
+		if err := mountReloadPlugin(client, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mountTuneData returns the tune parameters of the mount that are not part
+// of api.MountConfigInput. When onlyChanged is set, only the parameters
+// that changed in the current plan are returned.
+func mountTuneData(d *schema.ResourceData, onlyChanged bool) map[string]interface{} {
+	data := map[string]interface{}{}
+
+	// The description is set when mounting, but can only be changed by
+	// tuning the mount afterwards.
+	if onlyChanged && d.HasChange("description") {
+		data["description"] = d.Get("description").(string)
+	}
+
+	if !onlyChanged || d.HasChange("identity_token_key") {
+		if v := d.Get("identity_token_key").(string); v != "" || onlyChanged {
+			data["identity_token_key"] = v
+		}
+	}
+
+	if !onlyChanged || d.HasChange("plugin_version") {
+		if v := d.Get("plugin_version").(string); v != "" {
+			data["plugin_version"] = v
+		}
+	}
+
+	if !onlyChanged || d.HasChange("options") {
+		if v := d.Get("options").(map[string]interface{}); len(v) > 0 {
+			data["options"] = v
+		}
+	}
+
+	for _, k := range []string{"audit_non_hmac_request_keys", "audit_non_hmac_response_keys"} {
+		if onlyChanged && !d.HasChange(k) {
+			continue
+		}
+		keys := toStringArray(d.Get(k).([]interface{}))
+		if len(keys) == 0 {
+			if !onlyChanged {
+				continue
+			}
+			// Vault ignores an empty list, a single empty key clears
+			// the setting instead.
+			keys = []string{""}
+		}
+		data[k] = keys
+	}
+
+	return data
+}
+
+func mountTune(client *api.Client, path string, data map[string]interface{}) error {
+	log.Printf("[DEBUG] Tuning mount %s in Vault", path)
+
+	if _, err := client.Logical().Write("sys/mounts/"+path+"/tune", data); err != nil {
+		return fmt.Errorf("error tuning mount %s in Vault: %s", path, err)
+	}
+
+	return nil
+}
+
+// mountWaitForKVUpgrade waits until the KV mount at path has finished
+// upgrading its data to version 2. The upgrade runs in the background
+// after the mount is tuned, and requests to the mount fail until it is
+// done.
+func mountWaitForKVUpgrade(client *api.Client, path string) error {
+	path = strings.Trim(path, "/")
+
+	log.Printf("[DEBUG] Waiting for mount %s to upgrade to KV version 2", path)
+	err := resource.Retry(mountKVUpgradeTimeout, func() *resource.RetryError {
+		_, err := client.Logical().Read(path + "/config")
+		if err == nil {
+			return nil
+		}
+		if strings.Contains(strings.ToLower(err.Error()), "upgrad") {
+			log.Printf("[DEBUG] Mount %s is still upgrading to KV version 2", path)
+			return resource.RetryableError(err)
+		}
+		return resource.NonRetryableError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for mount %s to upgrade to KV version 2: %s", path, err)
+	}
+
+	return nil
+}
+
+// mountReloadPlugin reloads the plugin of the backend mounted at path, so
+// that it runs the version configured for the mount.
+func mountReloadPlugin(client *api.Client, path string) error {
+	log.Printf("[DEBUG] Reloading plugin of mount %s in Vault", path)
+
+	_, err := client.Logical().Write("sys/plugins/reload/backend", map[string]interface{}{
+		"mounts": []string{strings.Trim(path, "/")},
+	})
+	if err != nil {
+		return fmt.Errorf("error reloading plugin of mount %s in Vault: %s", path, err)
+	}
+
 	return nil
 }
 
@@ -112,12 +288,45 @@ func mountUpdate(d *schema.ResourceData, meta interface{}) error {
 		path = newPath
 	}
 
+	// KV mounts can be upgraded from version 1 to 2 in place, but there
+	// is no way back.
+	kvUpgrade := false
+	if d.HasChange("options") {
+		o, n := d.GetChange("options")
+		oldVersion, _ := o.(map[string]interface{})["version"].(string)
+		newVersion, _ := n.(map[string]interface{})["version"].(string)
+		if oldVersion == "2" && newVersion != "2" {
+			return fmt.Errorf("mount %s can't be downgraded from KV version 2, it must be replaced instead", path)
+		}
+		kvUpgrade = newVersion == "2" && oldVersion != "2"
+	}
+
 	log.Printf("[DEBUG] Updating mount %s in Vault", path)
 
 	if err := client.Sys().TuneMount(path, config); err != nil {
 		return fmt.Errorf("error updating Vault: %s", err)
 	}
 
+	if tune := mountTuneData(d, true); len(tune) > 0 {
+		if err := mountTune(client, path, tune); err != nil {
+			return err
+		}
+	}
+
+	if kvUpgrade {
+		if err := mountWaitForKVUpgrade(client, path); err != nil {
+			return err
+		}
+	}
+
+	// A change in the plugin version may also come from Read finding the
+	// backend still running an older version than the one configured.
+	if d.HasChange("plugin_version") && d.Get("plugin_version").(string) != "" {
+		if err := mountReloadPlugin(client, path); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -126,6 +335,14 @@ func mountDelete(d *schema.ResourceData, meta interface{}) error {
 
 	path := d.Id()
 
+	// Unmounting destroys all of the data stored by the backend, so
+	// refuse to do it until the flag has been cleared in a prior apply.
+	if d.Get("prevent_unmount").(bool) {
+		return fmt.Errorf("refusing to unmount %q because prevent_unmount is set; "+
+			"unmounting destroys all data stored in the backend. Set prevent_unmount "+
+			"to false and apply before removing this mount", path)
+	}
+
 	log.Printf("[DEBUG] Unmounting %s from Vault", path)
 
 	if err := client.Sys().Unmount(path); err != nil {
@@ -163,5 +380,59 @@ func mountRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("default_lease_ttl_seconds", mount.Config.DefaultLeaseTTL)
 	d.Set("max_lease_ttl_seconds", mount.Config.MaxLeaseTTL)
 
+	tune, err := client.Logical().Read("sys/mounts/" + strings.Trim(path, "/") + "/tune")
+	if err != nil {
+		return fmt.Errorf("error reading tuning of mount %s from Vault: %s", path, err)
+	}
+	if tune != nil {
+		d.Set("identity_token_key", tune.Data["identity_token_key"])
+		d.Set("audit_non_hmac_request_keys", flattenStringList(tune.Data["audit_non_hmac_request_keys"]))
+		d.Set("audit_non_hmac_response_keys", flattenStringList(tune.Data["audit_non_hmac_response_keys"]))
+		d.Set("plugin_version", tune.Data["plugin_version"])
+		if options, ok := tune.Data["options"].(map[string]interface{}); ok {
+			d.Set("options", options)
+		}
+	}
+
+	// The mount output of the API client doesn't include plugin versions,
+	// so they are taken from the raw listing. Only mounts pinning a plugin
+	// version need it.
+	if v, ok := d.GetOk("plugin_version"); ok && v.(string) != "" {
+		running, err := mountRunningPluginVersion(client, path)
+		if err != nil {
+			return err
+		}
+		d.Set("running_plugin_version", running)
+
+		// When the plugin was upgraded in the catalog, or the mount tuned,
+		// without reloading the backend, it keeps running the previous
+		// version. Report that version so that the plan reloads it.
+		if running != "" && running != v.(string) {
+			log.Printf("[WARN] Mount %s runs plugin version %s instead of %s", path, running, v)
+			d.Set("plugin_version", running)
+		}
+	}
+
 	return nil
 }
+
+// mountRunningPluginVersion returns the version of the plugin that the
+// backend mounted at path is currently running.
+func mountRunningPluginVersion(client *api.Client, path string) (string, error) {
+	secret, err := client.Logical().Read("sys/mounts")
+	if err != nil {
+		return "", fmt.Errorf("error reading mounts from Vault: %s", err)
+	}
+	if secret == nil {
+		return "", nil
+	}
+
+	// Vault versions listing mounts only at the top level of the response
+	// predate plugin versions, so there is nothing to report for them.
+	mount, ok := secret.Data[strings.Trim(path, "/")+"/"].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	running, _ := mount["running_plugin_version"].(string)
+	return running, nil
+}
@@ -2,6 +2,8 @@ package vault
 
 import (
 	"fmt"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -163,6 +165,260 @@ func testResourceMount_updateCheck(s *terraform.State) error {
 	return nil
 }
 
+func TestResourceMount_description(t *testing.T) {
+	path := "example-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceMount_descriptionConfig(path, "First description"),
+				Check: func(*terraform.State) error {
+					client := testProvider.Meta().(*api.Client)
+					_, err := client.Logical().Write(path+"/canary", map[string]interface{}{"foo": "bar"})
+					return err
+				},
+			},
+			{
+				Config: testResourceMount_descriptionConfig(path, "Second description"),
+				Check: func(*terraform.State) error {
+					mount, err := findMount(path)
+					if err != nil {
+						return err
+					}
+					if mount.Description != "Second description" {
+						return fmt.Errorf("description is %q; wanted %q", mount.Description, "Second description")
+					}
+
+					// The mount was tuned rather than replaced, so its
+					// data is still there.
+					client := testProvider.Meta().(*api.Client)
+					secret, err := client.Logical().Read(path + "/canary")
+					if err != nil {
+						return err
+					}
+					if secret == nil {
+						return fmt.Errorf("mount was recreated, losing its data")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testResourceMount_descriptionConfig(path, description string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+	path = "%s"
+	type = "kv"
+	description = "%s"
+}
+`, path, description)
+}
+
+func TestResourceMount_preventUnmount(t *testing.T) {
+	path := "example-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceMount_preventUnmountConfig(path, true),
+				Check:  resource.TestCheckResourceAttr("vault_mount.test", "prevent_unmount", "true"),
+			},
+			{
+				Config:      testResourceMount_preventUnmountConfig(path, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("prevent_unmount is set"),
+			},
+			{
+				Config: testResourceMount_preventUnmountConfig(path, false),
+				Check:  resource.TestCheckResourceAttr("vault_mount.test", "prevent_unmount", "false"),
+			},
+		},
+	})
+}
+
+func testResourceMount_preventUnmountConfig(path string, prevent bool) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+	path = "%s"
+	type = "generic"
+	prevent_unmount = %t
+}
+`, path, prevent)
+}
+
+func TestResourceMount_identityTokenKey(t *testing.T) {
+	path := "example-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceMount_identityTokenKeyConfig(path, "default"),
+				Check:  resource.TestCheckResourceAttr("vault_mount.test", "identity_token_key", "default"),
+			},
+			{
+				Config: testResourceMount_identityTokenKeyConfig(path, ""),
+				Check:  resource.TestCheckResourceAttr("vault_mount.test", "identity_token_key", ""),
+			},
+		},
+	})
+}
+
+func testResourceMount_identityTokenKeyConfig(path, key string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+	path = "%s"
+	type = "aws"
+	identity_token_key = "%s"
+}
+`, path, key)
+}
+
+func TestResourceMount_auditNonHMACKeys(t *testing.T) {
+	path := "example-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "vault_mount" "test" {
+	path = "%s"
+	type = "generic"
+	audit_non_hmac_request_keys = ["foo", "bar"]
+	audit_non_hmac_response_keys = ["baz"]
+}
+`, path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mount.test", "audit_non_hmac_request_keys.#", "2"),
+					resource.TestCheckResourceAttr("vault_mount.test", "audit_non_hmac_request_keys.0", "foo"),
+					resource.TestCheckResourceAttr("vault_mount.test", "audit_non_hmac_request_keys.1", "bar"),
+					resource.TestCheckResourceAttr("vault_mount.test", "audit_non_hmac_response_keys.#", "1"),
+					resource.TestCheckResourceAttr("vault_mount.test", "audit_non_hmac_response_keys.0", "baz"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "vault_mount" "test" {
+	path = "%s"
+	type = "generic"
+	audit_non_hmac_request_keys = ["foo"]
+}
+`, path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mount.test", "audit_non_hmac_request_keys.#", "1"),
+					resource.TestCheckResourceAttr("vault_mount.test", "audit_non_hmac_response_keys.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Pinning a plugin version requires an external plugin registered in the
+// catalog, given by the plugin name and two of its registered versions.
+func TestResourceMount_pluginVersion(t *testing.T) {
+	plugin := os.Getenv("TEST_VAULT_PLUGIN_NAME")
+	if plugin == "" {
+		t.Skip("TEST_VAULT_PLUGIN_NAME not set")
+	}
+	version1 := os.Getenv("TEST_VAULT_PLUGIN_VERSION_1")
+	version2 := os.Getenv("TEST_VAULT_PLUGIN_VERSION_2")
+
+	path := "example-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceMount_pluginVersionConfig(path, plugin, version1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mount.test", "plugin_version", version1),
+					resource.TestCheckResourceAttr("vault_mount.test", "running_plugin_version", version1),
+				),
+			},
+			{
+				Config: testResourceMount_pluginVersionConfig(path, plugin, version2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mount.test", "plugin_version", version2),
+					resource.TestCheckResourceAttr("vault_mount.test", "running_plugin_version", version2),
+				),
+			},
+		},
+	})
+}
+
+func testResourceMount_pluginVersionConfig(path, plugin, version string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+	path = "%s"
+	type = "%s"
+	plugin_version = "%s"
+}
+`, path, plugin, version)
+}
+
+func TestResourceMount_kvUpgrade(t *testing.T) {
+	path := acctest.RandomWithPrefix("kv")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceMount_kvVersionConfig(path, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mount.test", "options.version", "1"),
+					func(*terraform.State) error {
+						client := testProvider.Meta().(*api.Client)
+						_, err := client.Logical().Write(path+"/foo", map[string]interface{}{"zip": "zap"})
+						return err
+					},
+				),
+			},
+			{
+				Config: testResourceMount_kvVersionConfig(path, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_mount.test", "options.version", "2"),
+					func(*terraform.State) error {
+						client := testProvider.Meta().(*api.Client)
+						secret, err := client.Logical().Read(path + "/data/foo")
+						if err != nil {
+							return err
+						}
+						if secret == nil {
+							return fmt.Errorf("secret written before the upgrade not found in %s", path)
+						}
+						data, _ := secret.Data["data"].(map[string]interface{})
+						if data["zip"] != "zap" {
+							return fmt.Errorf("unexpected data after the upgrade: %v", secret.Data)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testResourceMount_kvVersionConfig(path, "1"),
+				ExpectError: regexp.MustCompile("can't be downgraded from KV version 2"),
+			},
+		},
+	})
+}
+
+func testResourceMount_kvVersionConfig(path, version string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+	path = "%s"
+	type = "kv"
+	options {
+		version = "%s"
+	}
+}
+`, path, version)
+}
+
 func findMount(path string) (*api.MountOutput, error) {
 	client := testProvider.Meta().(*api.Client)
 
@@ -0,0 +1,241 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// namespaceDeleteTimeout bounds the retries of a namespace deletion while
+// Vault is still removing its children, which happens asynchronously.
+const namespaceDeleteTimeout = 2 * time.Minute
+
+func namespaceResource() *schema.Resource {
+	return &schema.Resource{
+		Create: namespaceCreate,
+		Update: namespaceUpdate,
+		Delete: namespaceDelete,
+		Read:   namespaceRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the namespace, relative to the namespace of the provider token.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"force_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete child namespaces and mounts of the namespace when destroying it.",
+			},
+
+			"namespace_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the namespace.",
+			},
+		},
+	}
+}
+
+func namespaceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Creating namespace %q in Vault", path)
+	if _, err := client.Logical().Write(namespaceAPIPath(path), nil); err != nil {
+		return fmt.Errorf("error creating namespace %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return namespaceRead(d, meta)
+}
+
+func namespaceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading namespace %q from Vault", path)
+	secret, err := client.Logical().Read(namespaceAPIPath(path))
+	if err != nil {
+		return fmt.Errorf("error reading namespace %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Namespace %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("namespace_id", secret.Data["id"])
+
+	return nil
+}
+
+// namespaceAPIPath returns the API path managing the namespace at path.
+// Namespaces are managed from their parent, so nested paths are addressed
+// through the parent namespace.
+func namespaceAPIPath(path string) string {
+	parent, name := "", strings.Trim(path, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		parent, name = name[:i], name[i+1:]
+	}
+	return namespacedPath(parent, "sys/namespaces/"+name)
+}
+
+// namespaceUpdate only persists force_delete, which is not stored in Vault.
+func namespaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	return namespaceRead(d, meta)
+}
+
+func namespaceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.Get("force_delete").(bool) {
+		return deleteNamespaceTree(client, path)
+	}
+
+	return deleteNamespace(client, path, false)
+}
+
+// deleteNamespaceTree deletes the namespace at path after its descendants
+// and mounts, deepest first. Failures don't stop the removal of siblings,
+// but a namespace is only deleted once everything under it is gone.
+func deleteNamespaceTree(client *api.Client, path string) error {
+	children, err := listChildNamespaces(client, path)
+	if err != nil {
+		return err
+	}
+
+	var result error
+	for _, child := range children {
+		if err := deleteNamespaceTree(client, path+"/"+child); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if err := unmountNamespaceBackends(client, path); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if result != nil {
+		return fmt.Errorf("error emptying namespace %q, it was not deleted: %s", path, result)
+	}
+
+	return deleteNamespace(client, path, true)
+}
+
+// listChildNamespaces returns the names of the namespaces directly under
+// the namespace at path.
+func listChildNamespaces(client *api.Client, path string) ([]string, error) {
+	secret, err := client.Logical().List(namespacedPath(path, "sys/namespaces"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing child namespaces of %q: %s", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+
+	keys, _ := secret.Data["keys"].([]interface{})
+	children := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if key, ok := k.(string); ok {
+			children = append(children, strings.Trim(key, "/"))
+		}
+	}
+	sort.Strings(children)
+	return children, nil
+}
+
+// unmountNamespaceBackends removes the secret and auth backends mounted in
+// the namespace at path, except for those Vault manages itself.
+func unmountNamespaceBackends(client *api.Client, path string) error {
+	var result error
+	for _, kind := range []string{"mounts", "auth"} {
+		secret, err := client.Logical().Read(namespacedPath(path, "sys/"+kind))
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("error listing %s of namespace %q: %s", kind, path, err))
+			continue
+		}
+		if secret == nil {
+			continue
+		}
+
+		mountPaths := make([]string, 0, len(secret.Data))
+		for mountPath := range secret.Data {
+			mountPaths = append(mountPaths, mountPath)
+		}
+		sort.Strings(mountPaths)
+
+		for _, mountPath := range mountPaths {
+			mount, ok := secret.Data[mountPath].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			mountType, _ := mount["type"].(string)
+			if !namespaceRemovableMount(kind, mountType) {
+				continue
+			}
+
+			log.Printf("[DEBUG] Removing %s %q of namespace %q", kind, mountPath, path)
+			if _, err := client.Logical().Delete(namespacedPath(path, "sys/"+kind+"/"+strings.Trim(mountPath, "/"))); err != nil {
+				result = multierror.Append(result, fmt.Errorf("error removing %s %q of namespace %q: %s", kind, mountPath, path, err))
+			}
+		}
+	}
+	return result
+}
+
+// namespaceRemovableMount reports whether a backend of the given type,
+// mounted under sys/mounts or sys/auth, can be removed. Vault mounts some
+// backends in every namespace and refuses to remove them.
+func namespaceRemovableMount(kind, mountType string) bool {
+	if kind == "auth" {
+		return mountType != "token" && mountType != "ns_token"
+	}
+	switch mountType {
+	case "system", "ns_system", "identity", "ns_identity", "cubbyhole", "ns_cubbyhole":
+		return false
+	}
+	return true
+}
+
+// deleteNamespace deletes the namespace at path, retrying while a deletion
+// is in progress. With waitForChildren, it also retries while Vault is still
+// cleaning up namespaces that were just deleted under it; otherwise having
+// child namespaces is an error.
+func deleteNamespace(client *api.Client, path string, waitForChildren bool) error {
+	log.Printf("[DEBUG] Deleting namespace %q from Vault", path)
+	return resource.Retry(namespaceDeleteTimeout, func() *resource.RetryError {
+		_, err := client.Logical().Delete(namespaceAPIPath(path))
+		if err == nil {
+			return nil
+		}
+		if strings.Contains(err.Error(), "in progress") || (waitForChildren && strings.Contains(err.Error(), "child namespaces")) {
+			log.Printf("[DEBUG] Namespace %q not ready to be deleted yet, retrying: %s", path, err)
+			return resource.RetryableError(err)
+		}
+		return resource.NonRetryableError(fmt.Errorf("error deleting namespace %q: %s", path, err))
+	})
+}
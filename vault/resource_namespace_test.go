@@ -0,0 +1,205 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestNamespaceRemovableMount(t *testing.T) {
+	cases := []struct {
+		kind      string
+		mountType string
+		removable bool
+	}{
+		{"mounts", "kv", true},
+		{"mounts", "transit", true},
+		{"mounts", "ns_system", false},
+		{"mounts", "ns_identity", false},
+		{"mounts", "ns_cubbyhole", false},
+		{"auth", "approle", true},
+		{"auth", "ns_token", false},
+	}
+	for _, c := range cases {
+		if got := namespaceRemovableMount(c.kind, c.mountType); got != c.removable {
+			t.Errorf("namespaceRemovableMount(%q, %q) = %t, expected %t", c.kind, c.mountType, got, c.removable)
+		}
+	}
+}
+
+func TestNamespaceAPIPath(t *testing.T) {
+	for path, expected := range map[string]string{
+		"team":          "sys/namespaces/team",
+		"team/dev/":     "team/sys/namespaces/dev",
+		"team/dev/blue": "team/dev/sys/namespaces/blue",
+	} {
+		if got := namespaceAPIPath(path); got != expected {
+			t.Errorf("namespaceAPIPath(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
+
+func TestNamespaceForceDelete(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	// Namespaces are only available in Vault Enterprise.
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+
+	path := acctest.RandomWithPrefix("test-namespace")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testNamespaceCheckDestroy(path),
+		Steps: []resource.TestStep{
+			{
+				Config: testNamespaceConfig(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_namespace.test", "path", path),
+					resource.TestCheckResourceAttrSet("vault_namespace.test", "namespace_id"),
+					testNamespacePopulate(path),
+				),
+			},
+		},
+	})
+}
+
+// testNamespacePopulate creates nested namespaces and mounts that are not
+// managed by Terraform, so that destroying the namespace needs force_delete.
+func testNamespacePopulate(path string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		child := path + "/child"
+		writes := []struct {
+			path string
+			data map[string]interface{}
+		}{
+			{namespacedPath(path, "sys/namespaces/child"), nil},
+			{namespacedPath(path, "sys/mounts/kv"), map[string]interface{}{"type": "kv"}},
+			{namespacedPath(child, "sys/namespaces/grandchild"), nil},
+			{namespacedPath(child+"/grandchild", "sys/auth/approle"), map[string]interface{}{"type": "approle"}},
+		}
+		for _, w := range writes {
+			if _, err := client.Logical().Write(w.path, w.data); err != nil {
+				return fmt.Errorf("error writing %q: %s", w.path, err)
+			}
+		}
+		return nil
+	}
+}
+
+func testNamespaceCheckDestroy(path string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		secret, err := client.Logical().Read(namespaceAPIPath(path))
+		if err != nil {
+			return fmt.Errorf("error checking for namespace %q: %s", path, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("namespace %q still exists", path)
+		}
+		return nil
+	}
+}
+
+func testNamespaceConfig(path string) string {
+	return fmt.Sprintf(`
+resource "vault_namespace" "test" {
+  path         = %q
+  force_delete = true
+}
+`, path)
+}
+
+// TestNamespaceProvider checks that the provider namespace applies to every
+// resource and that the namespace of vault_generic_endpoint is relative to
+// it.
+func TestNamespaceProvider(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+	testAccPreCheck(t)
+
+	// The provider namespace must exist before the provider is configured,
+	// so it's managed outside of Terraform, with a client without namespace.
+	client, err := api.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	namespace := acctest.RandomWithPrefix("test-namespace")
+	if _, err := client.Logical().Write(namespaceAPIPath(namespace), nil); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Logical().Delete(namespaceAPIPath(namespace))
+
+	child := namespace + "/child"
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		CheckDestroy: testNamespaceProviderCheckDestroy(client, child),
+		Steps: []resource.TestStep{
+			{
+				Config: testNamespaceProviderConfig(namespace),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_generic_endpoint.mount", "namespace", "child"),
+					testNamespaceProviderCheckMount(client, child, "kv"),
+				),
+			},
+		},
+	})
+}
+
+func testNamespaceProviderCheckMount(client *api.Client, namespace, mount string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		path := namespacedPath(namespace, "sys/mounts/"+mount+"/tune")
+		secret, err := client.Logical().Read(path)
+		if err != nil {
+			return fmt.Errorf("error reading %q: %s", path, err)
+		}
+		if secret == nil {
+			return fmt.Errorf("mount %q not found in namespace %q", mount, namespace)
+		}
+		return nil
+	}
+}
+
+func testNamespaceProviderCheckDestroy(client *api.Client, path string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		secret, err := client.Logical().Read(namespaceAPIPath(path))
+		if err != nil {
+			return fmt.Errorf("error checking for namespace %q: %s", path, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("namespace %q still exists", path)
+		}
+		return nil
+	}
+}
+
+func testNamespaceProviderConfig(namespace string) string {
+	return fmt.Sprintf(`
+provider "vault" {
+  namespace = %q
+}
+
+resource "vault_namespace" "child" {
+  path = "child"
+}
+
+resource "vault_generic_endpoint" "mount" {
+  namespace    = "${vault_namespace.child.path}"
+  path         = "sys/mounts/kv"
+  data_json    = "{\"type\": \"kv\"}"
+  disable_read = true
+}
+`, namespace)
+}
@@ -0,0 +1,215 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func oktaAuthBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: oktaAuthBackendCreate,
+		Update: oktaAuthBackendUpdate,
+		Delete: oktaAuthBackendDelete,
+		Read:   oktaAuthBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "okta",
+				Description: "Path to enable the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the backend.",
+			},
+
+			"organization": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Okta organization, e.g. dev-123456.",
+			},
+
+			"token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Okta API token used to look up the groups of users, only the groups configured in Vault are used if not set.",
+			},
+
+			"base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "okta.com",
+				Description: "Domain of the Okta API, e.g. oktapreview.com.",
+			},
+
+			"bypass_okta_mfa": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Let users log in without the multi-factor authentication required by the Okta organization.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "TTL in seconds of the tokens issued by the backend, the system default if 0.",
+			},
+
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL in seconds of the tokens issued by the backend, the system default if 0.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The accessor of the backend.",
+			},
+		},
+	}
+}
+
+func oktaAuthBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Enabling Okta auth backend at %q", path)
+	if err := client.Sys().EnableAuth(path, "okta", d.Get("description").(string)); err != nil {
+		return fmt.Errorf("error enabling Okta auth backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if err := oktaAuthBackendWriteConfig(client, d); err != nil {
+		return err
+	}
+
+	return oktaAuthBackendRead(d, meta)
+}
+
+func oktaAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("description") {
+		if err := authBackendTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("organization") || d.HasChange("token") || d.HasChange("base_url") ||
+		d.HasChange("bypass_okta_mfa") || d.HasChange("ttl") || d.HasChange("max_ttl") {
+		if err := oktaAuthBackendWriteConfig(client, d); err != nil {
+			return err
+		}
+	}
+
+	return oktaAuthBackendRead(d, meta)
+}
+
+// oktaAuthBackendWriteConfig writes the config of the backend. The API
+// token is always written so that removing it from the configuration
+// clears it, Vault keeps the previous one when it is left out.
+func oktaAuthBackendWriteConfig(client *api.Client, d *schema.ResourceData) error {
+	path := "auth/" + d.Id() + "/config"
+
+	log.Printf("[DEBUG] Writing Okta auth backend config %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"organization":    d.Get("organization").(string),
+		"token":           d.Get("token").(string),
+		"base_url":        d.Get("base_url").(string),
+		"bypass_okta_mfa": d.Get("bypass_okta_mfa").(bool),
+		"ttl":             fmt.Sprintf("%ds", d.Get("ttl").(int)),
+		"max_ttl":         fmt.Sprintf("%ds", d.Get("max_ttl").(int)),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing Okta auth backend config %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func oktaAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading Okta auth backend %q", path)
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("error reading auth backends from Vault: %s", err)
+	}
+	auth, ok := auths[path+"/"]
+	if !ok {
+		log.Printf("[WARN] Okta auth backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", path)
+	d.Set("description", auth.Description)
+
+	// The API token is never returned, so it is kept from the
+	// configuration.
+	config, err := client.Logical().Read("auth/" + path + "/config")
+	if err != nil {
+		return fmt.Errorf("error reading Okta auth backend config %q: %s", path, err)
+	}
+	if config != nil {
+		// Vault 1.0 renamed organization to org_name.
+		if v, ok := config.Data["org_name"]; ok {
+			d.Set("organization", v)
+		} else {
+			d.Set("organization", config.Data["organization"])
+		}
+		if v, ok := config.Data["base_url"].(string); ok && v != "" {
+			d.Set("base_url", v)
+		}
+		if v, ok := config.Data["bypass_okta_mfa"]; ok {
+			d.Set("bypass_okta_mfa", v)
+		}
+		d.Set("ttl", durationSecondsFromResponse(tokenSetting(config.Data, "token_ttl", "ttl")))
+		d.Set("max_ttl", durationSecondsFromResponse(tokenSetting(config.Data, "token_max_ttl", "max_ttl")))
+	}
+
+	accessor, err := authBackendAccessor(client, path)
+	if err != nil {
+		return err
+	}
+	d.Set("accessor", accessor)
+
+	return nil
+}
+
+func oktaAuthBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Disabling Okta auth backend %q", path)
+	if err := client.Sys().DisableAuth(path); err != nil {
+		return fmt.Errorf("error disabling Okta auth backend %q: %s", path, err)
+	}
+
+	return nil
+}
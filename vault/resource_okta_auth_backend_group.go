@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var oktaAuthBackendGroupFromPathRegex = regexp.MustCompile("^auth/(.+)/groups/([^/]+)$")
+
+func oktaAuthBackendGroupResource() *schema.Resource {
+	return &schema.Resource{
+		Create: oktaAuthBackendGroupWrite,
+		Update: oktaAuthBackendGroupWrite,
+		Delete: oktaAuthBackendGroupDelete,
+		Read:   oktaAuthBackendGroupRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "okta",
+				Description: "Path of the Okta auth backend the group belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"group_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Okta group.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the tokens of the members of the group.",
+			},
+		},
+	}
+}
+
+func oktaAuthBackendGroupPath(backend, groupName string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/groups/" + groupName
+}
+
+func oktaAuthBackendGroupWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := oktaAuthBackendGroupPath(d.Get("backend").(string), d.Get("group_name").(string))
+
+	log.Printf("[DEBUG] Writing Okta group %q to Vault", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"policies": strings.Join(toStringArray(d.Get("policies").(*schema.Set).List()), ","),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing Okta group %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return oktaAuthBackendGroupRead(d, meta)
+}
+
+func oktaAuthBackendGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := oktaAuthBackendGroupFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid Okta group ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading Okta group %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading Okta group %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Okta group %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("group_name", res[2])
+	if err := d.Set("policies", flattenStringList(secret.Data["policies"])); err != nil {
+		return fmt.Errorf("error setting policies of Okta group %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func oktaAuthBackendGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting Okta group %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting Okta group %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestOktaAuthBackendGroup(t *testing.T) {
+	backend := acctest.RandomWithPrefix("okta")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testOktaAuthBackendGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testOktaAuthBackendGroupConfig(backend, `["default", "dev"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_okta_auth_backend_group.test", "id", "auth/"+backend+"/groups/example"),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend_group.test", "policies.#", "2"),
+				),
+			},
+			{
+				Config: testOktaAuthBackendGroupConfig(backend, `["admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_okta_auth_backend_group.test", "policies.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_okta_auth_backend_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testOktaAuthBackendGroupDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_okta_auth_backend_group" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for Okta group %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("Okta group %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testOktaAuthBackendGroupConfig(backend, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_okta_auth_backend" "test" {
+	path = "%s"
+	organization = "example"
+}
+
+resource "vault_okta_auth_backend_group" "test" {
+	backend = "${vault_okta_auth_backend.test.path}"
+	group_name = "example"
+	policies = %s
+}
+`, backend, policies)
+}
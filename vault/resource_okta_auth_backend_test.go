@@ -0,0 +1,78 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestOktaAuthBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("okta")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testOktaAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testOktaAuthBackendConfig(path, "example", "okta.com", 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_okta_auth_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend.test", "organization", "example"),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend.test", "base_url", "okta.com"),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend.test", "ttl", "0"),
+					resource.TestCheckResourceAttrSet("vault_okta_auth_backend.test", "accessor"),
+				),
+			},
+			{
+				Config: testOktaAuthBackendConfig(path, "example-preview", "oktapreview.com", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_okta_auth_backend.test", "organization", "example-preview"),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend.test", "base_url", "oktapreview.com"),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend.test", "ttl", "3600"),
+				),
+			},
+			{
+				ResourceName:            "vault_okta_auth_backend.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+	})
+}
+
+func testOktaAuthBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_okta_auth_backend" {
+			continue
+		}
+		if _, ok := auths[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("Okta auth backend %q still enabled", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testOktaAuthBackendConfig(path, organization, baseURL string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_okta_auth_backend" "test" {
+	path = "%s"
+	description = "test"
+	organization = "%s"
+	token = "secret"
+	base_url = "%s"
+	ttl = %d
+	max_ttl = 7200
+}
+`, path, organization, baseURL, ttl)
+}
@@ -0,0 +1,129 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var oktaAuthBackendUserFromPathRegex = regexp.MustCompile("^auth/(.+)/users/([^/]+)$")
+
+func oktaAuthBackendUserResource() *schema.Resource {
+	return &schema.Resource{
+		Create: oktaAuthBackendUserWrite,
+		Update: oktaAuthBackendUserWrite,
+		Delete: oktaAuthBackendUserDelete,
+		Read:   oktaAuthBackendUserRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "okta",
+				Description: "Path of the Okta auth backend the user belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Login of the Okta user, usually their email address.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies attached to the tokens of the user, on top of those of their groups.",
+			},
+
+			"groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Groups the user is considered a member of, on top of those found in Okta.",
+			},
+		},
+	}
+}
+
+func oktaAuthBackendUserPath(backend, username string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/users/" + username
+}
+
+func oktaAuthBackendUserWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := oktaAuthBackendUserPath(d.Get("backend").(string), d.Get("username").(string))
+
+	log.Printf("[DEBUG] Writing Okta user %q to Vault", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"policies": strings.Join(toStringArray(d.Get("policies").(*schema.Set).List()), ","),
+		"groups":   strings.Join(toStringArray(d.Get("groups").(*schema.Set).List()), ","),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing Okta user %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return oktaAuthBackendUserRead(d, meta)
+}
+
+func oktaAuthBackendUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := oktaAuthBackendUserFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid Okta user ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading Okta user %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading Okta user %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Okta user %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("username", res[2])
+	for _, k := range []string{"policies", "groups"} {
+		if err := d.Set(k, flattenStringList(secret.Data[k])); err != nil {
+			return fmt.Errorf("error setting %s of Okta user %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func oktaAuthBackendUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting Okta user %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting Okta user %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestOktaAuthBackendUser(t *testing.T) {
+	backend := acctest.RandomWithPrefix("okta")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testOktaAuthBackendUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testOktaAuthBackendUserConfig(backend, `["default", "dev"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_okta_auth_backend_user.test", "id", "auth/"+backend+"/users/example"),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend_user.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_okta_auth_backend_user.test", "groups.#", "1"),
+				),
+			},
+			{
+				Config: testOktaAuthBackendUserConfig(backend, `["admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_okta_auth_backend_user.test", "policies.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vault_okta_auth_backend_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testOktaAuthBackendUserDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_okta_auth_backend_user" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for Okta user %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("Okta user %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testOktaAuthBackendUserConfig(backend, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_okta_auth_backend" "test" {
+	path = "%s"
+	organization = "example"
+}
+
+resource "vault_okta_auth_backend_user" "test" {
+	backend = "${vault_okta_auth_backend.test.path}"
+	username = "example"
+	policies = %s
+	groups = ["developers"]
+}
+`, backend, policies)
+}
@@ -0,0 +1,250 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func pkiSecretBackendCertResource() *schema.Resource {
+	return &schema.Resource{
+		Create: pkiSecretBackendCertCreate,
+		Update: pkiSecretBackendCertUpdate,
+		Delete: pkiSecretBackendCertDelete,
+		Read:   pkiSecretBackendCertRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the PKI secret backend to issue the certificate with.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role to issue the certificate with.",
+			},
+
+			"common_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Common name of the certificate.",
+			},
+
+			"alt_names": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Subject alternative DNS names and email addresses.",
+			},
+
+			"ip_sans": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Subject alternative IP addresses.",
+			},
+
+			"uri_sans": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Subject alternative URIs.",
+			},
+
+			"other_sans": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Custom subject alternative names, as <oid>;<type>:<value>.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "TTL in seconds of the certificate.",
+			},
+
+			"exclude_cn_from_sans": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Don't include the common name in the subject alternative names.",
+			},
+
+			"min_seconds_remaining": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     604800,
+				Description: "Issue a new certificate when the current one has less than this many seconds left.",
+			},
+
+			"revoke": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Revoke the certificate when it is destroyed or replaced.",
+			},
+
+			"renew_pending": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Set on refresh when the certificate has less than min_seconds_remaining left, so that it's replaced. Not meant to be configured.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if v.(bool) {
+						errs = append(errs, fmt.Errorf("%s is set by the provider and can't be configured", k))
+					}
+					return
+				},
+			},
+
+			"certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The issued certificate.",
+			},
+
+			"issuing_ca": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The certificate of the issuing CA.",
+			},
+
+			"ca_chain": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The chain of CA certificates of the issued certificate.",
+			},
+
+			"private_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The private key of the certificate.",
+			},
+
+			"private_key_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the private key.",
+			},
+
+			"serial_number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The serial number of the certificate.",
+			},
+
+			"expiration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix time at which the certificate expires.",
+			},
+		},
+	}
+}
+
+func pkiSecretBackendCertCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/issue/" + d.Get("name").(string)
+
+	data := map[string]interface{}{
+		"common_name":          d.Get("common_name").(string),
+		"alt_names":            strings.Join(toStringArray(d.Get("alt_names").([]interface{})), ","),
+		"ip_sans":              strings.Join(toStringArray(d.Get("ip_sans").([]interface{})), ","),
+		"uri_sans":             strings.Join(toStringArray(d.Get("uri_sans").([]interface{})), ","),
+		"other_sans":           strings.Join(toStringArray(d.Get("other_sans").([]interface{})), ","),
+		"exclude_cn_from_sans": d.Get("exclude_cn_from_sans").(bool),
+		"format":               "pem",
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		data["ttl"] = v.(int)
+	}
+
+	log.Printf("[DEBUG] Issuing certificate %q with %q", d.Get("common_name").(string), path)
+	secret, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error issuing certificate with %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no certificate returned by %q", path)
+	}
+
+	serial, _ := secret.Data["serial_number"].(string)
+
+	d.SetId(backend + "/" + serial)
+	d.Set("certificate", secret.Data["certificate"])
+	d.Set("issuing_ca", secret.Data["issuing_ca"])
+	d.Set("ca_chain", flattenStringList(secret.Data["ca_chain"]))
+	d.Set("private_key", secret.Data["private_key"])
+	d.Set("private_key_type", secret.Data["private_key_type"])
+	d.Set("serial_number", serial)
+	d.Set("expiration", intFromResponse(secret.Data["expiration"]))
+	d.Set("renew_pending", false)
+
+	return nil
+}
+
+func pkiSecretBackendCertRead(d *schema.ResourceData, meta interface{}) error {
+	// Issued certificates never change, but they are replaced when about
+	// to expire. Flagging them makes the plan differ from the configuration
+	// in a field forcing a new resource, so the old certificate is only
+	// destroyed, and revoked if requested, when the plan is applied.
+	expiration := int64(d.Get("expiration").(int))
+	if pkiSecretBackendCertNeedsRenewal(expiration, d.Get("min_seconds_remaining").(int), time.Now()) {
+		log.Printf("[WARN] Certificate %q expires at %s, marking it to be renewed", d.Id(), time.Unix(expiration, 0))
+		d.Set("renew_pending", true)
+	}
+	return nil
+}
+
+// pkiSecretBackendCertUpdate only stores the settings that don't affect the
+// issued certificate.
+func pkiSecretBackendCertUpdate(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func pkiSecretBackendCertDelete(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("revoke").(bool) {
+		return nil
+	}
+
+	return pkiSecretBackendCertRevoke(d, meta.(*api.Client))
+}
+
+func pkiSecretBackendCertRevoke(d *schema.ResourceData, client *api.Client) error {
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	serial := d.Get("serial_number").(string)
+
+	log.Printf("[DEBUG] Revoking certificate %q in %q", serial, backend)
+	_, err := client.Logical().Write(backend+"/revoke", map[string]interface{}{
+		"serial_number": serial,
+	})
+	if err != nil {
+		return fmt.Errorf("error revoking certificate %q in %q: %s", serial, backend, err)
+	}
+
+	return nil
+}
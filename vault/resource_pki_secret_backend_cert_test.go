@@ -0,0 +1,159 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestPKISecretBackendCert(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testPKISecretBackendCertConfig(backend, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_root_cert.test", "id", backend+"/root"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_root_cert.test", "certificate"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_root_cert.test", "serial_number"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_root_cert.test", "private_key", ""),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_cert.test", "certificate"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_cert.test", "private_key"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_cert.test", "expiration"),
+					resource.TestCheckResourceAttrPair(
+						"vault_pki_secret_backend_cert.test", "issuing_ca",
+						"vault_pki_secret_backend_root_cert.test", "certificate",
+					),
+				),
+			},
+			{
+				// Changing the renewal threshold doesn't issue a new
+				// certificate.
+				Config: testPKISecretBackendCertConfig(backend, 1800),
+				Check:  resource.TestCheckResourceAttr("vault_pki_secret_backend_cert.test", "min_seconds_remaining", "1800"),
+			},
+		},
+	})
+}
+
+// With min_seconds_remaining above the TTL, every plan replaces the
+// certificate. Planning alone doesn't revoke it, applying the plan revokes
+// the one it replaces.
+func TestPKISecretBackendCert_renewRevoke(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	var serial string
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:             testPKISecretBackendCertConfig(backend, 10000),
+				Check:              testPKISecretBackendCertSerial("vault_pki_secret_backend_cert.test", &serial),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:             testPKISecretBackendCertConfig(backend, 10000),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					if err := testPKISecretBackendCertRevoked(backend, &serial)(nil); err == nil {
+						t.Fatalf("certificate %q was revoked by planning its renewal", serial)
+					}
+				},
+				Config: testPKISecretBackendCertConfig(backend, 10000),
+				Check: resource.ComposeTestCheckFunc(
+					testPKISecretBackendCertRenewed("vault_pki_secret_backend_cert.test", &serial),
+					testPKISecretBackendCertRevoked(backend, &serial),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_cert.test", "renew_pending", "false"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testPKISecretBackendCertSerial(name string, serial *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", name)
+		}
+		*serial = rs.Primary.Attributes["serial_number"]
+		return nil
+	}
+}
+
+func testPKISecretBackendCertRenewed(name string, serial *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", name)
+		}
+		if rs.Primary.Attributes["serial_number"] == *serial {
+			return fmt.Errorf("certificate %q was not renewed", *serial)
+		}
+		return nil
+	}
+}
+
+func testPKISecretBackendCertRevoked(backend string, serial *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+
+		secret, err := client.Logical().Read(backend + "/cert/" + *serial)
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			return fmt.Errorf("certificate %q not found", *serial)
+		}
+		if intFromResponse(secret.Data["revocation_time"]) == 0 {
+			return fmt.Errorf("certificate %q was not revoked", *serial)
+		}
+		return nil
+	}
+}
+
+func testPKISecretBackendCertConfig(backend string, minSecondsRemaining int) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "pki" {
+	path = "%s"
+	type = "pki"
+	max_lease_ttl_seconds = 86400
+}
+
+resource "vault_pki_secret_backend_root_cert" "test" {
+	backend = "${vault_mount.pki.path}"
+	type = "internal"
+	common_name = "Root CA"
+	ttl = 86400
+	organization = "Example"
+}
+
+resource "vault_pki_secret_backend_role" "test" {
+	backend = "${vault_pki_secret_backend_root_cert.test.backend}"
+	name = "test"
+	ttl = 7200
+	allowed_domains = ["example.com"]
+	allow_subdomains = true
+}
+
+resource "vault_pki_secret_backend_cert" "test" {
+	backend = "${vault_pki_secret_backend_role.test.backend}"
+	name = "${vault_pki_secret_backend_role.test.name}"
+	common_name = "www.example.com"
+	alt_names = ["api.example.com"]
+	ttl = 7200
+	min_seconds_remaining = %d
+	revoke = true
+}
+`, backend, minSecondsRemaining)
+}
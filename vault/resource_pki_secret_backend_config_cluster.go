@@ -0,0 +1,107 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func pkiSecretBackendConfigClusterResource() *schema.Resource {
+	return &schema.Resource{
+		Create: pkiSecretBackendConfigClusterWrite,
+		Update: pkiSecretBackendConfigClusterWrite,
+		Delete: pkiSecretBackendConfigClusterDelete,
+		Read:   pkiSecretBackendConfigClusterRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the PKI secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of this cluster's mount, used for ACME directories and templated AIA URLs.",
+			},
+
+			"aia_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of this cluster's mount for AIA URLs, which may be a non-TLS URL.",
+			},
+		},
+	}
+}
+
+func pkiSecretBackendConfigClusterWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/config/cluster"
+
+	log.Printf("[DEBUG] Writing PKI cluster config to %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"path":     d.Get("path").(string),
+		"aia_path": d.Get("aia_path").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing PKI cluster config %q: %s", path, err)
+	}
+
+	d.SetId(backend)
+
+	return pkiSecretBackendConfigClusterRead(d, meta)
+}
+
+func pkiSecretBackendConfigClusterRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id() + "/config/cluster"
+
+	log.Printf("[DEBUG] Reading PKI cluster config from %q", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading PKI cluster config %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] PKI cluster config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", d.Id())
+	d.Set("path", secret.Data["path"])
+	d.Set("aia_path", secret.Data["aia_path"])
+
+	return nil
+}
+
+func pkiSecretBackendConfigClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id() + "/config/cluster"
+
+	// The cluster config can't be removed, so clear both URLs instead.
+	log.Printf("[DEBUG] Resetting PKI cluster config %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"path":     "",
+		"aia_path": "",
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting PKI cluster config %q: %s", path, err)
+	}
+
+	return nil
+}
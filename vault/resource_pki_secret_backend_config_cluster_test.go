@@ -0,0 +1,50 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestPkiSecretBackendConfigCluster(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testPkiSecretBackendConfigCluster(backend, "https://vault.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_config_cluster.test", "path", "https://vault.example.com/v1/"+backend),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_config_cluster.test", "aia_path", "http://vault.example.com/v1/"+backend),
+				),
+			},
+			{
+				Config: testPkiSecretBackendConfigCluster(backend, "https://lb.example.com"),
+				Check:  resource.TestCheckResourceAttr("vault_pki_secret_backend_config_cluster.test", "path", "https://lb.example.com/v1/"+backend),
+			},
+			{
+				ResourceName:      "vault_pki_secret_backend_config_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testPkiSecretBackendConfigCluster(backend, url string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "pki" {
+	path = "%s"
+	type = "pki"
+}
+
+resource "vault_pki_secret_backend_config_cluster" "test" {
+	backend = "${vault_mount.pki.path}"
+	path = "%s/v1/${vault_mount.pki.path}"
+	aia_path = "http://vault.example.com/v1/${vault_mount.pki.path}"
+}
+`, backend, url)
+}
@@ -0,0 +1,86 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func pkiSecretBackendIntermediateCertRequestResource() *schema.Resource {
+	s := pkiSecretBackendSubjectSchema()
+
+	s["backend"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Path of the PKI secret backend to generate the intermediate CA key in.",
+		StateFunc: func(v interface{}) string {
+			return strings.Trim(v.(string), "/")
+		},
+	}
+	s["type"] = pkiSecretBackendTypeSchema()
+
+	s["csr"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The PEM-encoded CSR of the intermediate CA.",
+	}
+	s["private_key"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The private key of the intermediate CA, only set when type is exported.",
+	}
+	s["private_key_type"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The type of the private key, only set when type is exported.",
+	}
+
+	return &schema.Resource{
+		Create: pkiSecretBackendIntermediateCertRequestCreate,
+		Delete: pkiSecretBackendIntermediateCertRequestDelete,
+		Read:   pkiSecretBackendIntermediateCertRequestRead,
+
+		Schema: s,
+	}
+}
+
+func pkiSecretBackendIntermediateCertRequestCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/intermediate/generate/" + d.Get("type").(string)
+
+	log.Printf("[DEBUG] Generating intermediate CA CSR %q with %q", d.Get("common_name").(string), path)
+	secret, err := client.Logical().Write(path, pkiSecretBackendSubjectData(d))
+	if err != nil {
+		return fmt.Errorf("error generating intermediate CA CSR with %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no CSR returned by %q", path)
+	}
+
+	d.SetId(backend + "/intermediate")
+	d.Set("csr", secret.Data["csr"])
+	d.Set("private_key", secret.Data["private_key"])
+	d.Set("private_key_type", secret.Data["private_key_type"])
+
+	return pkiSecretBackendIntermediateCertRequestRead(d, meta)
+}
+
+func pkiSecretBackendIntermediateCertRequestRead(d *schema.ResourceData, meta interface{}) error {
+	// Generated CSRs never change, so there's nothing to refresh.
+	return nil
+}
+
+// pkiSecretBackendIntermediateCertRequestDelete only removes the CSR from
+// the state, as Vault has no way to delete it. The key stays in the backend
+// until a new one is generated.
+func pkiSecretBackendIntermediateCertRequestDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Leaving intermediate CA key %q in Vault", d.Id())
+	return nil
+}
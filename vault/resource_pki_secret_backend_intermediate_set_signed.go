@@ -0,0 +1,67 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func pkiSecretBackendIntermediateSetSignedResource() *schema.Resource {
+	return &schema.Resource{
+		Create: pkiSecretBackendIntermediateSetSignedCreate,
+		Delete: pkiSecretBackendIntermediateSetSignedDelete,
+		Read:   pkiSecretBackendIntermediateSetSignedRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the PKI secret backend the CSR of the intermediate CA was generated in.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"certificate": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "PEM-encoded signed intermediate CA certificate, optionally followed by its CA chain.",
+			},
+		},
+	}
+}
+
+func pkiSecretBackendIntermediateSetSignedCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/intermediate/set-signed"
+
+	log.Printf("[DEBUG] Setting signed intermediate CA certificate with %q", path)
+	if _, err := client.Logical().Write(path, map[string]interface{}{
+		"certificate": d.Get("certificate").(string),
+	}); err != nil {
+		return fmt.Errorf("error setting signed intermediate CA certificate with %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return pkiSecretBackendIntermediateSetSignedRead(d, meta)
+}
+
+func pkiSecretBackendIntermediateSetSignedRead(d *schema.ResourceData, meta interface{}) error {
+	// The certificate is only known from the configuration.
+	return nil
+}
+
+// pkiSecretBackendIntermediateSetSignedDelete only removes the certificate
+// from the state, as Vault has no way to unset it.
+func pkiSecretBackendIntermediateSetSignedDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Leaving intermediate CA certificate %q in Vault", d.Id())
+	return nil
+}
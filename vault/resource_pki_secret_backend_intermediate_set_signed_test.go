@@ -0,0 +1,97 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestPKISecretBackendIntermediateSetSigned(t *testing.T) {
+	root := acctest.RandomWithPrefix("pki-root")
+	intermediate := acctest.RandomWithPrefix("pki-intermediate")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "vault_mount" "root" {
+	path = "%s"
+	type = "pki"
+	max_lease_ttl_seconds = 86400
+}
+
+resource "vault_mount" "intermediate" {
+	path = "%s"
+	type = "pki"
+	max_lease_ttl_seconds = 43200
+}
+
+resource "vault_pki_secret_backend_root_cert" "test" {
+	backend = "${vault_mount.root.path}"
+	type = "internal"
+	common_name = "Root CA"
+	ttl = 86400
+}
+
+resource "vault_pki_secret_backend_intermediate_cert_request" "test" {
+	backend = "${vault_mount.intermediate.path}"
+	type = "exported"
+	common_name = "Intermediate CA"
+	key_type = "ec"
+	key_bits = 256
+}
+
+resource "vault_pki_secret_backend_sign_intermediate" "test" {
+	backend = "${vault_pki_secret_backend_root_cert.test.backend}"
+	csr = "${vault_pki_secret_backend_intermediate_cert_request.test.csr}"
+	common_name = "Intermediate CA"
+	ttl = 43200
+}
+
+resource "vault_pki_secret_backend_intermediate_set_signed" "test" {
+	backend = "${vault_pki_secret_backend_intermediate_cert_request.test.backend}"
+	certificate = "${vault_pki_secret_backend_sign_intermediate.test.certificate}"
+}
+`, root, intermediate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_intermediate_cert_request.test", "csr"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_intermediate_cert_request.test", "private_key"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_intermediate_cert_request.test", "private_key_type", "ec"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_intermediate_set_signed.test", "id", intermediate+"/intermediate/set-signed"),
+					testPKISecretBackendCA(intermediate, "vault_pki_secret_backend_sign_intermediate.test"),
+				),
+			},
+		},
+	})
+}
+
+// testPKISecretBackendCA checks that the CA certificate of a PKI secret
+// backend matches the certificate of the given resource.
+func testPKISecretBackendCA(backend, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", resourceName)
+		}
+
+		client := testProvider.Meta().(*api.Client)
+		secret, err := client.Logical().Read(backend + "/cert/ca")
+		if err != nil {
+			return fmt.Errorf("error reading CA of %q: %s", backend, err)
+		}
+		if secret == nil {
+			return fmt.Errorf("no CA set in %q", backend)
+		}
+		got := strings.TrimSpace(secret.Data["certificate"].(string))
+		if want := strings.TrimSpace(rs.Primary.Attributes["certificate"]); got != want {
+			return fmt.Errorf("CA of %q is\n%s\nwant\n%s", backend, got, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var pkiSecretBackendIssuerIDRegex = regexp.MustCompile("^(.+)/issuer/([^/]+)$")
+
+func pkiSecretBackendIssuerResource() *schema.Resource {
+	return &schema.Resource{
+		Create: pkiSecretBackendIssuerCreate,
+		Update: pkiSecretBackendIssuerUpdate,
+		Delete: pkiSecretBackendIssuerDelete,
+		Read:   pkiSecretBackendIssuerRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the PKI secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"issuer_ref": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID or name of an existing issuer.",
+			},
+
+			"issuer_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the issuer.",
+			},
+
+			"leaf_not_after_behavior": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Behavior for leaf certificates outliving the issuer: err, truncate or permit.",
+			},
+
+			"usage": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Comma-separated list of allowed usages of the issuer.",
+			},
+
+			"revoked": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Revoke the issuer. Revocation can't be undone.",
+			},
+
+			"issuer_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the issuer.",
+			},
+
+			"revocation_time": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix time at which the issuer was revoked, 0 if it isn't.",
+			},
+		},
+	}
+}
+
+func pkiSecretBackendIssuerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/issuer/" + d.Get("issuer_ref").(string)
+
+	// Issuers are created by generating or importing CAs, so the resource
+	// takes over the configuration of an existing one.
+	log.Printf("[DEBUG] Reading PKI issuer %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading PKI issuer %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no PKI issuer found at %q", path)
+	}
+	issuerID, _ := secret.Data["issuer_id"].(string)
+	if issuerID == "" {
+		return fmt.Errorf("no issuer ID returned for PKI issuer %q", path)
+	}
+
+	d.SetId(backend + "/issuer/" + issuerID)
+
+	return pkiSecretBackendIssuerUpdate(d, meta)
+}
+
+func pkiSecretBackendIssuerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+	backend, err := pkiSecretBackendIssuerBackendFromID(path)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("revoked") && !d.Get("revoked").(bool) && !d.IsNewResource() {
+		return fmt.Errorf("PKI issuer %q is revoked, and revocation can't be undone", path)
+	}
+
+	data := map[string]interface{}{}
+	for _, k := range []string{"issuer_name", "leaf_not_after_behavior", "usage"} {
+		if v, ok := d.GetOk(k); ok {
+			data[k] = v.(string)
+		}
+	}
+	if len(data) > 0 {
+		log.Printf("[DEBUG] Updating PKI issuer %q in Vault", path)
+		if _, err := client.Logical().Write(path, data); err != nil {
+			return fmt.Errorf("error updating PKI issuer %q: %s", path, err)
+		}
+	}
+
+	if d.Get("revoked").(bool) && (d.IsNewResource() || d.HasChange("revoked")) {
+		log.Printf("[DEBUG] Revoking PKI issuer %q", path)
+		if _, err := client.Logical().Write(path+"/revoke", nil); err != nil {
+			return fmt.Errorf("error revoking PKI issuer %q: %s", path, err)
+		}
+
+		// Vault adds the issuer to the CRLs of its parents, rebuild
+		// them now so that the revocation is published right away.
+		log.Printf("[DEBUG] Rotating CRLs of PKI backend %q", backend)
+		if _, err := client.Logical().Read(backend + "/crl/rotate"); err != nil {
+			return fmt.Errorf("error rotating CRLs of PKI backend %q: %s", backend, err)
+		}
+	}
+
+	return pkiSecretBackendIssuerRead(d, meta)
+}
+
+func pkiSecretBackendIssuerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+	backend, err := pkiSecretBackendIssuerBackendFromID(path)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Reading PKI issuer %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading PKI issuer %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] PKI issuer %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", backend)
+	if _, ok := d.GetOk("issuer_ref"); !ok {
+		d.Set("issuer_ref", secret.Data["issuer_id"])
+	}
+	d.Set("issuer_id", secret.Data["issuer_id"])
+	d.Set("issuer_name", secret.Data["issuer_name"])
+	d.Set("leaf_not_after_behavior", secret.Data["leaf_not_after_behavior"])
+	d.Set("usage", secret.Data["usage"])
+	d.Set("revoked", secret.Data["revoked"])
+	d.Set("revocation_time", intFromResponse(secret.Data["revocation_time"]))
+
+	return nil
+}
+
+func pkiSecretBackendIssuerDelete(d *schema.ResourceData, meta interface{}) error {
+	// Deleting the issuer would also delete its certificate, so it is
+	// only removed from the state, as with other imported CAs.
+	log.Printf("[DEBUG] Removing PKI issuer %q from state", d.Id())
+
+	return nil
+}
+
+func pkiSecretBackendIssuerBackendFromID(id string) (string, error) {
+	res := pkiSecretBackendIssuerIDRegex.FindStringSubmatch(id)
+	if res == nil {
+		return "", fmt.Errorf("invalid PKI issuer ID %q, expected <backend>/issuer/<issuer_id>", id)
+	}
+	return res[1], nil
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestPkiSecretBackendIssuer(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testPkiSecretBackendIssuerMountConfig(backend),
+				Check: func(*terraform.State) error {
+					client := testProvider.Meta().(*api.Client)
+					_, err := client.Logical().Write(backend+"/root/generate/internal", map[string]interface{}{
+						"common_name": "Old Root CA",
+						"issuer_name": "old-root",
+						"ttl":         "8760h",
+					})
+					return err
+				},
+			},
+			{
+				Config: testPkiSecretBackendIssuerConfig(backend, "retired-root", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_issuer.test", "issuer_name", "retired-root"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_issuer.test", "revoked", "false"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_issuer.test", "revocation_time", "0"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_issuer.test", "issuer_id"),
+				),
+			},
+			{
+				Config: testPkiSecretBackendIssuerConfig(backend, "retired-root", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_issuer.test", "revoked", "true"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_issuer.test", "revocation_time"),
+				),
+			},
+			{
+				Config:      testPkiSecretBackendIssuerConfig(backend, "retired-root", false),
+				ExpectError: regexp.MustCompile("revocation can't be undone"),
+			},
+		},
+	})
+}
+
+func testPkiSecretBackendIssuerMountConfig(backend string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "pki" {
+	path = "%s"
+	type = "pki"
+	max_lease_ttl_seconds = 31536000
+}
+`, backend)
+}
+
+func testPkiSecretBackendIssuerConfig(backend, name string, revoked bool) string {
+	return testPkiSecretBackendIssuerMountConfig(backend) + fmt.Sprintf(`
+resource "vault_pki_secret_backend_issuer" "test" {
+	backend = "${vault_mount.pki.path}"
+	issuer_ref = "old-root"
+	issuer_name = "%s"
+	revoked = %t
+}
+`, name, revoked)
+}
@@ -0,0 +1,429 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var (
+	pkiSecretBackendRoleBackendFromPathRegex = regexp.MustCompile("^(.+)/roles/.+$")
+	pkiSecretBackendRoleNameFromPathRegex    = regexp.MustCompile("^.+/roles/(.+)$")
+)
+
+// pkiSecretBackendRoleBoolFields are the role fields that map one-to-one
+// onto boolean attributes of the resource.
+var pkiSecretBackendRoleBoolFields = []string{
+	"allow_localhost",
+	"allow_bare_domains",
+	"allow_subdomains",
+	"allow_glob_domains",
+	"allow_any_name",
+	"enforce_hostnames",
+	"allow_ip_sans",
+	"server_flag",
+	"client_flag",
+	"code_signing_flag",
+	"email_protection_flag",
+	"use_csr_common_name",
+	"use_csr_sans",
+	"require_cn",
+	"generate_lease",
+	"no_store",
+}
+
+// pkiSecretBackendRoleListFields are the role fields that map onto lists
+// of strings.
+var pkiSecretBackendRoleListFields = []string{
+	"allowed_domains",
+	"allowed_uri_sans",
+	"allowed_other_sans",
+	"cn_validations",
+	"key_usage",
+	"allowed_user_ids",
+}
+
+func pkiSecretBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: pkiSecretBackendRoleWrite,
+		Update: pkiSecretBackendRoleWrite,
+		Delete: pkiSecretBackendRoleDelete,
+		Read:   pkiSecretBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the PKI secret backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default TTL in seconds for certificates issued by this role.",
+			},
+
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TTL in seconds for certificates issued by this role.",
+			},
+
+			"allow_localhost": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether localhost may be requested as a common name or SAN.",
+			},
+
+			"allowed_domains": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Domains for which certificates may be requested.",
+			},
+
+			"allow_bare_domains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the allowed domains themselves may be requested.",
+			},
+
+			"allow_subdomains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether subdomains of the allowed domains may be requested.",
+			},
+
+			"allow_glob_domains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether glob patterns are permitted in allowed_domains.",
+			},
+
+			"allow_any_name": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether any common name may be requested.",
+			},
+
+			"enforce_hostnames": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether common names and DNS SANs must be valid hostnames.",
+			},
+
+			"allow_ip_sans": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether IP SANs may be requested.",
+			},
+
+			"allowed_uri_sans": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "URI SANs, possibly globbed, that may be requested, e.g. SPIFFE IDs.",
+			},
+
+			"allowed_other_sans": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Other SANs that may be requested, in the form <oid>;<type>:<value>.",
+			},
+
+			"cn_validations": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validatePKICNValidation,
+				},
+				Description: "Validations applied to the common name: email, hostname or disabled.",
+			},
+
+			"require_cn": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether a common name is required when issuing certificates.",
+			},
+
+			"server_flag": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether issued certificates are flagged for server use.",
+			},
+
+			"client_flag": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether issued certificates are flagged for client use.",
+			},
+
+			"code_signing_flag": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether issued certificates are flagged for code signing use.",
+			},
+
+			"email_protection_flag": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether issued certificates are flagged for email protection use.",
+			},
+
+			"key_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "rsa",
+				Description: "Type of the generated keys: rsa, ec or any.",
+			},
+
+			"key_bits": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     2048,
+				Description: "Number of bits of the generated keys.",
+			},
+
+			"key_usage": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Allowed key usages for issued certificates.",
+			},
+
+			"use_csr_common_name": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the common name in a CSR is used when signing.",
+			},
+
+			"use_csr_sans": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the SANs in a CSR are used when signing.",
+			},
+
+			"generate_lease": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether leases are generated for issued certificates.",
+			},
+
+			"no_store": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether issued certificates are kept out of storage.",
+			},
+
+			"no_store_metadata": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the metadata of issued certificates is kept out of storage.",
+			},
+
+			"serial_number_source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validatePKISerialNumberSource,
+				Description:  "Source of the subject serial number of issued certificates: json-csr or json.",
+			},
+
+			"allowed_user_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "User IDs, possibly globbed, that may be requested in the userID subject attribute.",
+			},
+		},
+	}
+}
+
+func validatePKICNValidation(v interface{}, k string) (ws []string, errs []error) {
+	switch value := v.(string); value {
+	case "email", "hostname", "disabled":
+	default:
+		errs = append(errs, fmt.Errorf("%s: unsupported value %q, must be one of email, hostname or disabled", k, value))
+	}
+	return
+}
+
+func validatePKISerialNumberSource(v interface{}, k string) (ws []string, errs []error) {
+	switch value := v.(string); value {
+	case "json-csr", "json":
+	default:
+		errs = append(errs, fmt.Errorf("%s: unsupported value %q, must be json-csr or json", k, value))
+	}
+	return
+}
+
+func pkiSecretBackendRolePath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(name, "/")
+}
+
+func pkiSecretBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := pkiSecretBackendRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"key_type": d.Get("key_type").(string),
+		"key_bits": d.Get("key_bits").(int),
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		data["ttl"] = v.(int)
+	}
+	if v, ok := d.GetOk("max_ttl"); ok {
+		data["max_ttl"] = v.(int)
+	}
+	if v, ok := d.GetOk("serial_number_source"); ok {
+		data["serial_number_source"] = v.(string)
+	}
+	for _, k := range pkiSecretBackendRoleBoolFields {
+		data[k] = d.Get(k).(bool)
+	}
+	// no_store_metadata is only supported by Vault Enterprise, so it's only
+	// sent once it has been enabled.
+	if d.Get("no_store_metadata").(bool) || d.HasChange("no_store_metadata") {
+		data["no_store_metadata"] = d.Get("no_store_metadata").(bool)
+	}
+	for _, k := range pkiSecretBackendRoleListFields {
+		data[k] = toStringArray(d.Get(k).([]interface{}))
+	}
+	// Lists that Vault populates with defaults are omitted when unset, so
+	// that the defaults apply instead of an empty list.
+	for _, k := range []string{"cn_validations", "key_usage"} {
+		if len(data[k].([]string)) == 0 {
+			delete(data, k)
+		}
+	}
+
+	log.Printf("[DEBUG] Writing PKI role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing PKI role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return pkiSecretBackendRoleRead(d, meta)
+}
+
+func pkiSecretBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	backend, err := pkiSecretBackendRoleBackendFromPath(path)
+	if err != nil {
+		return fmt.Errorf("invalid PKI role ID %q: %s", path, err)
+	}
+	name, err := pkiSecretBackendRoleNameFromPath(path)
+	if err != nil {
+		return fmt.Errorf("invalid PKI role ID %q: %s", path, err)
+	}
+
+	log.Printf("[DEBUG] Reading PKI role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading PKI role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] PKI role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", backend)
+	d.Set("name", name)
+	d.Set("ttl", durationSecondsFromResponse(secret.Data["ttl"]))
+	d.Set("max_ttl", durationSecondsFromResponse(secret.Data["max_ttl"]))
+	d.Set("key_type", secret.Data["key_type"])
+	d.Set("key_bits", intFromResponse(secret.Data["key_bits"]))
+	for _, k := range []string{"serial_number_source", "no_store_metadata"} {
+		if v, ok := secret.Data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+	for _, k := range pkiSecretBackendRoleBoolFields {
+		if v, ok := secret.Data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+	for _, k := range pkiSecretBackendRoleListFields {
+		if v, ok := secret.Data[k]; ok {
+			d.Set(k, flattenStringList(v))
+		}
+	}
+
+	return nil
+}
+
+func pkiSecretBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting PKI role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting PKI role %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func pkiSecretBackendRoleBackendFromPath(path string) (string, error) {
+	if !pkiSecretBackendRoleBackendFromPathRegex.MatchString(path) {
+		return "", fmt.Errorf("no backend found")
+	}
+	res := pkiSecretBackendRoleBackendFromPathRegex.FindStringSubmatch(path)
+	return res[1], nil
+}
+
+func pkiSecretBackendRoleNameFromPath(path string) (string, error) {
+	if !pkiSecretBackendRoleNameFromPathRegex.MatchString(path) {
+		return "", fmt.Errorf("no role name found")
+	}
+	res := pkiSecretBackendRoleNameFromPathRegex.FindStringSubmatch(path)
+	return res[1], nil
+}
@@ -0,0 +1,111 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestPKISecretBackendRole_basic(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	name := acctest.RandomWithPrefix("role")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testPKISecretBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPKISecretBackendRole_initialConfig(backend, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "name", name),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "backend", backend),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "allowed_domains.#", "1"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "allowed_uri_sans.#", "1"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "allowed_uri_sans.0", "spiffe://example.com/*"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "require_cn", "false"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "serial_number_source", "json-csr"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "allowed_user_ids.#", "0"),
+				),
+			},
+			{
+				Config: testPKISecretBackendRole_updateConfig(backend, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "allowed_other_sans.#", "1"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "cn_validations.#", "1"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "cn_validations.0", "disabled"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "require_cn", "true"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "serial_number_source", "json"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "allowed_user_ids.#", "2"),
+					resource.TestCheckResourceAttr("vault_pki_secret_backend_role.test", "allowed_user_ids.1", "svc-*"),
+				),
+			},
+			{
+				ResourceName:      "vault_pki_secret_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testPKISecretBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_pki_secret_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testPKISecretBackendRole_initialConfig(backend, name string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "pki" {
+	path = "%s"
+	type = "pki"
+}
+
+resource "vault_pki_secret_backend_role" "test" {
+	backend = "${vault_mount.pki.path}"
+	name = "%s"
+	ttl = 3600
+	allowed_domains = ["example.com"]
+	allowed_uri_sans = ["spiffe://example.com/*"]
+	require_cn = false
+}
+`, backend, name)
+}
+
+func testPKISecretBackendRole_updateConfig(backend, name string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "pki" {
+	path = "%s"
+	type = "pki"
+}
+
+resource "vault_pki_secret_backend_role" "test" {
+	backend = "${vault_mount.pki.path}"
+	name = "%s"
+	ttl = 3600
+	allowed_domains = ["example.com"]
+	allowed_uri_sans = ["spiffe://example.com/*"]
+	allowed_other_sans = ["1.3.6.1.4.1.311.20.2.3;utf8:*@example.com"]
+	cn_validations = ["disabled"]
+	require_cn = true
+	serial_number_source = "json"
+	allowed_user_ids = ["inventory", "svc-*"]
+}
+`, backend, name)
+}
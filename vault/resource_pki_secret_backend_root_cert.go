@@ -0,0 +1,130 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func pkiSecretBackendRootCertResource() *schema.Resource {
+	s := pkiSecretBackendSubjectSchema()
+
+	s["backend"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Path of the PKI secret backend to generate the root CA in.",
+		StateFunc: func(v interface{}) string {
+			return strings.Trim(v.(string), "/")
+		},
+	}
+	s["type"] = pkiSecretBackendTypeSchema()
+	s["ttl"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "TTL in seconds of the root CA certificate.",
+	}
+	s["max_path_length"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     -1,
+		Description: "Maximum path length of the certificate chain below the root CA. -1 means no limit.",
+	}
+	s["permitted_dns_domains"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "DNS domains the root CA is restricted to.",
+	}
+
+	s["certificate"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The root CA certificate.",
+	}
+	s["issuing_ca"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The certificate of the issuing CA, the root CA itself.",
+	}
+	s["serial_number"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The serial number of the root CA certificate.",
+	}
+	s["private_key"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The private key of the root CA, only set when type is exported.",
+	}
+	s["private_key_type"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The type of the private key, only set when type is exported.",
+	}
+
+	return &schema.Resource{
+		Create: pkiSecretBackendRootCertCreate,
+		Delete: pkiSecretBackendRootCertDelete,
+		Read:   pkiSecretBackendRootCertRead,
+
+		Schema: s,
+	}
+}
+
+func pkiSecretBackendRootCertCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/root/generate/" + d.Get("type").(string)
+
+	data := pkiSecretBackendSubjectData(d)
+	data["max_path_length"] = d.Get("max_path_length").(int)
+	data["permitted_dns_domains"] = toStringArray(d.Get("permitted_dns_domains").([]interface{}))
+	if v, ok := d.GetOk("ttl"); ok {
+		data["ttl"] = v.(int)
+	}
+
+	log.Printf("[DEBUG] Generating root CA %q with %q", d.Get("common_name").(string), path)
+	secret, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error generating root CA with %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no certificate returned by %q", path)
+	}
+
+	d.SetId(backend + "/root")
+	d.Set("certificate", secret.Data["certificate"])
+	d.Set("issuing_ca", secret.Data["issuing_ca"])
+	d.Set("serial_number", secret.Data["serial_number"])
+	d.Set("private_key", secret.Data["private_key"])
+	d.Set("private_key_type", secret.Data["private_key_type"])
+
+	return pkiSecretBackendRootCertRead(d, meta)
+}
+
+func pkiSecretBackendRootCertRead(d *schema.ResourceData, meta interface{}) error {
+	// Generated certificates never change, so there's nothing to refresh.
+	return nil
+}
+
+func pkiSecretBackendRootCertDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting root CA %q", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting root CA %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func pkiSecretBackendSignIntermediateResource() *schema.Resource {
+	return &schema.Resource{
+		Create: pkiSecretBackendSignIntermediateCreate,
+		Delete: pkiSecretBackendSignIntermediateDelete,
+		Read:   pkiSecretBackendSignIntermediateRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the PKI secret backend holding the root CA.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"csr": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "PEM-encoded CSR of the intermediate CA.",
+			},
+
+			"common_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Common name of the intermediate CA certificate.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "TTL in seconds of the intermediate CA certificate.",
+			},
+
+			"max_path_length": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     -1,
+				Description: "Maximum path length of the certificate chain below the intermediate CA. -1 means no limit.",
+			},
+
+			"permitted_dns_domains": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "DNS domains the intermediate CA is restricted to.",
+			},
+
+			"use_csr_values": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether the subject and extensions of the CSR are used instead of the request values.",
+			},
+
+			"certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The signed intermediate CA certificate.",
+			},
+
+			"issuing_ca": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The certificate of the CA that signed the intermediate.",
+			},
+
+			"ca_chain": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The chain of CA certificates of the signed intermediate.",
+			},
+
+			"serial_number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The serial number of the signed intermediate CA certificate.",
+			},
+		},
+	}
+}
+
+func pkiSecretBackendSignIntermediateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/root/sign-intermediate"
+
+	data := map[string]interface{}{
+		"csr":                   d.Get("csr").(string),
+		"common_name":           d.Get("common_name").(string),
+		"max_path_length":       d.Get("max_path_length").(int),
+		"permitted_dns_domains": toStringArray(d.Get("permitted_dns_domains").([]interface{})),
+		"use_csr_values":        d.Get("use_csr_values").(bool),
+		"format":                "pem",
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		data["ttl"] = v.(int)
+	}
+
+	log.Printf("[DEBUG] Signing intermediate CA %q with %q", d.Get("common_name").(string), path)
+	secret, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error signing intermediate CA with %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no certificate returned by %q", path)
+	}
+
+	serial, _ := secret.Data["serial_number"].(string)
+
+	d.SetId(backend + "/" + serial)
+	d.Set("certificate", secret.Data["certificate"])
+	d.Set("issuing_ca", secret.Data["issuing_ca"])
+	d.Set("ca_chain", flattenStringList(secret.Data["ca_chain"]))
+	d.Set("serial_number", serial)
+
+	return pkiSecretBackendSignIntermediateRead(d, meta)
+}
+
+func pkiSecretBackendSignIntermediateRead(d *schema.ResourceData, meta interface{}) error {
+	// Signed certificates never change, so there's nothing to refresh.
+	return nil
+}
+
+func pkiSecretBackendSignIntermediateDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	serial := d.Get("serial_number").(string)
+
+	log.Printf("[DEBUG] Revoking intermediate CA %q in %q", serial, backend)
+	_, err := client.Logical().Write(backend+"/revoke", map[string]interface{}{
+		"serial_number": serial,
+	})
+	if err != nil {
+		return fmt.Errorf("error revoking intermediate CA %q in %q: %s", serial, backend, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestPKISecretBackendSignIntermediate(t *testing.T) {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skip(fmt.Sprintf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar))
+	}
+	testAccPreCheck(t)
+
+	// The CSR has to exist before the configuration is rendered, so the
+	// intermediate backend is prepared directly through the API.
+	client, err := api.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediate := acctest.RandomWithPrefix("pki-intermediate")
+	if err := client.Sys().Mount(intermediate, &api.MountInput{Type: "pki"}); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Sys().Unmount(intermediate)
+	csrSecret, err := client.Logical().Write(intermediate+"/intermediate/generate/internal", map[string]interface{}{
+		"common_name": "Intermediate CA",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr := csrSecret.Data["csr"].(string)
+
+	root := acctest.RandomWithPrefix("pki-root")
+	defer client.Sys().Unmount(root)
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := client.Sys().Mount(root, &api.MountInput{Type: "pki"}); err != nil {
+						t.Fatal(err)
+					}
+					_, err := client.Logical().Write(root+"/root/generate/internal", map[string]interface{}{
+						"common_name": "Root CA",
+						"ttl":         "87600h",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(`
+resource "vault_pki_secret_backend_sign_intermediate" "test" {
+	backend = "%s"
+	csr = <<EOT
+%s
+EOT
+	common_name = "Intermediate CA"
+	ttl = 43200
+	max_path_length = 1
+	permitted_dns_domains = ["example.com"]
+}
+`, root, csr),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_sign_intermediate.test", "certificate"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_sign_intermediate.test", "issuing_ca"),
+					resource.TestCheckResourceAttrSet("vault_pki_secret_backend_sign_intermediate.test", "serial_number"),
+				),
+			},
+		},
+	})
+}
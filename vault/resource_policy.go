@@ -3,7 +3,10 @@ package vault
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"strings"
 
+	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/vault/api"
 )
@@ -27,14 +30,47 @@ func policyResource() *schema.Resource {
 			},
 
 			"policy": &schema.Schema{
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The policy document",
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The policy document",
+				ValidateFunc: validatePolicyHCL,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return policyEquivalent(old, new)
+				},
 			},
 		},
 	}
 }
 
+// validatePolicyHCL checks that the policy document is syntactically valid
+// HCL or JSON, so that mistakes are reported at plan time. Whether the
+// policy makes sense is still left for Vault to decide.
+func validatePolicyHCL(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := hcl.Parse(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: error parsing policy: %s", k, err))
+	}
+	return
+}
+
+// policyEquivalent reports whether two policy documents grant the same
+// rules, ignoring formatting and comments and whether they are written in
+// HCL or JSON. Vault stores policies verbatim, so without this reformatting
+// a policy, or importing one, would show a diff.
+func policyEquivalent(a, b string) bool {
+	if strings.TrimSpace(a) == strings.TrimSpace(b) {
+		return true
+	}
+
+	var aRules, bRules interface{}
+	if err := hcl.Decode(&aRules, a); err != nil {
+		return false
+	}
+	if err := hcl.Decode(&bRules, b); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aRules, bRules)
+}
+
 func policyWrite(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*api.Client)
 
@@ -78,6 +114,11 @@ func policyRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error reading from Vault: %s", err)
 	}
+	if policy == "" {
+		log.Printf("[WARN] Policy %q not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
 
 	d.Set("policy", policy)
 	d.Set("name", name)
@@ -2,6 +2,7 @@ package vault
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -117,3 +118,82 @@ func testResourcePolicy_updateCheck(s *terraform.State) error {
 
 	return nil
 }
+
+func TestValidatePolicyHCL(t *testing.T) {
+	valid := []string{
+		`path "secret/*" { policy = "read" }`,
+		`path "secret/*" { capabilities = ["read", "list"] }`,
+		`{"path": {"secret/*": {"capabilities": ["read"]}}}`,
+	}
+	for _, policy := range valid {
+		if _, errs := validatePolicyHCL(policy, "policy"); len(errs) > 0 {
+			t.Errorf("unexpected errors validating %q: %v", policy, errs)
+		}
+	}
+
+	_, errs := validatePolicyHCL("path \"secret/*\" {\n  capabilities = [\"read\" \"list\"]\n}\n", "policy")
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "2:26") {
+		t.Errorf("expected the error to include the position, got %q", errs[0])
+	}
+}
+
+func TestPolicyEquivalent(t *testing.T) {
+	policy := "path \"secret/*\" {\n\tcapabilities = [\"read\", \"list\"]\n}\n"
+
+	equivalent := []string{
+		policy,
+		`path "secret/*" { capabilities = ["read", "list"] }`,
+		"# Read-only access\npath \"secret/*\" {\n  capabilities = [\"read\", \"list\"]\n}",
+		`{"path": {"secret/*": {"capabilities": ["read", "list"]}}}`,
+	}
+	for _, other := range equivalent {
+		if !policyEquivalent(policy, other) {
+			t.Errorf("expected %q to be equivalent to %q", other, policy)
+		}
+	}
+
+	different := []string{
+		`path "secret/*" { capabilities = ["read"] }`,
+		`path "secret/foo" { capabilities = ["read", "list"] }`,
+		`path "secret/*" { capabilities = ["read", "list"`,
+	}
+	for _, other := range different {
+		if policyEquivalent(policy, other) {
+			t.Errorf("expected %q not to be equivalent to %q", other, policy)
+		}
+	}
+}
+
+func TestResourcePolicy_import(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testResourcePolicy_initialConfig(name),
+			},
+			resource.TestStep{
+				// An equivalent policy formatted differently from the
+				// configuration doesn't need to be changed.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if err := client.Sys().PutPolicy(name, `{"path": {"secret/*": {"policy": "read"}}}`); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   testResourcePolicy_initialConfig(name),
+				PlanOnly: true,
+			},
+			resource.TestStep{
+				ResourceName:            "vault_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"policy"},
+			},
+		},
+	})
+}
@@ -0,0 +1,106 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func quotaLeaseCountResource() *schema.Resource {
+	return &schema.Resource{
+		Create: quotaLeaseCountWrite,
+		Update: quotaLeaseCountWrite,
+		Delete: quotaLeaseCountDelete,
+		Read:   quotaLeaseCountRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the quota.",
+			},
+
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path, mount or namespace the quota applies to, the whole cluster if empty.",
+			},
+
+			"max_leases": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Maximum number of leases that can be outstanding at a time.",
+			},
+		},
+	}
+}
+
+func quotaLeaseCountPath(name string) string {
+	return "sys/quotas/lease-count/" + name
+}
+
+func quotaLeaseCountWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Get("name").(string)
+	path := quotaLeaseCountPath(name)
+
+	log.Printf("[DEBUG] Writing lease count quota %q to Vault", name)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"path":       d.Get("path").(string),
+		"max_leases": d.Get("max_leases").(int),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing lease count quota %q: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return quotaLeaseCountRead(d, meta)
+}
+
+func quotaLeaseCountRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Reading lease count quota %q from Vault", name)
+	secret, err := client.Logical().Read(quotaLeaseCountPath(name))
+	if err != nil {
+		return fmt.Errorf("error reading lease count quota %q: %s", name, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Lease count quota %q not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	// Vault returns the path of mounts with a trailing slash.
+	if path, _ := secret.Data["path"].(string); strings.TrimSuffix(path, "/") != strings.TrimSuffix(d.Get("path").(string), "/") {
+		d.Set("path", path)
+	}
+	d.Set("max_leases", intFromResponse(secret.Data["max_leases"]))
+
+	return nil
+}
+
+func quotaLeaseCountDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Deleting lease count quota %q from Vault", name)
+	if _, err := client.Logical().Delete(quotaLeaseCountPath(name)); err != nil {
+		return fmt.Errorf("error deleting lease count quota %q: %s", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestQuotaLeaseCount(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	// Lease count quotas are only available in Vault Enterprise.
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+
+	name := acctest.RandomWithPrefix("test-lease-count")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testQuotaCheckDestroy("lease-count", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testQuotaLeaseCountConfig(name, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_quota_lease_count.test", "name", name),
+					resource.TestCheckResourceAttr("vault_quota_lease_count.test", "max_leases", "100"),
+				),
+			},
+			{
+				Config: testQuotaLeaseCountConfig(name, 200),
+				Check:  resource.TestCheckResourceAttr("vault_quota_lease_count.test", "max_leases", "200"),
+			},
+			{
+				ResourceName:      "vault_quota_lease_count.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testQuotaLeaseCountConfig(name string, maxLeases int) string {
+	return fmt.Sprintf(`
+resource "vault_quota_lease_count" "test" {
+	name = "%s"
+	path = "sys"
+	max_leases = %d
+}
+`, name, maxLeases)
+}
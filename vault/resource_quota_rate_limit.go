@@ -0,0 +1,129 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func quotaRateLimitResource() *schema.Resource {
+	return &schema.Resource{
+		Create: quotaRateLimitWrite,
+		Update: quotaRateLimitWrite,
+		Delete: quotaRateLimitDelete,
+		Read:   quotaRateLimitRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the quota.",
+			},
+
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path, mount or namespace the quota applies to, the whole cluster if empty.",
+			},
+
+			"rate": {
+				Type:        schema.TypeFloat,
+				Required:    true,
+				Description: "Maximum number of requests per interval.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if v.(float64) <= 0 {
+						errs = append(errs, fmt.Errorf("%s must be greater than 0, got %v", k, v))
+					}
+					return
+				},
+			},
+
+			"interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Seconds of the interval the rate applies to.",
+			},
+
+			"block_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Seconds during which clients exceeding the rate are rejected, only for the rest of the interval if 0.",
+			},
+		},
+	}
+}
+
+func quotaRateLimitPath(name string) string {
+	return "sys/quotas/rate-limit/" + name
+}
+
+func quotaRateLimitWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Get("name").(string)
+	path := quotaRateLimitPath(name)
+
+	log.Printf("[DEBUG] Writing rate limit quota %q to Vault", name)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"path":           d.Get("path").(string),
+		"rate":           d.Get("rate").(float64),
+		"interval":       fmt.Sprintf("%ds", d.Get("interval").(int)),
+		"block_interval": fmt.Sprintf("%ds", d.Get("block_interval").(int)),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing rate limit quota %q: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return quotaRateLimitRead(d, meta)
+}
+
+func quotaRateLimitRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Reading rate limit quota %q from Vault", name)
+	secret, err := client.Logical().Read(quotaRateLimitPath(name))
+	if err != nil {
+		return fmt.Errorf("error reading rate limit quota %q: %s", name, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Rate limit quota %q not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	// Vault returns the path of mounts with a trailing slash.
+	if path, _ := secret.Data["path"].(string); strings.TrimSuffix(path, "/") != strings.TrimSuffix(d.Get("path").(string), "/") {
+		d.Set("path", path)
+	}
+	d.Set("rate", floatFromResponse(secret.Data["rate"]))
+	d.Set("interval", durationSecondsFromResponse(secret.Data["interval"]))
+	d.Set("block_interval", durationSecondsFromResponse(secret.Data["block_interval"]))
+
+	return nil
+}
+
+func quotaRateLimitDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Id()
+
+	log.Printf("[DEBUG] Deleting rate limit quota %q from Vault", name)
+	if _, err := client.Logical().Delete(quotaRateLimitPath(name)); err != nil {
+		return fmt.Errorf("error deleting rate limit quota %q: %s", name, err)
+	}
+
+	return nil
+}
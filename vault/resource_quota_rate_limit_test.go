@@ -0,0 +1,71 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestQuotaRateLimit(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-rate-limit")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testQuotaCheckDestroy("rate-limit", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testQuotaRateLimitConfig(name, "", 100, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_quota_rate_limit.test", "name", name),
+					resource.TestCheckResourceAttr("vault_quota_rate_limit.test", "path", ""),
+					resource.TestCheckResourceAttr("vault_quota_rate_limit.test", "rate", "100"),
+					resource.TestCheckResourceAttr("vault_quota_rate_limit.test", "interval", "1"),
+				),
+			},
+			{
+				Config: testQuotaRateLimitConfig(name, "sys", 10.5, 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_quota_rate_limit.test", "path", "sys"),
+					resource.TestCheckResourceAttr("vault_quota_rate_limit.test", "rate", "10.5"),
+					resource.TestCheckResourceAttr("vault_quota_rate_limit.test", "block_interval", "60"),
+				),
+			},
+			{
+				ResourceName:            "vault_quota_rate_limit.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"path"},
+			},
+		},
+	})
+}
+
+func testQuotaCheckDestroy(quotaType, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		path := "sys/quotas/" + quotaType + "/" + name
+		secret, err := client.Logical().Read(path)
+		if err != nil {
+			return fmt.Errorf("error checking for quota %q: %s", path, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("quota %q still exists", path)
+		}
+		return nil
+	}
+}
+
+func testQuotaRateLimitConfig(name, path string, rate float64, blockInterval int) string {
+	return fmt.Sprintf(`
+resource "vault_quota_rate_limit" "test" {
+	name = "%s"
+	path = "%s"
+	rate = %v
+	block_interval = %d
+}
+`, name, path, rate, blockInterval)
+}
@@ -0,0 +1,200 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func rabbitMQSecretBackendResource() *schema.Resource {
+	return &schema.Resource{
+		Create: rabbitMQSecretBackendCreate,
+		Update: rabbitMQSecretBackendUpdate,
+		Delete: rabbitMQSecretBackendDelete,
+		Read:   rabbitMQSecretBackendRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "rabbitmq",
+				Description: "Path to mount the backend at.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly description of the mount.",
+			},
+
+			"default_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"max_lease_ttl_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TTL in seconds of the credentials issued by the backend.",
+			},
+
+			"connection_uri": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "URI of the RabbitMQ management API, e.g. http://rabbitmq:15672.",
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Username of the RabbitMQ administrator Vault creates users with.",
+			},
+
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the RabbitMQ administrator.",
+			},
+
+			"verify_connection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether Vault checks the connection when it's written.",
+			},
+		},
+	}
+}
+
+func rabbitMQSecretBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	log.Printf("[DEBUG] Mounting RabbitMQ secret backend at %q", path)
+	err := client.Sys().Mount(path, &api.MountInput{
+		Type:        "rabbitmq",
+		Description: d.Get("description").(string),
+		Config: api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error mounting RabbitMQ secret backend at %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	if err := rabbitMQSecretBackendWriteConnection(client, d); err != nil {
+		return err
+	}
+
+	return rabbitMQSecretBackendRead(d, meta)
+}
+
+func rabbitMQSecretBackendUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	if d.HasChange("default_lease_ttl_seconds") || d.HasChange("max_lease_ttl_seconds") {
+		log.Printf("[DEBUG] Tuning RabbitMQ secret backend %q", path)
+		err := client.Sys().TuneMount(path, api.MountConfigInput{
+			DefaultLeaseTTL: fmt.Sprintf("%ds", d.Get("default_lease_ttl_seconds")),
+			MaxLeaseTTL:     fmt.Sprintf("%ds", d.Get("max_lease_ttl_seconds")),
+		})
+		if err != nil {
+			return fmt.Errorf("error tuning RabbitMQ secret backend %q: %s", path, err)
+		}
+	}
+
+	if d.HasChange("description") {
+		if err := mountTune(client, path, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("connection_uri") || d.HasChange("username") ||
+		d.HasChange("password") || d.HasChange("verify_connection") {
+		if err := rabbitMQSecretBackendWriteConnection(client, d); err != nil {
+			return err
+		}
+	}
+
+	return rabbitMQSecretBackendRead(d, meta)
+}
+
+// rabbitMQSecretBackendWriteConnection writes the connection of the backend
+// to the management API, which is replaced all together on every write.
+func rabbitMQSecretBackendWriteConnection(client *api.Client, d *schema.ResourceData) error {
+	path := d.Id() + "/config/connection"
+
+	log.Printf("[DEBUG] Writing RabbitMQ secret backend connection %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"connection_uri":    d.Get("connection_uri").(string),
+		"username":          d.Get("username").(string),
+		"password":          d.Get("password").(string),
+		"verify_connection": d.Get("verify_connection").(bool),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing RabbitMQ secret backend connection %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func rabbitMQSecretBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Reading RabbitMQ secret backend %q", path)
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("error reading mounts from Vault: %s", err)
+	}
+	mount, ok := mounts[path+"/"]
+	if !ok {
+		log.Printf("[WARN] RabbitMQ secret backend %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	// The connection can't be read back, so it's kept from the configuration.
+	d.Set("path", path)
+	d.Set("description", mount.Description)
+	d.Set("default_lease_ttl_seconds", mount.Config.DefaultLeaseTTL)
+	d.Set("max_lease_ttl_seconds", mount.Config.MaxLeaseTTL)
+
+	return nil
+}
+
+func rabbitMQSecretBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Unmounting RabbitMQ secret backend %q", path)
+	if err := client.Sys().Unmount(path); err != nil {
+		return fmt.Errorf("error unmounting RabbitMQ secret backend %q: %s", path, err)
+	}
+
+	return nil
+}
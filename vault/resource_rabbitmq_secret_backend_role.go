@@ -0,0 +1,191 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var rabbitMQSecretBackendRoleFromPathRegex = regexp.MustCompile("^(.+)/roles/([^/]+)$")
+
+func rabbitMQSecretBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: rabbitMQSecretBackendRoleWrite,
+		Update: rabbitMQSecretBackendRoleWrite,
+		Delete: rabbitMQSecretBackendRoleDelete,
+		Read:   rabbitMQSecretBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "rabbitmq",
+				Description: "Path of the RabbitMQ secret backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "RabbitMQ tags of the users created, e.g. management.",
+			},
+
+			"vhost": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Permissions of the users created on a virtual host.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the virtual host.",
+						},
+						"configure": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Regular expression of the resources the users can configure.",
+						},
+						"write": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Regular expression of the resources the users can write to.",
+						},
+						"read": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Regular expression of the resources the users can read from.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func rabbitMQSecretBackendRolePath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(name, "/")
+}
+
+func rabbitMQSecretBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := rabbitMQSecretBackendRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	// Vault takes the permissions as a JSON object keyed by virtual host.
+	vhosts := map[string]interface{}{}
+	for _, raw := range d.Get("vhost").(*schema.Set).List() {
+		vhost := raw.(map[string]interface{})
+		vhosts[vhost["host"].(string)] = map[string]interface{}{
+			"configure": vhost["configure"],
+			"write":     vhost["write"],
+			"read":      vhost["read"],
+		}
+	}
+	encoded, err := json.Marshal(vhosts)
+	if err != nil {
+		return fmt.Errorf("error encoding vhosts of RabbitMQ role %q: %s", path, err)
+	}
+
+	data := map[string]interface{}{
+		"tags":   strings.Join(toStringArray(d.Get("tags").([]interface{})), ","),
+		"vhosts": string(encoded),
+	}
+
+	log.Printf("[DEBUG] Writing RabbitMQ role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing RabbitMQ role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return rabbitMQSecretBackendRoleRead(d, meta)
+}
+
+// rabbitMQVhostsFromResponse converts the permissions of a role, keyed by
+// virtual host, into vhost blocks.
+func rabbitMQVhostsFromResponse(raw interface{}) []map[string]interface{} {
+	vhosts, _ := raw.(map[string]interface{})
+	hosts := make([]string, 0, len(vhosts))
+	for host := range vhosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	result := make([]map[string]interface{}, 0, len(hosts))
+	for _, host := range hosts {
+		permissions, _ := vhosts[host].(map[string]interface{})
+		vhost := map[string]interface{}{"host": host}
+		for _, k := range []string{"configure", "write", "read"} {
+			vhost[k], _ = permissions[k].(string)
+		}
+		result = append(result, vhost)
+	}
+	return result
+}
+
+func rabbitMQSecretBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := rabbitMQSecretBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid RabbitMQ role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading RabbitMQ role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading RabbitMQ role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] RabbitMQ role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	if err := d.Set("tags", flattenStringList(secret.Data["tags"])); err != nil {
+		return fmt.Errorf("error setting tags of RabbitMQ role %q: %s", path, err)
+	}
+	if err := d.Set("vhost", rabbitMQVhostsFromResponse(secret.Data["vhosts"])); err != nil {
+		return fmt.Errorf("error setting vhost of RabbitMQ role %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func rabbitMQSecretBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting RabbitMQ role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting RabbitMQ role %q: %s", path, err)
+	}
+
+	return nil
+}
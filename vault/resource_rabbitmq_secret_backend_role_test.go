@@ -0,0 +1,109 @@
+package vault
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestRabbitMQVhostsFromResponse(t *testing.T) {
+	raw := map[string]interface{}{
+		"/":   map[string]interface{}{"configure": ".*", "write": ".*", "read": ".*"},
+		"dev": map[string]interface{}{"read": "^logs$"},
+	}
+	expected := []map[string]interface{}{
+		{"host": "/", "configure": ".*", "write": ".*", "read": ".*"},
+		{"host": "dev", "configure": "", "write": "", "read": "^logs$"},
+	}
+	if got := rabbitMQVhostsFromResponse(raw); !reflect.DeepEqual(got, expected) {
+		t.Errorf("rabbitMQVhostsFromResponse() = %v, expected %v", got, expected)
+	}
+	if got := rabbitMQVhostsFromResponse(nil); len(got) != 0 {
+		t.Errorf("rabbitMQVhostsFromResponse(nil) = %v, expected no vhosts", got)
+	}
+}
+
+func TestRabbitMQSecretBackendRole(t *testing.T) {
+	backend := acctest.RandomWithPrefix("rabbitmq")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testRabbitMQSecretBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testRabbitMQSecretBackendRoleConfig(backend, `["management"]`, ".*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend_role.test", "backend", backend),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend_role.test", "name", "app"),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend_role.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend_role.test", "vhost.#", "2"),
+				),
+			},
+			{
+				Config: testRabbitMQSecretBackendRoleConfig(backend, `["management", "monitoring"]`, "^app-"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend_role.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend_role.test", "vhost.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "vault_rabbitmq_secret_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testRabbitMQSecretBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_rabbitmq_secret_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("RabbitMQ role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testRabbitMQSecretBackendRoleConfig(backend, tags, write string) string {
+	return fmt.Sprintf(`
+resource "vault_rabbitmq_secret_backend" "test" {
+	path = "%s"
+	connection_uri = "http://rabbitmq:15672"
+	username = "admin"
+	password = "secret"
+	verify_connection = false
+}
+
+resource "vault_rabbitmq_secret_backend_role" "test" {
+	backend = "${vault_rabbitmq_secret_backend.test.path}"
+	name = "app"
+	tags = %s
+
+	vhost {
+		host = "/"
+		configure = ".*"
+		write = "%s"
+		read = ".*"
+	}
+
+	vhost {
+		host = "logs"
+		read = ".*"
+	}
+}
+`, backend, tags, write)
+}
@@ -0,0 +1,79 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+// The connection isn't verified, so the test doesn't need a RabbitMQ
+// server.
+func TestRabbitMQSecretBackend(t *testing.T) {
+	path := acctest.RandomWithPrefix("rabbitmq")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testRabbitMQSecretBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testRabbitMQSecretBackendConfig(path, "http://rabbitmq-1:15672", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend.test", "connection_uri", "http://rabbitmq-1:15672"),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend.test", "default_lease_ttl_seconds", "3600"),
+				),
+			},
+			{
+				Config: testRabbitMQSecretBackendConfig(path, "http://rabbitmq-2:15672", 1800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend.test", "id", path),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend.test", "connection_uri", "http://rabbitmq-2:15672"),
+					resource.TestCheckResourceAttr("vault_rabbitmq_secret_backend.test", "default_lease_ttl_seconds", "1800"),
+				),
+			},
+			{
+				ResourceName:            "vault_rabbitmq_secret_backend.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"connection_uri", "username", "password", "verify_connection"},
+			},
+		},
+	})
+}
+
+func testRabbitMQSecretBackendDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_rabbitmq_secret_backend" {
+			continue
+		}
+		if _, ok := mounts[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("RabbitMQ secret backend %q still mounted", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testRabbitMQSecretBackendConfig(path, connectionURI string, defaultTTL int) string {
+	return fmt.Sprintf(`
+resource "vault_rabbitmq_secret_backend" "test" {
+	path = "%s"
+	description = "test"
+	connection_uri = "%s"
+	username = "admin"
+	password = "secret"
+	verify_connection = false
+	default_lease_ttl_seconds = %d
+	max_lease_ttl_seconds = 86400
+}
+`, path, connectionURI, defaultTTL)
+}
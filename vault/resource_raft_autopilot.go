@@ -0,0 +1,162 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+const raftAutopilotConfigPath = "sys/storage/raft/autopilot/configuration"
+
+// raftAutopilotDurations are the settings of autopilot that are durations,
+// given in seconds.
+var raftAutopilotDurations = []string{
+	"last_contact_threshold",
+	"dead_server_last_contact_threshold",
+	"server_stabilization_time",
+}
+
+// raftAutopilotDefaults are the settings of autopilot in a new cluster,
+// which are restored when the resource is destroyed.
+var raftAutopilotDefaults = map[string]interface{}{
+	"cleanup_dead_servers":               false,
+	"last_contact_threshold":             "10s",
+	"dead_server_last_contact_threshold": "24h",
+	"max_trailing_logs":                  1000,
+	"min_quorum":                         0,
+	"server_stabilization_time":          "10s",
+}
+
+func raftAutopilotResource() *schema.Resource {
+	return &schema.Resource{
+		Create: raftAutopilotWrite,
+		Update: raftAutopilotWrite,
+		Delete: raftAutopilotDelete,
+		Read:   raftAutopilotRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cleanup_dead_servers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Remove dead servers from the cluster periodically, requires min_quorum.",
+			},
+
+			"last_contact_threshold": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds after the last contact with the leader a server is considered unhealthy.",
+			},
+
+			"dead_server_last_contact_threshold": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds after the last contact with the leader a server is considered dead and cleaned up.",
+			},
+
+			"max_trailing_logs": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of log entries a server can be behind the leader before being considered unhealthy.",
+			},
+
+			"min_quorum": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Minimum number of voters the cluster is kept at when cleaning up dead servers.",
+			},
+
+			"server_stabilization_time": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds a server must be healthy for before being promoted to voter.",
+			},
+		},
+	}
+}
+
+func raftAutopilotWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	// Vault only updates the settings that are given, so those that aren't
+	// configured keep the value of the cluster.
+	data := map[string]interface{}{}
+	for k := range raftAutopilotDefaults {
+		if d.IsNewResource() {
+			if _, ok := d.GetOk(k); !ok {
+				continue
+			}
+		} else if !d.HasChange(k) {
+			continue
+		}
+		data[k] = d.Get(k)
+	}
+	for _, k := range raftAutopilotDurations {
+		if v, ok := data[k]; ok {
+			data[k] = fmt.Sprintf("%ds", v.(int))
+		}
+	}
+
+	log.Printf("[DEBUG] Writing raft autopilot config to Vault")
+	if _, err := client.Logical().Write(raftAutopilotConfigPath, data); err != nil {
+		return raftAutopilotError("writing", err)
+	}
+
+	d.SetId(raftAutopilotConfigPath)
+
+	return raftAutopilotRead(d, meta)
+}
+
+func raftAutopilotRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Reading raft autopilot config from Vault")
+	secret, err := client.Logical().Read(raftAutopilotConfigPath)
+	if err != nil {
+		return raftAutopilotError("reading", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return fmt.Errorf("no raft autopilot config found; Vault 1.7 or later with raft integrated storage is required")
+	}
+
+	d.Set("cleanup_dead_servers", secret.Data["cleanup_dead_servers"])
+	d.Set("max_trailing_logs", intFromResponse(secret.Data["max_trailing_logs"]))
+	d.Set("min_quorum", intFromResponse(secret.Data["min_quorum"]))
+	for _, k := range raftAutopilotDurations {
+		d.Set(k, durationSecondsFromResponse(secret.Data[k]))
+	}
+
+	return nil
+}
+
+func raftAutopilotDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Restoring the default raft autopilot config in Vault")
+	if _, err := client.Logical().Write(raftAutopilotConfigPath, raftAutopilotDefaults); err != nil {
+		return raftAutopilotError("restoring", err)
+	}
+
+	return nil
+}
+
+// raftAutopilotError describes an error of a request to the autopilot
+// config. As for the state, Vault rejects the requests rather than
+// answering 404 when raft is not the storage backend.
+func raftAutopilotError(action string, err error) error {
+	if strings.Contains(err.Error(), "raft storage is not in use") {
+		return fmt.Errorf("error %s raft autopilot config: Vault is not using raft integrated storage: %s", action, err)
+	}
+	return fmt.Errorf("error %s raft autopilot config: %s", action, err)
+}
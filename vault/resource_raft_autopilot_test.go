@@ -0,0 +1,29 @@
+package vault
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// As for the autopilot state, the acceptance tests run against a dev server
+// without raft, so only the error reported then can be checked.
+func TestRaftAutopilot_noRaft(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "vault_raft_autopilot" "test" {
+	cleanup_dead_servers = true
+	min_quorum = 3
+	dead_server_last_contact_threshold = 3600
+}
+`,
+				ExpectError: regexp.MustCompile("not using raft integrated storage"),
+			},
+		},
+	})
+}
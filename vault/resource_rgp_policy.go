@@ -0,0 +1,39 @@
+package vault
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func rgpPolicyResource() *schema.Resource {
+	return &schema.Resource{
+		Create: rgpPolicyWrite,
+		Update: rgpPolicyWrite,
+		Delete: rgpPolicyDelete,
+		Read:   rgpPolicyRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: sentinelPolicySchema("role governing policy"),
+	}
+}
+
+func rgpPolicyWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := sentinelPolicyWrite(d, client, "rgp", "RGP policy", map[string]interface{}{}); err != nil {
+		return err
+	}
+
+	return rgpPolicyRead(d, meta)
+}
+
+func rgpPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	_, err := sentinelPolicyRead(d, meta.(*api.Client), "rgp", "RGP policy")
+	return err
+}
+
+func rgpPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	return sentinelPolicyDelete(d, meta.(*api.Client), "rgp", "RGP policy")
+}
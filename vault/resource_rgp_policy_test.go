@@ -0,0 +1,60 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestRGPPolicy(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	// Sentinel policies are only available in Vault Enterprise.
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+
+	name := acctest.RandomWithPrefix("test-rgp")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testSentinelPolicyCheckDestroy("rgp", name),
+		Steps: []resource.TestStep{
+			{
+				Config: testRGPPolicyConfig(name, "advisory"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_rgp_policy.test", "name", name),
+					resource.TestCheckResourceAttr("vault_rgp_policy.test", "enforcement_level", "advisory"),
+				),
+			},
+			{
+				Config: testRGPPolicyConfig(name, "hard-mandatory"),
+				Check:  resource.TestCheckResourceAttr("vault_rgp_policy.test", "enforcement_level", "hard-mandatory"),
+			},
+			{
+				ResourceName:      "vault_rgp_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testRGPPolicyConfig(name, level string) string {
+	return fmt.Sprintf(`
+resource "vault_rgp_policy" "test" {
+  name              = %q
+  enforcement_level = %q
+
+  policy = <<EOT
+main = rule {
+  true
+}
+EOT
+}
+`, name, level)
+}
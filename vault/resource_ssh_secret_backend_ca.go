@@ -0,0 +1,123 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func sshSecretBackendCAResource() *schema.Resource {
+	return &schema.Resource{
+		Create: sshSecretBackendCACreate,
+		Delete: sshSecretBackendCADelete,
+		Read:   sshSecretBackendCARead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ssh",
+				Description: "Path of the SSH secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"generate_signing_key": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether Vault generates the key pair of the CA, instead of using public_key and private_key.",
+			},
+
+			"public_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Public key of the CA, in authorized_keys format.",
+			},
+
+			"private_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Private key of the CA, when not generated by Vault.",
+			},
+		},
+	}
+}
+
+func sshSecretBackendCACreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/config/ca"
+
+	data := map[string]interface{}{
+		"generate_signing_key": d.Get("generate_signing_key").(bool),
+	}
+	if v, ok := d.GetOk("public_key"); ok {
+		data["public_key"] = v.(string)
+	}
+	if v, ok := d.GetOk("private_key"); ok {
+		data["private_key"] = v.(string)
+	}
+
+	log.Printf("[DEBUG] Writing SSH CA %q", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing SSH CA %q: %s", path, err)
+	}
+
+	d.SetId(backend)
+
+	return sshSecretBackendCARead(d, meta)
+}
+
+func sshSecretBackendCARead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := d.Id()
+	path := backend + "/config/ca"
+
+	log.Printf("[DEBUG] Reading SSH CA %q", path)
+	secret, err := client.Logical().Read(path)
+	// Vault answers with an error instead of an empty response when the CA
+	// was deleted.
+	if err != nil && !strings.Contains(err.Error(), "keys haven't been configured yet") {
+		return fmt.Errorf("error reading SSH CA %q: %s", path, err)
+	}
+	if err != nil || secret == nil || secret.Data["public_key"] == nil {
+		log.Printf("[WARN] SSH CA %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	// The private key is never returned, so it's kept from the state.
+	d.Set("backend", backend)
+	d.Set("public_key", strings.TrimSpace(secret.Data["public_key"].(string)))
+
+	return nil
+}
+
+func sshSecretBackendCADelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id() + "/config/ca"
+
+	log.Printf("[DEBUG] Deleting SSH CA %q", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting SSH CA %q: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestSSHSecretBackendCA_basic(t *testing.T) {
+	backend := acctest.RandomWithPrefix("ssh")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testSSHSecretBackendCADestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testSSHSecretBackendCAConfig(backend),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_ca.test", "backend", backend),
+					resource.TestMatchResourceAttr("vault_ssh_secret_backend_ca.test", "public_key", regexp.MustCompile("^ssh-rsa ")),
+				),
+			},
+			{
+				ResourceName:            "vault_ssh_secret_backend_ca.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"generate_signing_key"},
+			},
+		},
+	})
+}
+
+func testSSHSecretBackendCADestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_ssh_secret_backend_ca" {
+			continue
+		}
+		mounts, err := client.Sys().ListMounts()
+		if err != nil {
+			return err
+		}
+		if _, ok := mounts[rs.Primary.ID+"/"]; !ok {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID + "/config/ca")
+		if err == nil && secret != nil {
+			return fmt.Errorf("SSH CA %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testSSHSecretBackendCAConfig(backend string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "ssh" {
+	path = "%s"
+	type = "ssh"
+}
+
+resource "vault_ssh_secret_backend_ca" "test" {
+	backend = "${vault_mount.ssh.path}"
+}
+`, backend)
+}
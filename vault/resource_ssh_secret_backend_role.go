@@ -0,0 +1,284 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var sshSecretBackendRoleFromPathRegex = regexp.MustCompile("^(.+)/roles/([^/]+)$")
+
+// sshSecretBackendRoleBoolFields are the role fields that map one-to-one
+// onto boolean attributes of the resource.
+var sshSecretBackendRoleBoolFields = []string{
+	"allow_user_certificates",
+	"allow_host_certificates",
+	"allow_bare_domains",
+	"allow_subdomains",
+	"allow_user_key_ids",
+}
+
+// sshSecretBackendRoleListFields are the role fields that map onto lists of
+// strings. Vault takes and returns them as comma-separated strings.
+var sshSecretBackendRoleListFields = []string{
+	"allowed_users",
+	"allowed_domains",
+	"allowed_critical_options",
+	"allowed_extensions",
+	"cidr_list",
+}
+
+func sshSecretBackendRoleResource() *schema.Resource {
+	return &schema.Resource{
+		Create: sshSecretBackendRoleWrite,
+		Update: sshSecretBackendRoleWrite,
+		Delete: sshSecretBackendRoleDelete,
+		Read:   sshSecretBackendRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ssh",
+				Description: "Path of the SSH secret backend the role belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role.",
+			},
+
+			"key_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Type of the credentials issued: ca for signed certificates or otp for one-time passwords.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if value := v.(string); value != "ca" && value != "otp" {
+						errs = append(errs, fmt.Errorf("%s must be ca or otp, got %q", k, value))
+					}
+					return
+				},
+			},
+
+			"default_user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User the credentials are issued for when none is requested. Required for otp roles.",
+			},
+
+			"allowed_users": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Users the credentials may be issued for, * for any.",
+			},
+
+			"allowed_domains": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Domains of the host certificates that may be signed.",
+			},
+
+			"allow_bare_domains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether host certificates may be signed for the allowed domains themselves.",
+			},
+
+			"allow_subdomains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether host certificates may be signed for subdomains of the allowed domains.",
+			},
+
+			"allow_user_certificates": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether user certificates may be signed, for ca roles.",
+			},
+
+			"allow_host_certificates": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether host certificates may be signed, for ca roles.",
+			},
+
+			"allow_user_key_ids": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the key ID of the certificates may be requested.",
+			},
+
+			"key_id_format": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template of the key ID of the signed certificates.",
+			},
+
+			"allowed_critical_options": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Critical options that may be requested, any if empty.",
+			},
+
+			"allowed_extensions": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Extensions that may be requested, any if empty.",
+			},
+
+			"default_critical_options": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Critical options of the signed certificates when none are requested.",
+			},
+
+			"default_extensions": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Extensions of the signed certificates when none are requested, e.g. permit-pty.",
+			},
+
+			"cidr_list": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "CIDR blocks of the hosts one-time passwords may be issued for, for otp roles.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default TTL in seconds of the signed certificates.",
+			},
+
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum TTL in seconds of the signed certificates.",
+			},
+		},
+	}
+}
+
+func sshSecretBackendRolePath(backend, name string) string {
+	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(name, "/")
+}
+
+func sshSecretBackendRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := sshSecretBackendRolePath(d.Get("backend").(string), d.Get("name").(string))
+
+	data := map[string]interface{}{
+		"key_type":                 d.Get("key_type").(string),
+		"default_user":             d.Get("default_user").(string),
+		"key_id_format":            d.Get("key_id_format").(string),
+		"default_critical_options": d.Get("default_critical_options").(map[string]interface{}),
+		"default_extensions":       d.Get("default_extensions").(map[string]interface{}),
+	}
+	for _, k := range sshSecretBackendRoleBoolFields {
+		data[k] = d.Get(k).(bool)
+	}
+	for _, k := range sshSecretBackendRoleListFields {
+		data[k] = strings.Join(toStringArray(d.Get(k).([]interface{})), ",")
+	}
+	for _, k := range []string{"ttl", "max_ttl"} {
+		if v, ok := d.GetOk(k); ok {
+			data[k] = fmt.Sprintf("%ds", v.(int))
+		}
+	}
+
+	log.Printf("[DEBUG] Writing SSH role %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing SSH role %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return sshSecretBackendRoleRead(d, meta)
+}
+
+func sshSecretBackendRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := sshSecretBackendRoleFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid SSH role ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading SSH role %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading SSH role %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] SSH role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	for _, k := range []string{"key_type", "default_user", "key_id_format", "default_critical_options", "default_extensions"} {
+		if v, ok := secret.Data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+	for _, k := range sshSecretBackendRoleBoolFields {
+		if v, ok := secret.Data[k]; ok {
+			d.Set(k, v)
+		}
+	}
+	for _, k := range sshSecretBackendRoleListFields {
+		if v, ok := secret.Data[k]; ok {
+			if err := d.Set(k, flattenStringList(v)); err != nil {
+				return fmt.Errorf("error setting %s of SSH role %q: %s", k, path, err)
+			}
+		}
+	}
+	// Vault versions before 1.0 return the TTLs as duration strings.
+	d.Set("ttl", durationSecondsFromResponse(secret.Data["ttl"]))
+	d.Set("max_ttl", durationSecondsFromResponse(secret.Data["max_ttl"]))
+
+	return nil
+}
+
+func sshSecretBackendRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting SSH role %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting SSH role %q: %s", path, err)
+	}
+
+	return nil
+}
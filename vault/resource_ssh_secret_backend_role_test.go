@@ -0,0 +1,88 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestSSHSecretBackendRole_basic(t *testing.T) {
+	backend := acctest.RandomWithPrefix("ssh")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testSSHSecretBackendRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testSSHSecretBackendRoleConfig(backend, `["ubuntu"]`, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "backend", backend),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "name", "dev"),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "key_type", "ca"),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "allow_user_certificates", "true"),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "allowed_users.#", "1"),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "default_extensions.permit-pty", ""),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "ttl", "3600"),
+				),
+			},
+			{
+				Config: testSSHSecretBackendRoleConfig(backend, `["ubuntu", "admin"]`, 7200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "allowed_users.#", "2"),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "allowed_users.1", "admin"),
+					resource.TestCheckResourceAttr("vault_ssh_secret_backend_role.test", "ttl", "7200"),
+				),
+			},
+			{
+				ResourceName:      "vault_ssh_secret_backend_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testSSHSecretBackendRoleDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_ssh_secret_backend_role" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			return fmt.Errorf("SSH role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testSSHSecretBackendRoleConfig(backend, allowedUsers string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "ssh" {
+	path = "%s"
+	type = "ssh"
+}
+
+resource "vault_ssh_secret_backend_role" "test" {
+	backend = "${vault_mount.ssh.path}"
+	name = "dev"
+	key_type = "ca"
+	allow_user_certificates = true
+	allowed_users = %s
+	default_user = "ubuntu"
+	default_extensions {
+		permit-pty = ""
+	}
+	ttl = %d
+	max_ttl = 86400
+}
+`, backend, allowedUsers, ttl)
+}
@@ -0,0 +1,208 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+	"golang.org/x/crypto/ssh"
+)
+
+func sshSecretBackendSignResource() *schema.Resource {
+	return &schema.Resource{
+		Create: sshSecretBackendSignCreate,
+		Update: sshSecretBackendSignUpdate,
+		Delete: sshSecretBackendSignDelete,
+		Read:   sshSecretBackendSignRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ssh",
+				Description: "Path of the SSH secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role to sign the key with.",
+			},
+
+			"public_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "SSH public key to sign, in authorized_keys format.",
+			},
+
+			"cert_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "user",
+				Description: "Type of the certificate, user or host.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if value := v.(string); value != "user" && value != "host" {
+						errs = append(errs, fmt.Errorf("%s must be user or host, got %q", k, value))
+					}
+					return
+				},
+			},
+
+			"valid_principals": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Users or hosts the certificate is valid for, the default user of the role if empty.",
+			},
+
+			"key_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Key ID of the certificate, if allowed by the role.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "TTL in seconds of the certificate, the TTL of the role if 0.",
+			},
+
+			"critical_options": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Critical options of the certificate, the defaults of the role if empty.",
+			},
+
+			"extensions": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Extensions of the certificate, the defaults of the role if empty.",
+			},
+
+			"min_seconds_remaining": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Sign the key again when the certificate has less than this many seconds left.",
+			},
+
+			"signed_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The signed certificate, in authorized_keys format.",
+			},
+
+			"serial_number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The serial number of the certificate.",
+			},
+
+			"expiration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix time at which the certificate expires, 0 if it never does.",
+			},
+		},
+	}
+}
+
+func sshSecretBackendSignCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/sign/" + d.Get("name").(string)
+
+	data := map[string]interface{}{
+		"public_key":       d.Get("public_key").(string),
+		"cert_type":        d.Get("cert_type").(string),
+		"valid_principals": strings.Join(toStringArray(d.Get("valid_principals").([]interface{})), ","),
+		"key_id":           d.Get("key_id").(string),
+		"critical_options": d.Get("critical_options").(map[string]interface{}),
+		"extensions":       d.Get("extensions").(map[string]interface{}),
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		data["ttl"] = fmt.Sprintf("%ds", v.(int))
+	}
+
+	log.Printf("[DEBUG] Signing SSH key with %q", path)
+	secret, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error signing SSH key with %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("no signed key returned by %q", path)
+	}
+
+	signedKey, _ := secret.Data["signed_key"].(string)
+	signedKey = strings.TrimSpace(signedKey)
+	expiration, err := sshCertificateExpiration(signedKey)
+	if err != nil {
+		return fmt.Errorf("error parsing SSH certificate returned by %q: %s", path, err)
+	}
+
+	serial, _ := secret.Data["serial_number"].(string)
+
+	d.SetId(backend + "/" + serial)
+	d.Set("signed_key", signedKey)
+	d.Set("serial_number", serial)
+	d.Set("expiration", expiration)
+
+	return nil
+}
+
+// sshCertificateExpiration returns the Unix time at which the certificate
+// in authorized_keys format expires, or 0 if it's valid forever.
+func sshCertificateExpiration(signedKey string) (int64, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(signedKey))
+	if err != nil {
+		return 0, err
+	}
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return 0, fmt.Errorf("key of type %q is not a certificate", key.Type())
+	}
+	if cert.ValidBefore == ssh.CertTimeInfinity {
+		return 0, nil
+	}
+	return int64(cert.ValidBefore), nil
+}
+
+func sshSecretBackendSignRead(d *schema.ResourceData, meta interface{}) error {
+	// Signed certificates never change, but like issued PKI certificates
+	// they are removed from the state when about to expire, so the key is
+	// signed again.
+	expiration := int64(d.Get("expiration").(int))
+	if expiration != 0 && pkiSecretBackendCertNeedsRenewal(expiration, d.Get("min_seconds_remaining").(int), time.Now()) {
+		log.Printf("[WARN] SSH certificate %q expires at %s, removing from state to sign it again", d.Id(), time.Unix(expiration, 0))
+		d.SetId("")
+	}
+	return nil
+}
+
+// sshSecretBackendSignUpdate only stores the settings that don't affect the
+// signed certificate.
+func sshSecretBackendSignUpdate(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// sshSecretBackendSignDelete only removes the certificate from the state, as
+// SSH certificates can't be revoked.
+func sshSecretBackendSignDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
@@ -0,0 +1,122 @@
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHCertificateExpiration(t *testing.T) {
+	cases := []struct {
+		validBefore uint64
+		expected    int64
+	}{
+		{1700000000, 1700000000},
+		{ssh.CertTimeInfinity, 0},
+	}
+	for _, c := range cases {
+		signedKey := testSSHCertificate(t, c.validBefore)
+		got, err := sshCertificateExpiration(signedKey)
+		if err != nil {
+			t.Fatalf("sshCertificateExpiration(%q) failed: %s", signedKey, err)
+		}
+		if got != c.expected {
+			t.Errorf("sshCertificateExpiration() = %d, expected %d", got, c.expected)
+		}
+	}
+
+	if _, err := sshCertificateExpiration(testSSHPublicKey(t)); err == nil {
+		t.Errorf("expected an error for a public key that is not a certificate")
+	}
+}
+
+func TestSSHSecretBackendSign_basic(t *testing.T) {
+	backend := acctest.RandomWithPrefix("ssh")
+	publicKey := testSSHPublicKey(t)
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testSSHSecretBackendSignConfig(backend, publicKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("vault_ssh_secret_backend_sign.test", "signed_key", regexp.MustCompile("^ssh-rsa-cert-v01@openssh.com ")),
+					resource.TestCheckResourceAttrSet("vault_ssh_secret_backend_sign.test", "serial_number"),
+					resource.TestMatchResourceAttr("vault_ssh_secret_backend_sign.test", "expiration", regexp.MustCompile("^[1-9][0-9]+$")),
+				),
+			},
+		},
+	})
+}
+
+func testSSHPublicKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(ssh.MarshalAuthorizedKey(publicKey))
+}
+
+func testSSHCertificate(t *testing.T, validBefore uint64) string {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(testSSHPublicKey(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := &ssh.Certificate{
+		Key:         publicKey,
+		CertType:    ssh.UserCert,
+		ValidBefore: validBefore,
+	}
+	if err := cert.SignCert(rand.Reader, signer); err != nil {
+		t.Fatal(err)
+	}
+	return string(ssh.MarshalAuthorizedKey(cert))
+}
+
+func testSSHSecretBackendSignConfig(backend, publicKey string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "ssh" {
+	path = "%s"
+	type = "ssh"
+}
+
+resource "vault_ssh_secret_backend_ca" "ca" {
+	backend = "${vault_mount.ssh.path}"
+}
+
+resource "vault_ssh_secret_backend_role" "dev" {
+	backend = "${vault_ssh_secret_backend_ca.ca.backend}"
+	name = "dev"
+	key_type = "ca"
+	allow_user_certificates = true
+	allowed_users = ["ubuntu"]
+	default_user = "ubuntu"
+	ttl = 3600
+}
+
+resource "vault_ssh_secret_backend_sign" "test" {
+	backend = "${vault_ssh_secret_backend_role.dev.backend}"
+	name = "${vault_ssh_secret_backend_role.dev.name}"
+	public_key = %q
+	valid_principals = ["ubuntu"]
+}
+`, backend, publicKey)
+}
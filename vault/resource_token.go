@@ -0,0 +1,289 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func tokenResource() *schema.Resource {
+	return &schema.Resource{
+		Create: tokenCreate,
+		Update: tokenUpdate,
+		Delete: tokenDelete,
+		Read:   tokenRead,
+
+		Schema: map[string]*schema.Schema{
+			"role_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the token role to create the token with.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies of the token, a subset of the policies of the provider token unless it is a root token.",
+			},
+
+			"no_parent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Create an orphan token, which isn't revoked along with the provider token.",
+			},
+
+			"no_default_policy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Don't attach the default policy to the token.",
+			},
+
+			"renewable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether the token can be renewed.",
+			},
+
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "TTL of the token in seconds.",
+			},
+
+			"explicit_max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Maximum TTL in seconds the token can't be renewed past.",
+			},
+
+			"period": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Period in seconds of the token, making it periodic if set.",
+			},
+
+			"num_uses": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Number of times the token can be used, unlimited if 0.",
+			},
+
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "token",
+				Description: "Display name of the token.",
+			},
+
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Metadata of the token, written to the audit logs.",
+			},
+
+			"renew_min_lease": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Renew the token on refresh when it has less than this many seconds left, never if 0.",
+			},
+
+			"renew_increment": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "TTL in seconds requested when renewing the token, its original TTL if 0.",
+			},
+
+			"client_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token.",
+			},
+
+			"accessor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The accessor of the token.",
+			},
+
+			"lease_duration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Seconds the token had left when last refreshed.",
+			},
+
+			"lease_started": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the token was last created or renewed, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func tokenCreatePath(roleName string, noParent bool) string {
+	switch {
+	case roleName != "":
+		return "auth/token/create/" + roleName
+	case noParent:
+		return "auth/token/create-orphan"
+	default:
+		return "auth/token/create"
+	}
+}
+
+func tokenCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := tokenCreatePath(d.Get("role_name").(string), d.Get("no_parent").(bool))
+
+	data := map[string]interface{}{
+		"policies":          d.Get("policies").(*schema.Set).List(),
+		"no_parent":         d.Get("no_parent").(bool),
+		"no_default_policy": d.Get("no_default_policy").(bool),
+		"renewable":         d.Get("renewable").(bool),
+		"num_uses":          d.Get("num_uses").(int),
+		"display_name":      d.Get("display_name").(string),
+		"meta":              d.Get("metadata").(map[string]interface{}),
+	}
+	for _, k := range []string{"ttl", "explicit_max_ttl", "period"} {
+		if v, ok := d.GetOk(k); ok {
+			data[k] = fmt.Sprintf("%ds", v.(int))
+		}
+	}
+
+	if !d.Get("no_parent").(bool) {
+		warnTokenParentExpires(client)
+	}
+
+	log.Printf("[DEBUG] Creating token with %q", path)
+	secret, err := client.Logical().Write(path, data)
+	if err != nil {
+		return fmt.Errorf("error creating token with %q: %s", path, err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return fmt.Errorf("no token returned by %q", path)
+	}
+
+	d.SetId(secret.Auth.Accessor)
+	d.Set("client_token", secret.Auth.ClientToken)
+	d.Set("accessor", secret.Auth.Accessor)
+	d.Set("lease_duration", secret.Auth.LeaseDuration)
+	d.Set("lease_started", time.Now().UTC().Format(time.RFC3339))
+
+	return tokenRead(d, meta)
+}
+
+// warnTokenParentExpires warns when the token of the provider, which is the
+// parent of the created token, expires without being renewable, as it is the
+// case of the child token of the provider. The created token is revoked
+// along with it.
+func warnTokenParentExpires(client *api.Client) {
+	parent, err := client.Auth().Token().LookupSelf()
+	if err != nil || parent == nil {
+		log.Printf("[DEBUG] Couldn't look up the token of the provider: %v", err)
+		return
+	}
+
+	ttl := intFromResponse(parent.Data["ttl"])
+	renewable, _ := parent.Data["renewable"].(bool)
+	if ttl > 0 && !renewable {
+		log.Printf("[WARN] The token of the provider expires in %ds and can't be renewed, the created token will be revoked along with it; set no_parent or skip_child_token in the provider to keep it", ttl)
+	}
+}
+
+// tokenUpdate only stores the renewal settings, the token itself can't be
+// changed.
+func tokenUpdate(d *schema.ResourceData, meta interface{}) error {
+	return tokenRead(d, meta)
+}
+
+// tokenNeedsRenewal reports whether a token with ttl seconds left has to be
+// renewed to keep at least renewMinLease seconds.
+func tokenNeedsRenewal(renewable bool, ttl, renewMinLease int) bool {
+	return renewable && renewMinLease > 0 && ttl < renewMinLease
+}
+
+// tokenAccessorNotFound reports whether err is the error that Vault returns
+// when looking up or revoking the accessor of a token that doesn't exist,
+// e.g. because it expired or was revoked.
+func tokenAccessorNotFound(err error) bool {
+	return strings.Contains(err.Error(), "invalid accessor")
+}
+
+func tokenRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	accessor := d.Id()
+
+	log.Printf("[DEBUG] Looking up token %q", accessor)
+	secret, err := client.Logical().Write("auth/token/lookup-accessor", map[string]interface{}{
+		"accessor": accessor,
+	})
+	if err != nil && !tokenAccessorNotFound(err) {
+		return fmt.Errorf("error looking up token %q: %s", accessor, err)
+	}
+	if err != nil || secret == nil {
+		log.Printf("[WARN] Token %q not found, removing from state", accessor)
+		d.SetId("")
+		return nil
+	}
+
+	ttl := intFromResponse(secret.Data["ttl"])
+	renewable, _ := secret.Data["renewable"].(bool)
+	if tokenNeedsRenewal(renewable, ttl, d.Get("renew_min_lease").(int)) {
+		log.Printf("[DEBUG] Renewing token %q with %ds left", accessor, ttl)
+		renewed, err := client.Logical().Write("auth/token/renew", map[string]interface{}{
+			"token":     d.Get("client_token").(string),
+			"increment": d.Get("renew_increment").(int),
+		})
+		if err != nil {
+			return fmt.Errorf("error renewing token %q: %s", accessor, err)
+		}
+		if renewed != nil && renewed.Auth != nil {
+			ttl = renewed.Auth.LeaseDuration
+			d.Set("lease_started", time.Now().UTC().Format(time.RFC3339))
+		}
+	}
+
+	d.Set("accessor", accessor)
+	d.Set("lease_duration", ttl)
+
+	return nil
+}
+
+func tokenDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	accessor := d.Id()
+
+	log.Printf("[DEBUG] Revoking token %q", accessor)
+	_, err := client.Logical().Write("auth/token/revoke-accessor", map[string]interface{}{
+		"accessor": accessor,
+	})
+	if err != nil && !tokenAccessorNotFound(err) {
+		return fmt.Errorf("error revoking token %q: %s", accessor, err)
+	}
+
+	return nil
+}
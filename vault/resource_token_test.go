@@ -0,0 +1,131 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestTokenCreatePath(t *testing.T) {
+	tests := []struct {
+		roleName string
+		noParent bool
+		want     string
+	}{
+		{"", false, "auth/token/create"},
+		{"", true, "auth/token/create-orphan"},
+		{"ci", false, "auth/token/create/ci"},
+		{"ci", true, "auth/token/create/ci"},
+	}
+	for _, test := range tests {
+		if got := tokenCreatePath(test.roleName, test.noParent); got != test.want {
+			t.Errorf("path for role %q and no_parent %t is %q; want %q", test.roleName, test.noParent, got, test.want)
+		}
+	}
+}
+
+func TestTokenNeedsRenewal(t *testing.T) {
+	tests := []struct {
+		renewable     bool
+		ttl           int
+		renewMinLease int
+		want          bool
+	}{
+		{true, 3600, 0, false},
+		{true, 3600, 600, false},
+		{true, 599, 600, true},
+		{false, 599, 600, false},
+	}
+	for _, test := range tests {
+		if got := tokenNeedsRenewal(test.renewable, test.ttl, test.renewMinLease); got != test.want {
+			t.Errorf("renewal of token with %ds left and %ds required is %t; want %t", test.ttl, test.renewMinLease, got, test.want)
+		}
+	}
+}
+
+func TestToken(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testTokenConfig(0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vault_token.test", "client_token"),
+					resource.TestCheckResourceAttrSet("vault_token.test", "accessor"),
+					resource.TestCheckResourceAttr("vault_token.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_token.test", "lease_duration", "3600"),
+				),
+			},
+			{
+				// Requiring more time than the token has left renews it,
+				// without creating a new one.
+				Config: testTokenConfig(7200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_token.test", "lease_duration", "7200"),
+					testTokenPolicies("vault_token.test", []string{"default", "dev", "ops"}),
+				),
+			},
+		},
+	})
+}
+
+func testTokenPolicies(name string, want []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", name)
+		}
+
+		client := testProvider.Meta().(*api.Client)
+		secret, err := client.Logical().Write("auth/token/lookup-accessor", map[string]interface{}{
+			"accessor": rs.Primary.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("error looking up token %q: %s", rs.Primary.ID, err)
+		}
+		got := flattenStringList(secret.Data["policies"])
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			return fmt.Errorf("token %q has policies %v; want %v", rs.Primary.ID, got, want)
+		}
+		return nil
+	}
+}
+
+func testTokenDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_token" {
+			continue
+		}
+		_, err := client.Logical().Write("auth/token/lookup-accessor", map[string]interface{}{
+			"accessor": rs.Primary.ID,
+		})
+		if err == nil {
+			return fmt.Errorf("token %q still exists", rs.Primary.ID)
+		}
+		if !tokenAccessorNotFound(err) {
+			return fmt.Errorf("error checking for token %q: %s", rs.Primary.ID, err)
+		}
+	}
+	return nil
+}
+
+func testTokenConfig(renewMinLease int) string {
+	return fmt.Sprintf(`
+resource "vault_token" "test" {
+	policies = ["dev", "ops"]
+	ttl = 3600
+	renew_min_lease = %d
+	renew_increment = 7200
+	metadata {
+		purpose = "test"
+	}
+}
+`, renewMinLease)
+}
@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func transformAlphabetResource() *schema.Resource {
+	s := transformSchema("alphabet")
+	s["alphabet"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Characters of the alphabet, e.g. 0123456789.",
+	}
+
+	return &schema.Resource{
+		Create: transformAlphabetWrite,
+		Update: transformAlphabetWrite,
+		Delete: transformAlphabetDelete,
+		Read:   transformAlphabetRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func transformAlphabetWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := transformWrite(d, client, "alphabet", map[string]interface{}{
+		"alphabet": d.Get("alphabet").(string),
+	}); err != nil {
+		return err
+	}
+
+	return transformAlphabetRead(d, meta)
+}
+
+func transformAlphabetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := transformRead(d, client, "alphabet")
+	if err != nil || data == nil {
+		return err
+	}
+
+	d.Set("alphabet", data["alphabet"])
+
+	return nil
+}
+
+func transformAlphabetDelete(d *schema.ResourceData, meta interface{}) error {
+	return transformDelete(d, meta.(*api.Client), "alphabet")
+}
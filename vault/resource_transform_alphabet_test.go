@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+// testAccTransformPreCheck skips the test unless it runs against Vault
+// Enterprise, the only one with the transform secret backend.
+func testAccTransformPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	if os.Getenv("VAULT_ENTERPRISE") == "" {
+		t.Skip("VAULT_ENTERPRISE not set")
+	}
+}
+
+func testTransformCheckDestroy(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			secret, err := client.Logical().Read(rs.Primary.ID)
+			if err != nil {
+				// The backend is usually unmounted along with its objects.
+				continue
+			}
+			if secret != nil {
+				return fmt.Errorf("%s %q still exists", resourceType, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func TestTransformAlphabet(t *testing.T) {
+	testAccTransformPreCheck(t)
+
+	backend := acctest.RandomWithPrefix("transform")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testTransformCheckDestroy("vault_transform_alphabet"),
+		Steps: []resource.TestStep{
+			{
+				Config: testTransformAlphabetConfig(backend, "0123456789"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transform_alphabet.test", "id", backend+"/alphabet/numerics"),
+					resource.TestCheckResourceAttr("vault_transform_alphabet.test", "alphabet", "0123456789"),
+				),
+			},
+			{
+				Config: testTransformAlphabetConfig(backend, "0123456789abcdef"),
+				Check:  resource.TestCheckResourceAttr("vault_transform_alphabet.test", "alphabet", "0123456789abcdef"),
+			},
+			{
+				ResourceName:      "vault_transform_alphabet.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testTransformMountConfig(backend string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "transform" {
+	path = "%s"
+	type = "transform"
+}
+`, backend)
+}
+
+func testTransformAlphabetConfig(backend, alphabet string) string {
+	return testTransformMountConfig(backend) + fmt.Sprintf(`
+resource "vault_transform_alphabet" "test" {
+	backend = "${vault_mount.transform.path}"
+	name = "numerics"
+	alphabet = "%s"
+}
+`, alphabet)
+}
@@ -0,0 +1,62 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func transformRoleResource() *schema.Resource {
+	s := transformSchema("role")
+	s["transformations"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Required:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+		Description: "Names of the transformations the role can use.",
+	}
+
+	return &schema.Resource{
+		Create: transformRoleWrite,
+		Update: transformRoleWrite,
+		Delete: transformRoleDelete,
+		Read:   transformRoleRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func transformRoleWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := transformWrite(d, client, "role", map[string]interface{}{
+		"transformations": d.Get("transformations").(*schema.Set).List(),
+	}); err != nil {
+		return err
+	}
+
+	return transformRoleRead(d, meta)
+}
+
+func transformRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := transformRead(d, client, "role")
+	if err != nil || data == nil {
+		return err
+	}
+
+	if err := d.Set("transformations", flattenStringList(data["transformations"])); err != nil {
+		return fmt.Errorf("error setting transformations of transform role %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func transformRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	return transformDelete(d, meta.(*api.Client), "role")
+}
@@ -0,0 +1,48 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestTransformRole(t *testing.T) {
+	testAccTransformPreCheck(t)
+
+	backend := acctest.RandomWithPrefix("transform")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testTransformCheckDestroy("vault_transform_role"),
+		Steps: []resource.TestStep{
+			{
+				Config: testTransformRoleConfig(backend, `"${vault_transform_transformation.test.name}"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transform_role.test", "id", backend+"/role/payments"),
+					resource.TestCheckResourceAttr("vault_transform_role.test", "transformations.#", "1"),
+				),
+			},
+			{
+				Config: testTransformRoleConfig(backend, `"${vault_transform_transformation.test.name}", "ccn-fpe"`),
+				Check:  resource.TestCheckResourceAttr("vault_transform_role.test", "transformations.#", "2"),
+			},
+			{
+				ResourceName:      "vault_transform_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testTransformRoleConfig(backend, transformations string) string {
+	return testTransformTransformationConfig(backend, "*", "payments") + fmt.Sprintf(`
+resource "vault_transform_role" "test" {
+	backend = "${vault_mount.transform.path}"
+	name = "payments"
+	transformations = [%s]
+}
+`, transformations)
+}
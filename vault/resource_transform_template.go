@@ -0,0 +1,80 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func transformTemplateResource() *schema.Resource {
+	s := transformSchema("template")
+	s["type"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     "regex",
+		Description: "Type of the template, only regex is supported.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			if v.(string) != "regex" {
+				errs = append(errs, fmt.Errorf("%s must be regex, got %q", k, v))
+			}
+			return
+		},
+	}
+	s["pattern"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Regular expression matching the values transformed, whose capture groups are the parts transformed.",
+	}
+	s["alphabet"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the alphabet of the transformed parts, such as builtin/numeric or a vault_transform_alphabet.",
+	}
+
+	return &schema.Resource{
+		Create: transformTemplateWrite,
+		Update: transformTemplateWrite,
+		Delete: transformTemplateDelete,
+		Read:   transformTemplateRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func transformTemplateWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	if err := transformWrite(d, client, "template", map[string]interface{}{
+		"type":     d.Get("type").(string),
+		"pattern":  d.Get("pattern").(string),
+		"alphabet": d.Get("alphabet").(string),
+	}); err != nil {
+		return err
+	}
+
+	return transformTemplateRead(d, meta)
+}
+
+func transformTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := transformRead(d, client, "template")
+	if err != nil || data == nil {
+		return err
+	}
+
+	for _, k := range []string{"type", "pattern", "alphabet"} {
+		d.Set(k, data[k])
+	}
+
+	return nil
+}
+
+func transformTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	return transformDelete(d, meta.(*api.Client), "template")
+}
@@ -0,0 +1,50 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestTransformTemplate(t *testing.T) {
+	testAccTransformPreCheck(t)
+
+	backend := acctest.RandomWithPrefix("transform")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testTransformCheckDestroy("vault_transform_template"),
+		Steps: []resource.TestStep{
+			{
+				Config: testTransformTemplateConfig(backend, `(\\d{4})-(\\d{4})`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transform_template.test", "type", "regex"),
+					resource.TestCheckResourceAttr("vault_transform_template.test", "pattern", `(\d{4})-(\d{4})`),
+					resource.TestCheckResourceAttr("vault_transform_template.test", "alphabet", "numerics"),
+				),
+			},
+			{
+				Config: testTransformTemplateConfig(backend, `(\\d{4})-(\\d{4})-(\\d{4})`),
+				Check:  resource.TestCheckResourceAttr("vault_transform_template.test", "pattern", `(\d{4})-(\d{4})-(\d{4})`),
+			},
+			{
+				ResourceName:      "vault_transform_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testTransformTemplateConfig(backend, pattern string) string {
+	return testTransformAlphabetConfig(backend, "0123456789") + fmt.Sprintf(`
+resource "vault_transform_template" "test" {
+	backend = "${vault_mount.transform.path}"
+	name = "account"
+	pattern = "%s"
+	alphabet = "${vault_transform_alphabet.test.name}"
+}
+`, pattern)
+}
@@ -0,0 +1,143 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func transformTransformationResource() *schema.Resource {
+	s := transformSchema("transformation")
+	s["type"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Type of the transformation: fpe, masking or tokenization.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			switch v.(string) {
+			case "fpe", "masking", "tokenization":
+			default:
+				errs = append(errs, fmt.Errorf("%s must be one of fpe, masking or tokenization, got %q", k, v))
+			}
+			return
+		},
+	}
+	s["template"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Name of the template of fpe and masking transformations, such as builtin/creditcardnumber.",
+	}
+	s["tweak_source"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "Source of the tweak of fpe transformations: supplied, generated or internal.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			switch v.(string) {
+			case "supplied", "generated", "internal":
+			default:
+				errs = append(errs, fmt.Errorf("%s must be one of supplied, generated or internal, got %q", k, v))
+			}
+			return
+		},
+	}
+	s["masking_character"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Character replacing the masked parts of masking transformations, * if not set.",
+		ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+			if len([]rune(v.(string))) != 1 {
+				errs = append(errs, fmt.Errorf("%s must be a single character, got %q", k, v))
+			}
+			return
+		},
+	}
+	s["allowed_roles"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+		Description: "Names of the roles allowed to use the transformation, which can contain * wildcards.",
+	}
+
+	return &schema.Resource{
+		Create: transformTransformationWrite,
+		Update: transformTransformationWrite,
+		Delete: transformTransformationDelete,
+		Read:   transformTransformationRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func transformTransformationWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data := map[string]interface{}{
+		"type":          d.Get("type").(string),
+		"allowed_roles": d.Get("allowed_roles").(*schema.Set).List(),
+	}
+	for _, k := range []string{"template", "tweak_source", "masking_character"} {
+		if v, ok := d.GetOk(k); ok {
+			data[k] = v
+		}
+	}
+
+	if err := transformWrite(d, client, "transformation", data); err != nil {
+		return err
+	}
+
+	return transformTransformationRead(d, meta)
+}
+
+// transformMaskingCharacter returns the masking character of a
+// transformation from a response. Some Vault versions return the code point
+// of the character instead of the character.
+func transformMaskingCharacter(raw interface{}) string {
+	switch v := raw.(type) {
+	case string:
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil && n > 0 {
+			return string(rune(n))
+		}
+	}
+	return ""
+}
+
+func transformTransformationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	data, err := transformRead(d, client, "transformation")
+	if err != nil || data == nil {
+		return err
+	}
+
+	d.Set("type", data["type"])
+	// Transformations are read with a list of templates, but can only be
+	// written with one.
+	if templates := flattenStringList(data["templates"]); len(templates) > 0 {
+		d.Set("template", templates[0])
+	} else {
+		d.Set("template", data["template"])
+	}
+	d.Set("tweak_source", data["tweak_source"])
+	d.Set("masking_character", transformMaskingCharacter(data["masking_character"]))
+
+	if err := d.Set("allowed_roles", flattenStringList(data["allowed_roles"])); err != nil {
+		return fmt.Errorf("error setting allowed_roles of transform transformation %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func transformTransformationDelete(d *schema.ResourceData, meta interface{}) error {
+	return transformDelete(d, meta.(*api.Client), "transformation")
+}
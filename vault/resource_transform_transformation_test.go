@@ -0,0 +1,70 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestTransformMaskingCharacter(t *testing.T) {
+	for _, c := range []struct {
+		raw      interface{}
+		expected string
+	}{
+		{"#", "#"},
+		{json.Number("42"), "*"},
+		{json.Number("0"), ""},
+		{nil, ""},
+	} {
+		if got := transformMaskingCharacter(c.raw); got != c.expected {
+			t.Errorf("%#v: expected %q, got %q", c.raw, c.expected, got)
+		}
+	}
+}
+
+func TestTransformTransformation(t *testing.T) {
+	testAccTransformPreCheck(t)
+
+	backend := acctest.RandomWithPrefix("transform")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testTransformCheckDestroy("vault_transform_transformation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testTransformTransformationConfig(backend, "#", "payments"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transform_transformation.test", "type", "masking"),
+					resource.TestCheckResourceAttr("vault_transform_transformation.test", "template", "builtin/creditcardnumber"),
+					resource.TestCheckResourceAttr("vault_transform_transformation.test", "masking_character", "#"),
+					resource.TestCheckResourceAttr("vault_transform_transformation.test", "allowed_roles.#", "1"),
+				),
+			},
+			{
+				Config: testTransformTransformationConfig(backend, "*", "payments-*"),
+				Check:  resource.TestCheckResourceAttr("vault_transform_transformation.test", "masking_character", "*"),
+			},
+			{
+				ResourceName:      "vault_transform_transformation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testTransformTransformationConfig(backend, maskingCharacter, allowedRole string) string {
+	return testTransformMountConfig(backend) + fmt.Sprintf(`
+resource "vault_transform_transformation" "test" {
+	backend = "${vault_mount.transform.path}"
+	name = "ccn-masking"
+	type = "masking"
+	template = "builtin/creditcardnumber"
+	masking_character = "%s"
+	allowed_roles = ["%s"]
+}
+`, maskingCharacter, allowedRole)
+}
@@ -0,0 +1,378 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var transitSecretBackendKeyIDRegex = regexp.MustCompile("^(.+)/keys/([^/]+)$")
+
+// transitManagedKeyTypes are the types of managed keys that Vault lists
+// under sys/managed-keys.
+var transitManagedKeyTypes = []string{"pkcs11", "awskms", "azurekeyvault", "gcpckms"}
+
+func transitSecretBackendKeyResource() *schema.Resource {
+	return &schema.Resource{
+		Create: transitSecretBackendKeyCreate,
+		Update: transitSecretBackendKeyUpdate,
+		Delete: transitSecretBackendKeyDelete,
+		Read:   transitSecretBackendKeyRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the transit secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the key.",
+			},
+
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "aes256-gcm96",
+				ForceNew:    true,
+				Description: "Type of the key, such as aes256-gcm96, chacha20-poly1305, ed25519, rsa-4096 or managed_key.",
+			},
+
+			"managed_key_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"managed_key_id"},
+				Description:   "Name of the managed key backing the key, with type managed_key.",
+			},
+
+			"managed_key_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"managed_key_name"},
+				Description:   "UUID of the managed key backing the key, with type managed_key.",
+			},
+
+			"derived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether keys are derived from a context given on each request.",
+			},
+
+			"convergent_encryption": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the same plaintext and context always produce the same ciphertext. Requires derived.",
+			},
+
+			"deletion_allowed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the key may be deleted.",
+			},
+
+			"exportable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the key may be exported. Can't be disabled once enabled.",
+			},
+
+			"allow_plaintext_backup": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether plaintext backups of the key may be taken. Can't be disabled once enabled.",
+			},
+
+			"auto_rotate_period": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds between automatic rotations of the key, 0 to disable them.",
+			},
+
+			"min_decryption_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Minimum version of the key allowed to decrypt.",
+			},
+
+			"min_encryption_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Minimum version of the key allowed to encrypt, 0 for the latest version.",
+			},
+
+			"latest_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Latest version of the key.",
+			},
+
+			"min_available_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Oldest version of the key that hasn't been trimmed.",
+			},
+
+			"keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Versions of the key, ordered by version.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func transitSecretBackendKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/keys/" + d.Get("name").(string)
+
+	data := map[string]interface{}{
+		"type":                   d.Get("type").(string),
+		"derived":                d.Get("derived").(bool),
+		"convergent_encryption":  d.Get("convergent_encryption").(bool),
+		"exportable":             d.Get("exportable").(bool),
+		"allow_plaintext_backup": d.Get("allow_plaintext_backup").(bool),
+	}
+	if v, ok := d.GetOk("auto_rotate_period"); ok {
+		data["auto_rotate_period"] = v.(int)
+	}
+
+	managedKeyName := d.Get("managed_key_name").(string)
+	managedKeyID := d.Get("managed_key_id").(string)
+	if data["type"] == "managed_key" {
+		if managedKeyName == "" && managedKeyID == "" {
+			return fmt.Errorf("transit key %q of type managed_key needs managed_key_name or managed_key_id", path)
+		}
+		if err := transitCheckManagedKeyExists(client, managedKeyName, managedKeyID); err != nil {
+			return fmt.Errorf("transit key %q: %s", path, err)
+		}
+		if managedKeyName != "" {
+			data["managed_key_name"] = managedKeyName
+		} else {
+			data["managed_key_id"] = managedKeyID
+		}
+	} else if managedKeyName != "" || managedKeyID != "" {
+		return fmt.Errorf("transit key %q sets a managed key, which is only supported with type managed_key", path)
+	}
+
+	log.Printf("[DEBUG] Creating transit key %q in Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error creating transit key %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return transitSecretBackendKeyUpdate(d, meta)
+}
+
+// transitCheckManagedKeyExists fails unless a managed key with the given
+// name, or with the given UUID when name is empty, is configured in Vault,
+// whatever its type. Managed keys are looked up by type, so each type is
+// listed in turn, and listings only have names, so looking up a UUID reads
+// each key.
+func transitCheckManagedKeyExists(client *api.Client, name, id string) error {
+	for _, keyType := range transitManagedKeyTypes {
+		secret, err := client.Logical().List("sys/managed-keys/" + keyType)
+		if err != nil {
+			return fmt.Errorf("error listing managed keys of type %s: %s", keyType, err)
+		}
+		if secret == nil {
+			continue
+		}
+		for _, k := range flattenStringList(secret.Data["keys"]) {
+			if name != "" {
+				if k == name {
+					return nil
+				}
+				continue
+			}
+
+			key, err := client.Logical().Read("sys/managed-keys/" + keyType + "/" + k)
+			if err != nil {
+				return fmt.Errorf("error reading managed key %q of type %s: %s", k, keyType, err)
+			}
+			if key != nil && key.Data["uuid"] == id {
+				return nil
+			}
+		}
+	}
+	if name != "" {
+		return fmt.Errorf("managed key %q not found; it must be configured under sys/managed-keys first", name)
+	}
+	return fmt.Errorf("managed key with ID %q not found; it must be configured under sys/managed-keys first", id)
+}
+
+func transitSecretBackendKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	data := map[string]interface{}{
+		"deletion_allowed":       d.Get("deletion_allowed").(bool),
+		"exportable":             d.Get("exportable").(bool),
+		"allow_plaintext_backup": d.Get("allow_plaintext_backup").(bool),
+	}
+	// Changes back to 0 have to be sent too, e.g. to encrypt with the
+	// latest version again or to disable auto rotation.
+	for _, k := range []string{"auto_rotate_period", "min_decryption_version", "min_encryption_version"} {
+		if v, ok := d.GetOk(k); ok || d.HasChange(k) {
+			data[k] = v.(int)
+		}
+	}
+
+	log.Printf("[DEBUG] Configuring transit key %q in Vault", path)
+	if _, err := client.Logical().Write(path+"/config", data); err != nil {
+		return fmt.Errorf("error configuring transit key %q: %s", path, err)
+	}
+
+	return transitSecretBackendKeyRead(d, meta)
+}
+
+func transitSecretBackendKeyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := transitSecretBackendKeyIDRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid transit key ID %q, expected <backend>/keys/<name>", path)
+	}
+
+	log.Printf("[DEBUG] Reading transit key %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading transit key %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Transit key %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[2])
+	d.Set("type", secret.Data["type"])
+	d.Set("derived", secret.Data["derived"])
+	d.Set("convergent_encryption", secret.Data["convergent_encryption"])
+	d.Set("deletion_allowed", secret.Data["deletion_allowed"])
+	d.Set("exportable", secret.Data["exportable"])
+	d.Set("allow_plaintext_backup", secret.Data["allow_plaintext_backup"])
+	// The managed key backing the key isn't returned, so managed_key_name
+	// and managed_key_id keep their configured values.
+	d.Set("auto_rotate_period", intFromResponse(secret.Data["auto_rotate_period"]))
+	d.Set("min_decryption_version", intFromResponse(secret.Data["min_decryption_version"]))
+	d.Set("min_encryption_version", intFromResponse(secret.Data["min_encryption_version"]))
+
+	// Versions are refreshed on every read, since the key may have been
+	// rotated by Vault, through auto_rotate_period, or by other clients.
+	d.Set("latest_version", intFromResponse(secret.Data["latest_version"]))
+	d.Set("min_available_version", intFromResponse(secret.Data["min_available_version"]))
+
+	keys, err := flattenTransitKeyVersions(secret.Data["keys"])
+	if err != nil {
+		return fmt.Errorf("error reading versions of transit key %q: %s", path, err)
+	}
+	if err := d.Set("keys", keys); err != nil {
+		return fmt.Errorf("error setting versions of transit key %q: %s", path, err)
+	}
+
+	return nil
+}
+
+// flattenTransitKeyVersions converts the keys of a transit key response
+// into a list ordered by version. Symmetric keys only report the creation
+// time of each version as a Unix timestamp, while asymmetric keys report
+// an object including the public key.
+func flattenTransitKeyVersions(raw interface{}) ([]map[string]interface{}, error) {
+	versionsI, _ := raw.(map[string]interface{})
+	versions := make([]map[string]interface{}, 0, len(versionsI))
+	for k, v := range versionsI {
+		number, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected version %q", k)
+		}
+		version := map[string]interface{}{
+			"version":       number,
+			"creation_time": "",
+			"public_key":    "",
+		}
+		switch info := v.(type) {
+		case json.Number:
+			if ts, err := info.Int64(); err == nil {
+				version["creation_time"] = time.Unix(ts, 0).UTC().Format(time.RFC3339)
+			}
+		case map[string]interface{}:
+			if s, ok := info["creation_time"].(string); ok {
+				version["creation_time"] = s
+			}
+			if s, ok := info["public_key"].(string); ok {
+				version["public_key"] = s
+			}
+		}
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i]["version"].(int) < versions[j]["version"].(int)
+	})
+	return versions, nil
+}
+
+func transitSecretBackendKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting transit key %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting transit key %q, deletion_allowed must be set first: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func transitSecretBackendKeyRotationResource() *schema.Resource {
+	return &schema.Resource{
+		Create: transitSecretBackendKeyRotationCreate,
+		Delete: transitSecretBackendKeyRotationDelete,
+		Read:   transitSecretBackendKeyRotationRead,
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the transit secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the key to rotate.",
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that rotate the key again when changed.",
+			},
+
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the key created by the rotation.",
+			},
+		},
+	}
+}
+
+func transitSecretBackendKeyRotationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/keys/" + d.Get("name").(string)
+
+	log.Printf("[DEBUG] Rotating transit key %q", path)
+	if _, err := client.Logical().Write(path+"/rotate", nil); err != nil {
+		return fmt.Errorf("error rotating transit key %q: %s", path, err)
+	}
+
+	// Older versions of Vault don't return the key from the rotation, so
+	// the new version is read back.
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading rotated transit key %q: %s", path, err)
+	}
+	if secret == nil {
+		return fmt.Errorf("transit key %q not found after rotation", path)
+	}
+
+	d.SetId(path + "/rotate")
+	d.Set("version", intFromResponse(secret.Data["latest_version"]))
+
+	return transitSecretBackendKeyRotationRead(d, meta)
+}
+
+func transitSecretBackendKeyRotationRead(d *schema.ResourceData, meta interface{}) error {
+	// A rotation is an action rather than an object, so there is nothing
+	// in Vault to refresh it from.
+	return nil
+}
+
+func transitSecretBackendKeyRotationDelete(d *schema.ResourceData, meta interface{}) error {
+	// Key versions can't be removed, so destroying the resource only
+	// removes it from the state.
+	log.Printf("[DEBUG] Removing rotation of transit key %q from state", d.Id())
+
+	return nil
+}
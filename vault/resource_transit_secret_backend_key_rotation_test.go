@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestTransitSecretBackendKeyRotation(t *testing.T) {
+	backend := acctest.RandomWithPrefix("transit")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testTransitSecretBackendKeyRotationConfig(backend, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key_rotation.test", "id", backend+"/keys/test/rotate"),
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key_rotation.test", "version", "2"),
+				),
+			},
+			{
+				Config: testTransitSecretBackendKeyRotationConfig(backend, "2"),
+				Check:  resource.TestCheckResourceAttr("vault_transit_secret_backend_key_rotation.test", "version", "3"),
+			},
+		},
+	})
+}
+
+func testTransitSecretBackendKeyRotationConfig(backend, trigger string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "transit" {
+	path = "%s"
+	type = "transit"
+}
+
+resource "vault_transit_secret_backend_key" "test" {
+	backend = "${vault_mount.transit.path}"
+	name = "test"
+	deletion_allowed = true
+}
+
+resource "vault_transit_secret_backend_key_rotation" "test" {
+	backend = "${vault_transit_secret_backend_key.test.backend}"
+	name = "${vault_transit_secret_backend_key.test.name}"
+	triggers {
+		rotation = "%s"
+	}
+}
+`, backend, trigger)
+}
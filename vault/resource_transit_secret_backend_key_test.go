@@ -0,0 +1,203 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestFlattenTransitKeyVersions(t *testing.T) {
+	versions, err := flattenTransitKeyVersions(map[string]interface{}{
+		"2": json.Number("1700000000"),
+		"1": map[string]interface{}{
+			"creation_time": "2023-01-01T00:00:00Z",
+			"public_key":    "ssh-ed25519 AAAA",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %v", versions)
+	}
+	if versions[0]["version"] != 1 || versions[0]["public_key"] != "ssh-ed25519 AAAA" || versions[0]["creation_time"] != "2023-01-01T00:00:00Z" {
+		t.Errorf("unexpected first version %v", versions[0])
+	}
+	if versions[1]["version"] != 2 || versions[1]["creation_time"] != "2023-11-14T22:13:20Z" {
+		t.Errorf("unexpected second version %v", versions[1])
+	}
+
+	if _, err := flattenTransitKeyVersions(map[string]interface{}{"latest": json.Number("1")}); err == nil {
+		t.Errorf("expected an error for a non numeric version")
+	}
+}
+
+func TestTransitSecretBackendKey(t *testing.T) {
+	backend := acctest.RandomWithPrefix("transit")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testTransitSecretBackendKeyConfig(backend),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "latest_version", "1"),
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "keys.#", "1"),
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "auto_rotate_period", "86400"),
+				),
+			},
+			{
+				// Rotations made outside of Terraform show up on refresh.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					if _, err := client.Logical().Write(backend+"/keys/test/rotate", nil); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testTransitSecretBackendKeyConfig(backend),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "latest_version", "2"),
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "keys.#", "2"),
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "keys.1.version", "2"),
+				),
+			},
+			{
+				ResourceName:      "vault_transit_secret_backend_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// So do changes of the minimum encryption version.
+				PreConfig: func() {
+					client := testProvider.Meta().(*api.Client)
+					_, err := client.Logical().Write(backend+"/keys/test/config", map[string]interface{}{
+						"min_encryption_version": 2,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testTransitSecretBackendKeyConfig(backend),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testTransitSecretBackendKeyConfig(backend string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "transit" {
+	path = "%s"
+	type = "transit"
+}
+
+resource "vault_transit_secret_backend_key" "test" {
+	backend = "${vault_mount.transit.path}"
+	name = "test"
+	deletion_allowed = true
+	auto_rotate_period = 86400
+	min_encryption_version = 0
+}
+`, backend)
+}
+
+func TestTransitSecretBackendKey_managedKeyValidation(t *testing.T) {
+	backend := acctest.RandomWithPrefix("transit")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testTransitSecretBackendKeyManagedConfig(backend, "managed_key", ""),
+				ExpectError: regexp.MustCompile("needs managed_key_name or managed_key_id"),
+			},
+			{
+				Config:      testTransitSecretBackendKeyManagedConfig(backend, "aes256-gcm96", `managed_key_name = "hsm"`),
+				ExpectError: regexp.MustCompile("only supported with type managed_key"),
+			},
+			{
+				Config:      testTransitSecretBackendKeyManagedConfig(backend, "managed_key", `managed_key_name = "missing"`),
+				ExpectError: regexp.MustCompile("managed key"),
+			},
+			{
+				Config:      testTransitSecretBackendKeyManagedConfig(backend, "managed_key", `managed_key_id = "00000000-0000-0000-0000-000000000000"`),
+				ExpectError: regexp.MustCompile("managed key"),
+			},
+		},
+	})
+}
+
+func TestTransitSecretBackendKey_managedKey(t *testing.T) {
+	// Managed keys are only available in Vault Enterprise, and need an
+	// HSM or KMS to be configured under sys/managed-keys.
+	name := os.Getenv("TEST_VAULT_MANAGED_KEY_NAME")
+	if name == "" {
+		t.Skip("TEST_VAULT_MANAGED_KEY_NAME not set")
+	}
+
+	backend := acctest.RandomWithPrefix("transit")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testTransitSecretBackendKeyManagedAllowedConfig(backend, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "type", "managed_key"),
+					resource.TestCheckResourceAttr("vault_transit_secret_backend_key.test", "managed_key_name", name),
+				),
+			},
+		},
+	})
+}
+
+func testTransitSecretBackendKeyManagedConfig(backend, keyType, managedKey string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "transit" {
+	path = "%s"
+	type = "transit"
+}
+
+resource "vault_transit_secret_backend_key" "test" {
+	backend = "${vault_mount.transit.path}"
+	name = "test"
+	type = "%s"
+	deletion_allowed = true
+	%s
+}
+`, backend, keyType, managedKey)
+}
+
+// testTransitSecretBackendKeyManagedAllowedConfig also allows the mount to
+// use the managed key, which Vault requires before keys can reference it.
+func testTransitSecretBackendKeyManagedAllowedConfig(backend, name string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "transit" {
+	path = "%s"
+	type = "transit"
+}
+
+resource "vault_generic_endpoint" "tune" {
+	path = "sys/mounts/${vault_mount.transit.path}/tune"
+	data_json = "{\"allowed_managed_keys\": [\"%s\"]}"
+	disable_read = true
+	disable_delete = true
+}
+
+resource "vault_transit_secret_backend_key" "test" {
+	backend = "${vault_mount.transit.path}"
+	name = "test"
+	type = "managed_key"
+	managed_key_name = "%s"
+	deletion_allowed = true
+	depends_on = ["vault_generic_endpoint.tune"]
+}
+`, backend, name, name)
+}
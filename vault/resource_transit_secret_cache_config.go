@@ -0,0 +1,105 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+func transitSecretCacheConfigResource() *schema.Resource {
+	return &schema.Resource{
+		Create: transitSecretCacheConfigWrite,
+		Update: transitSecretCacheConfigWrite,
+		Delete: transitSecretCacheConfigDelete,
+		Read:   transitSecretCacheConfigRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the transit secret backend.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"size": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Number of keys kept in the cache. 0 means an unlimited cache.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if value := v.(int); value != 0 && value < 10 {
+						errs = append(errs, fmt.Errorf("%s must be 0 or at least 10, got %d", k, value))
+					}
+					return
+				},
+			},
+		},
+	}
+}
+
+func transitSecretCacheConfigWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	backend := strings.Trim(d.Get("backend").(string), "/")
+	path := backend + "/cache-config"
+
+	log.Printf("[DEBUG] Writing transit cache config to %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"size": d.Get("size").(int),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing transit cache config %q: %s", path, err)
+	}
+
+	d.SetId(backend)
+
+	return transitSecretCacheConfigRead(d, meta)
+}
+
+func transitSecretCacheConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id() + "/cache-config"
+
+	log.Printf("[DEBUG] Reading transit cache config from %q", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading transit cache config %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Transit cache config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", d.Id())
+	d.Set("size", intFromResponse(secret.Data["size"]))
+
+	return nil
+}
+
+func transitSecretCacheConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id() + "/cache-config"
+
+	// The cache config can't be removed, so restore the default
+	// unlimited cache instead.
+	log.Printf("[DEBUG] Resetting transit cache config %q", path)
+	_, err := client.Logical().Write(path, map[string]interface{}{
+		"size": 0,
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting transit cache config %q: %s", path, err)
+	}
+
+	return nil
+}
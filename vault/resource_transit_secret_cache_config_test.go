@@ -0,0 +1,46 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestTransitSecretCacheConfig(t *testing.T) {
+	backend := acctest.RandomWithPrefix("transit")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testTransitSecretCacheConfig(backend, 500),
+				Check:  resource.TestCheckResourceAttr("vault_transit_secret_cache_config.test", "size", "500"),
+			},
+			{
+				Config: testTransitSecretCacheConfig(backend, 1000),
+				Check:  resource.TestCheckResourceAttr("vault_transit_secret_cache_config.test", "size", "1000"),
+			},
+			{
+				ResourceName:      "vault_transit_secret_cache_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testTransitSecretCacheConfig(backend string, size int) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "transit" {
+	path = "%s"
+	type = "transit"
+}
+
+resource "vault_transit_secret_cache_config" "test" {
+	backend = "${vault_mount.transit.path}"
+	size = %d
+}
+`, backend, size)
+}
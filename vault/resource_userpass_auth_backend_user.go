@@ -0,0 +1,177 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+var userpassAuthBackendUserFromPathRegex = regexp.MustCompile("^auth/(.+)/users/([^/]+)$")
+
+func userpassAuthBackendUserResource() *schema.Resource {
+	return &schema.Resource{
+		Create: userpassAuthBackendUserWrite,
+		Update: userpassAuthBackendUserWrite,
+		Delete: userpassAuthBackendUserDelete,
+		Read:   userpassAuthBackendUserRead,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "userpass",
+				Description: "Path of the userpass auth backend the user belongs to.",
+				StateFunc: func(v interface{}) string {
+					return strings.Trim(v.(string), "/")
+				},
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the user.",
+				StateFunc: func(v interface{}) string {
+					// Vault stores usernames in lowercase.
+					return strings.ToLower(v.(string))
+				},
+			},
+
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the user, only written on create and when password_version changes if it is set.",
+				// Vault never returns the password, so with password_version
+				// changes to it are only applied along with a new version.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old != "" && d.Get("password_version").(int) != 0 && !d.HasChange("password_version")
+				},
+			},
+
+			"password_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Version of the password, which is written again when changed.",
+			},
+
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Policies of the tokens issued for the user.",
+			},
+
+			"token_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default TTL of the tokens issued for the user in seconds.",
+			},
+
+			"token_max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum TTL of the tokens issued for the user in seconds.",
+			},
+
+			"token_bound_cidrs": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "CIDR blocks of the addresses allowed to use the tokens issued for the user.",
+			},
+		},
+	}
+}
+
+func userpassAuthBackendUserPath(backend, username string) string {
+	return "auth/" + strings.Trim(backend, "/") + "/users/" + strings.ToLower(username)
+}
+
+func userpassAuthBackendUserWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := userpassAuthBackendUserPath(d.Get("backend").(string), d.Get("username").(string))
+
+	data := map[string]interface{}{
+		"policies":    d.Get("policies").(*schema.Set).List(),
+		"ttl":         d.Get("token_ttl").(int),
+		"max_ttl":     d.Get("token_max_ttl").(int),
+		"bound_cidrs": d.Get("token_bound_cidrs").(*schema.Set).List(),
+	}
+	// Leaving the password out of updates keeps the current one.
+	if d.IsNewResource() || d.HasChange("password") || d.HasChange("password_version") {
+		data["password"] = d.Get("password").(string)
+	}
+
+	log.Printf("[DEBUG] Writing userpass user %q to Vault", path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing userpass user %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return userpassAuthBackendUserRead(d, meta)
+}
+
+func userpassAuthBackendUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	res := userpassAuthBackendUserFromPathRegex.FindStringSubmatch(path)
+	if res == nil {
+		return fmt.Errorf("invalid userpass user ID %q", path)
+	}
+
+	log.Printf("[DEBUG] Reading userpass user %q from Vault", path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return fmt.Errorf("error reading userpass user %q: %s", path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Userpass user %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("username", res[2])
+	d.Set("token_ttl", intFromResponse(tokenSetting(secret.Data, "token_ttl", "ttl")))
+	d.Set("token_max_ttl", intFromResponse(tokenSetting(secret.Data, "token_max_ttl", "max_ttl")))
+
+	sets := map[string]interface{}{
+		"policies":          tokenSetting(secret.Data, "token_policies", "policies"),
+		"token_bound_cidrs": tokenSetting(secret.Data, "token_bound_cidrs", "bound_cidrs"),
+	}
+	for k, v := range sets {
+		if err := d.Set(k, flattenStringList(v)); err != nil {
+			return fmt.Errorf("error setting %s of userpass user %q: %s", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+func userpassAuthBackendUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting userpass user %q from Vault", path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting userpass user %q: %s", path, err)
+	}
+
+	return nil
+}
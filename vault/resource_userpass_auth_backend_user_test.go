@@ -0,0 +1,99 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/vault/api"
+)
+
+func TestUserpassAuthBackendUser(t *testing.T) {
+	backend := acctest.RandomWithPrefix("userpass")
+	resource.Test(t, resource.TestCase{
+		Providers:    testProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testUserpassAuthBackendUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUserpassAuthBackendUserConfig(backend, "first-password", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vault_userpass_auth_backend_user.test", "id", "auth/"+backend+"/users/alice"),
+					resource.TestCheckResourceAttr("vault_userpass_auth_backend_user.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("vault_userpass_auth_backend_user.test", "token_ttl", "3600"),
+					testUserpassAuthBackendUserLogin(backend, "first-password"),
+				),
+			},
+			{
+				// The password is only written again with a new version.
+				Config:   testUserpassAuthBackendUserConfig(backend, "second-password", 1),
+				PlanOnly: true,
+			},
+			{
+				Config: testUserpassAuthBackendUserConfig(backend, "second-password", 2),
+				Check:  testUserpassAuthBackendUserLogin(backend, "second-password"),
+			},
+			{
+				ResourceName:            "vault_userpass_auth_backend_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "password_version"},
+			},
+		},
+	})
+}
+
+func testUserpassAuthBackendUserLogin(backend, password string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(*api.Client)
+		path := "auth/" + backend + "/login/alice"
+		secret, err := client.Logical().Write(path, map[string]interface{}{
+			"password": password,
+		})
+		if err != nil {
+			return fmt.Errorf("error logging in with %q: %s", path, err)
+		}
+		if secret == nil || secret.Auth == nil {
+			return fmt.Errorf("no token returned by %q", path)
+		}
+		return nil
+	}
+}
+
+func testUserpassAuthBackendUserDestroy(s *terraform.State) error {
+	client := testProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_userpass_auth_backend_user" {
+			continue
+		}
+		secret, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking for userpass user %q: %s", rs.Primary.ID, err)
+		}
+		if secret != nil {
+			return fmt.Errorf("userpass user %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testUserpassAuthBackendUserConfig(backend, password string, version int) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "userpass" {
+	type = "userpass"
+	path = "%s"
+}
+
+resource "vault_userpass_auth_backend_user" "test" {
+	backend = "${vault_auth_backend.userpass.path}"
+	username = "alice"
+	password = "%s"
+	password_version = %d
+	policies = ["dev", "prod"]
+	token_ttl = 3600
+}
+`, backend, password, version)
+}
@@ -0,0 +1,99 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// Sentinel policies are only available in Vault Enterprise. Endpoint
+// governing policies (EGPs) and role governing policies (RGPs) are written
+// the same way, except that EGPs also list the paths they apply to.
+
+func sentinelPolicySchema(kind string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: fmt.Sprintf("Name of the %s.", kind),
+		},
+
+		"policy": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The Sentinel policy document.",
+		},
+
+		"enforcement_level": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Enforcement level of the policy: advisory, soft-mandatory or hard-mandatory.",
+			ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+				switch v.(string) {
+				case "advisory", "soft-mandatory", "hard-mandatory":
+				default:
+					errs = append(errs, fmt.Errorf("%s must be one of advisory, soft-mandatory or hard-mandatory, got %q", k, v))
+				}
+				return
+			},
+		},
+	}
+}
+
+// sentinelPolicyWrite writes the policy of the given type, egp or rgp, with
+// the extra data of its type.
+func sentinelPolicyWrite(d *schema.ResourceData, client *api.Client, policyType, kind string, data map[string]interface{}) error {
+	name := d.Get("name").(string)
+	path := "sys/policies/" + policyType + "/" + name
+
+	data["policy"] = d.Get("policy").(string)
+	data["enforcement_level"] = d.Get("enforcement_level").(string)
+
+	log.Printf("[DEBUG] Writing %s %q to Vault", kind, name)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing %s %q: %s", kind, name, err)
+	}
+
+	d.SetId(name)
+
+	return nil
+}
+
+// sentinelPolicyRead reads the policy of the given type into d, returning
+// its data, or nil if it no longer exists.
+func sentinelPolicyRead(d *schema.ResourceData, client *api.Client, policyType, kind string) (map[string]interface{}, error) {
+	name := d.Id()
+	path := "sys/policies/" + policyType + "/" + name
+
+	log.Printf("[DEBUG] Reading %s %q from Vault", kind, name)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s %q: %s", kind, name, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] %s %q not found, removing from state", kind, name)
+		d.SetId("")
+		return nil, nil
+	}
+
+	d.Set("name", name)
+	d.Set("policy", secret.Data["policy"])
+	d.Set("enforcement_level", secret.Data["enforcement_level"])
+
+	return secret.Data, nil
+}
+
+func sentinelPolicyDelete(d *schema.ResourceData, client *api.Client, policyType, kind string) error {
+	name := d.Id()
+	path := "sys/policies/" + policyType + "/" + name
+
+	log.Printf("[DEBUG] Deleting %s %q from Vault", kind, name)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting %s %q: %s", kind, name, err)
+	}
+
+	return nil
+}
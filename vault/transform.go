@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/vault/api"
+)
+
+// The transform secret backend is only available in Vault Enterprise. Its
+// alphabets, templates, transformations and roles are all written to
+// <backend>/<kind>/<name>, which is the ID of their resources.
+
+var transformIDRegex = regexp.MustCompile("^(.+)/(alphabet|template|transformation|role)/([^/]+)$")
+
+// transformSchema returns the arguments identifying an object of the given
+// kind in a transform secret backend.
+func transformSchema(kind string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"backend": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Path of the transform secret backend.",
+			StateFunc: func(v interface{}) string {
+				return strings.Trim(v.(string), "/")
+			},
+		},
+
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: fmt.Sprintf("Name of the %s.", kind),
+		},
+	}
+}
+
+func transformPath(backend, kind, name string) string {
+	return strings.Trim(backend, "/") + "/" + kind + "/" + strings.Trim(name, "/")
+}
+
+func transformWrite(d *schema.ResourceData, client *api.Client, kind string, data map[string]interface{}) error {
+	path := transformPath(d.Get("backend").(string), kind, d.Get("name").(string))
+
+	log.Printf("[DEBUG] Writing transform %s %q to Vault", kind, path)
+	if _, err := client.Logical().Write(path, data); err != nil {
+		return fmt.Errorf("error writing transform %s %q: %s", kind, path, err)
+	}
+
+	d.SetId(path)
+
+	return nil
+}
+
+// transformRead reads the object of the given kind into d, returning its
+// data, or nil if it no longer exists.
+func transformRead(d *schema.ResourceData, client *api.Client, kind string) (map[string]interface{}, error) {
+	path := d.Id()
+
+	res := transformIDRegex.FindStringSubmatch(path)
+	if res == nil || res[2] != kind {
+		return nil, fmt.Errorf("invalid transform %s ID %q", kind, path)
+	}
+
+	log.Printf("[DEBUG] Reading transform %s %q from Vault", kind, path)
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading transform %s %q: %s", kind, path, err)
+	}
+	if secret == nil {
+		log.Printf("[WARN] Transform %s %q not found, removing from state", kind, path)
+		d.SetId("")
+		return nil, nil
+	}
+
+	d.Set("backend", res[1])
+	d.Set("name", res[3])
+
+	return secret.Data, nil
+}
+
+func transformDelete(d *schema.ResourceData, client *api.Client, kind string) error {
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting transform %s %q from Vault", kind, path)
+	if _, err := client.Logical().Delete(path); err != nil {
+		return fmt.Errorf("error deleting transform %s %q: %s", kind, path, err)
+	}
+
+	return nil
+}
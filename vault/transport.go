@@ -0,0 +1,71 @@
+package vault
+
+import (
+	"log"
+	"net/http"
+)
+
+// vaultTransport is the HTTP transport used for all requests to Vault. It
+// adds the provider-wide request headers and handles Vault responses that
+// can be recovered from by resending the request.
+type vaultTransport struct {
+	base http.RoundTripper
+
+	// headers are set on every request.
+	headers http.Header
+}
+
+func newVaultTransport(base http.RoundTripper) *vaultTransport {
+	return &vaultTransport{
+		base:    base,
+		headers: http.Header{},
+	}
+}
+
+func (t *vaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request they were given.
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header[k] = v
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	// Performance standby nodes answer with 412 when they haven't caught
+	// up with a write yet. The active node always has the latest state,
+	// so resend the request there.
+	if resp.StatusCode == http.StatusPreconditionFailed && req.Header.Get("X-Vault-Forward") == "" {
+		retry, ok := rewindRequest(req)
+		if !ok {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		log.Printf("[DEBUG] Stale read of %s from performance standby, forwarding to the active node", req.URL.Path)
+		retry.Header.Set("X-Vault-Forward", "active-node")
+		return t.base.RoundTrip(retry)
+	}
+
+	return resp, nil
+}
+
+// rewindRequest returns a copy of req that can be sent again, or false if
+// its body can't be replayed.
+func rewindRequest(req *http.Request) (*http.Request, bool) {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	retry.Body = body
+	return retry, true
+}
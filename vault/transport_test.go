@@ -0,0 +1,71 @@
+package vault
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVaultTransport_staleReadForwarded(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get("X-Vault-Forward") != "active-node" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if string(body) != `{"zip":"zap"}` {
+			t.Errorf("body was not replayed, got %q", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newVaultTransport(http.DefaultTransport)}
+	req, err := http.NewRequest("PUT", server.URL+"/v1/secret/foo", strings.NewReader(`{"zip":"zap"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d; want %d", resp.StatusCode, http.StatusOK)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests; want 2", requests)
+	}
+}
+
+func TestVaultTransport_headers(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get("X-Vault-Forward"); got != "active-node" {
+			t.Errorf("X-Vault-Forward is %q; want %q", got, "active-node")
+		}
+		w.WriteHeader(http.StatusPreconditionFailed)
+	}))
+	defer server.Close()
+
+	transport := newVaultTransport(http.DefaultTransport)
+	transport.headers.Set("X-Vault-Forward", "active-node")
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL + "/v1/secret/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// Requests already sent to the active node aren't retried.
+	if requests != 1 {
+		t.Errorf("got %d requests; want 1", requests)
+	}
+}
@@ -0,0 +1,201 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// toStringArray converts a list of interface{} values as returned by
+// ResourceData.Get for a TypeList of strings into a []string.
+func toStringArray(input []interface{}) []string {
+	output := make([]string, len(input))
+	for i, item := range input {
+		output[i] = item.(string)
+	}
+	return output
+}
+
+// flattenStringList normalizes a list-valued field from a Vault response.
+// Depending on the Vault version, such fields are returned either as a
+// JSON array or as a single comma-separated string.
+func flattenStringList(raw interface{}) []string {
+	switch v := raw.(type) {
+	case []interface{}:
+		output := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				output = append(output, s)
+			}
+		}
+		return output
+	case string:
+		if v == "" {
+			return []string{}
+		}
+		output := []string{}
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				output = append(output, item)
+			}
+		}
+		return output
+	default:
+		return []string{}
+	}
+}
+
+// intFromResponse converts a numeric field from a Vault response into an
+// int. Responses are decoded with json.Number, but values built by hand
+// may hold plain Go numbers instead.
+func intFromResponse(raw interface{}) int {
+	switch v := raw.(type) {
+	case json.Number:
+		i, err := v.Int64()
+		if err != nil {
+			f, _ := v.Float64()
+			return int(f)
+		}
+		return int(i)
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case int64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// floatFromResponse converts a numeric field from a Vault response into a
+// float64, like intFromResponse.
+func floatFromResponse(raw interface{}) float64 {
+	switch v := raw.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+// durationSecondsFromResponse converts a duration field from a Vault
+// response into seconds. Depending on the backend and Vault version, such
+// fields are returned either as a number of seconds or as a Go duration
+// string, e.g. "1h0m0s".
+func durationSecondsFromResponse(raw interface{}) int {
+	if s, ok := raw.(string); ok {
+		if d, err := time.ParseDuration(s); err == nil {
+			return int(d.Seconds())
+		}
+		if i, err := strconv.Atoi(s); err == nil {
+			return i
+		}
+		return 0
+	}
+	return intFromResponse(raw)
+}
+
+// tokenSetting returns the token setting of an auth backend role from a
+// response. Vault 1.2 renamed the token settings shared by all auth
+// backends, returning them under both names for some time, and older
+// versions only know the legacy name.
+func tokenSetting(data map[string]interface{}, key, legacyKey string) interface{} {
+	if v, ok := data[key]; ok {
+		return v
+	}
+	return data[legacyKey]
+}
+
+// namespacedPath returns the API path addressing path within the given
+// namespace. Vault resolves a leading namespace in the request path the
+// same way as the X-Vault-Namespace header, relative to the namespace of
+// the token, so this targets a child namespace for a single request.
+func namespacedPath(namespace, path string) string {
+	namespace = strings.Trim(namespace, "/")
+	if namespace == "" {
+		return path
+	}
+	return namespace + "/" + strings.TrimLeft(path, "/")
+}
+
+// readWithQuery reads path like Logical().Read, adding the given query
+// parameters to the request, e.g. version for KV version 2 secrets. The
+// vendored client has no way to pass them to Logical().Read.
+func readWithQuery(client *api.Client, path string, query map[string]interface{}) (*api.Secret, error) {
+	if len(query) == 0 {
+		return client.Logical().Read(path)
+	}
+
+	r := client.NewRequest("GET", "/v1/"+path)
+	for k, v := range query {
+		r.Params.Set(k, fmt.Sprint(v))
+	}
+	resp, err := client.RawRequest(r)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if resp != nil && resp.StatusCode == 404 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ParseSecret(resp.Body)
+}
+
+// writeWrapped writes data to path like Logical().Write, asking Vault to
+// wrap the response in a token valid for wrapTTL, which is returned in its
+// WrapInfo. The vendored client only supports wrapping for every request
+// at once, through SetWrappingLookupFunc.
+func writeWrapped(client *api.Client, path string, data map[string]interface{}, wrapTTL string) (*api.Secret, error) {
+	r := client.NewRequest("PUT", "/v1/"+path)
+	if err := r.SetJSONBody(data); err != nil {
+		return nil, err
+	}
+	return requestWrapped(client, r, wrapTTL)
+}
+
+// readWrapped reads path like readWithQuery, asking Vault to wrap the
+// response like writeWrapped.
+func readWrapped(client *api.Client, path string, query map[string]interface{}, wrapTTL string) (*api.Secret, error) {
+	r := client.NewRequest("GET", "/v1/"+path)
+	for k, v := range query {
+		r.Params.Set(k, fmt.Sprint(v))
+	}
+	return requestWrapped(client, r, wrapTTL)
+}
+
+// requestWrapped makes the request with the given wrap TTL. Like with
+// Logical().Read, reading a path that doesn't exist isn't an error.
+func requestWrapped(client *api.Client, r *api.Request, wrapTTL string) (*api.Secret, error) {
+	r.WrapTTL = wrapTTL
+
+	resp, err := client.RawRequest(r)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if resp != nil && resp.StatusCode == 404 && r.Method == "GET" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, nil
+	}
+
+	return api.ParseSecret(resp.Body)
+}
@@ -0,0 +1,198 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+)
+
+func TestFlattenStringList(t *testing.T) {
+	cases := []struct {
+		input interface{}
+		want  []string
+	}{
+		{[]interface{}{"a", "b"}, []string{"a", "b"}},
+		{[]interface{}{"a", ""}, []string{"a"}},
+		{"a, b,c", []string{"a", "b", "c"}},
+		{"", []string{}},
+		{nil, []string{}},
+	}
+
+	for _, tc := range cases {
+		if got := flattenStringList(tc.input); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("flattenStringList(%#v) = %#v; want %#v", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestIntFromResponse(t *testing.T) {
+	cases := []struct {
+		input interface{}
+		want  int
+	}{
+		{json.Number("3600"), 3600},
+		{json.Number("1.5"), 1},
+		{float64(42), 42},
+		{7, 7},
+		{"nope", 0},
+		{nil, 0},
+	}
+
+	for _, tc := range cases {
+		if got := intFromResponse(tc.input); got != tc.want {
+			t.Errorf("intFromResponse(%#v) = %d; want %d", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestFloatFromResponse(t *testing.T) {
+	cases := []struct {
+		input interface{}
+		want  float64
+	}{
+		{json.Number("1.5"), 1.5},
+		{json.Number("100"), 100},
+		{float64(0.25), 0.25},
+		{7, 7},
+		{"nope", 0},
+		{nil, 0},
+	}
+
+	for _, tc := range cases {
+		if got := floatFromResponse(tc.input); got != tc.want {
+			t.Errorf("floatFromResponse(%#v) = %v; want %v", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestDurationSecondsFromResponse(t *testing.T) {
+	cases := []struct {
+		input interface{}
+		want  int
+	}{
+		{json.Number("3600"), 3600},
+		{"1h0m0s", 3600},
+		{"90s", 90},
+		{"0s", 0},
+		{"120", 120},
+		{"nope", 0},
+		{nil, 0},
+	}
+
+	for _, tc := range cases {
+		if got := durationSecondsFromResponse(tc.input); got != tc.want {
+			t.Errorf("durationSecondsFromResponse(%#v) = %d; want %d", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestNamespacedPath(t *testing.T) {
+	cases := []struct {
+		namespace, path, want string
+	}{
+		{"", "secret/foo", "secret/foo"},
+		{"team", "secret/foo", "team/secret/foo"},
+		{"/team/a/", "/secret/foo", "team/a/secret/foo"},
+	}
+
+	for _, tc := range cases {
+		if got := namespacedPath(tc.namespace, tc.path); got != tc.want {
+			t.Errorf("namespacedPath(%q, %q) = %q; want %q", tc.namespace, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestReadWithQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/secret/data/foo":
+			if got := r.URL.Query().Get("version"); got != "2" {
+				t.Errorf("version is %q; want %q", got, "2")
+			}
+			w.Write([]byte(`{"data": {"zip": "zap"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := api.DefaultConfig()
+	config.Address = server.URL
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, err := readWithQuery(client, "secret/data/foo", map[string]interface{}{"version": "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret == nil || secret.Data["zip"] != "zap" {
+		t.Errorf("unexpected secret %#v", secret)
+	}
+
+	secret, err = readWithQuery(client, "secret/data/missing", map[string]interface{}{"version": "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret != nil {
+		t.Errorf("expected no secret for a missing path, got %#v", secret)
+	}
+}
+
+func TestWriteWrapped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Vault-Wrap-TTL"); got != "60s" {
+			t.Errorf("wrap TTL is %q; want %q", got, "60s")
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if body["foo"] != "bar" {
+			t.Errorf("unexpected body %v", body)
+		}
+		w.Write([]byte(`{"wrap_info": {"token": "wrapping-token", "ttl": 60, "wrapped_accessor": "accessor"}}`))
+	}))
+	defer server.Close()
+
+	config := api.DefaultConfig()
+	config.Address = server.URL
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, err := writeWrapped(client, "auth/approle/role/foo/secret-id", map[string]interface{}{"foo": "bar"}, "60s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret == nil || secret.WrapInfo == nil {
+		t.Fatalf("expected a wrapped response, got %#v", secret)
+	}
+	if secret.WrapInfo.Token != "wrapping-token" || secret.WrapInfo.WrappedAccessor != "accessor" {
+		t.Errorf("unexpected wrap info %#v", secret.WrapInfo)
+	}
+}
+
+func TestTokenSetting(t *testing.T) {
+	data := map[string]interface{}{
+		"token_policies": []interface{}{"new"},
+		"policies":       []interface{}{"old"},
+		"period":         json.Number("60"),
+	}
+
+	if got := tokenSetting(data, "token_policies", "policies"); !reflect.DeepEqual(got, []interface{}{"new"}) {
+		t.Errorf("token_policies = %v; want the new setting", got)
+	}
+	if got := intFromResponse(tokenSetting(data, "token_period", "period")); got != 60 {
+		t.Errorf("token_period = %v; want the legacy setting", got)
+	}
+	if got := tokenSetting(data, "token_ttl", "ttl"); got != nil {
+		t.Errorf("token_ttl = %v; want nil", got)
+	}
+}